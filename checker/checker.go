@@ -0,0 +1,169 @@
+package checker
+
+import (
+	"os"
+	"sort"
+
+	"github.com/Eclalang/Ecla/errorHandler"
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+	"github.com/Eclalang/Ecla/interpreter/eclaType"
+	"github.com/Eclalang/Ecla/lexer"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// Checker checks the types of a parsed file without executing it.
+type Checker struct {
+	Errors     []errorHandler.Error
+	scope      *scope
+	structs    map[string]*structInfo
+	interfaces map[string]*eclaDecl.InterfaceDecl
+	// interfaceMethods holds the prototypes of the methods of each interface
+	interfaceMethods map[string]map[string]parser.FunctionPrototype
+	functions        []*function
+	pending          []func()
+}
+
+// structInfo holds the declaration of a struct and the prototypes of its methods.
+type structInfo struct {
+	decl    *eclaDecl.StructDecl
+	methods map[string]parser.FunctionPrototype
+}
+
+// method is the type of a method bound to a struct declaration.
+type method string
+
+func (m method) GetType() string {
+	return string(m)
+}
+
+// New returns a new Checker.
+func New() *Checker {
+	c := &Checker{
+		Errors:     []errorHandler.Error{},
+		scope:      newScope(nil),
+		structs:    make(map[string]*structInfo),
+		interfaces: make(map[string]*eclaDecl.InterfaceDecl),
+
+		interfaceMethods: make(map[string]map[string]parser.FunctionPrototype),
+	}
+	errorDecl := &eclaDecl.StructDecl{Fields: eclaType.ErrorDecl.Fields, Order: eclaType.ErrorDecl.Order, Name: eclaType.ErrorDecl.Name, Methods: make(map[string]eclaDecl.Method)}
+	c.structs[parser.Error] = &structInfo{decl: errorDecl, methods: make(map[string]parser.FunctionPrototype)}
+	return c
+}
+
+// DeclareFunction declares a function implemented outside of the checked code,
+// like a built-in function registered on an interpreter.Env, each prototype is an overload.
+func (c *Checker) DeclareFunction(name string, prototypes ...parser.FunctionPrototype) {
+	for _, prototype := range prototypes {
+		c.scope.addFunction(name, prototype)
+	}
+}
+
+// Check checks the file and returns every error found, ordered by position.
+func Check(file *parser.File) []errorHandler.Error {
+	c := New()
+	c.CheckFile(file)
+	return c.Errors
+}
+
+// CheckCode lexes, parses and checks the code, the syntax errors are returned with the type errors of the statements parsed without errors.
+func CheckCode(code string) []errorHandler.Error {
+	pars := parser.Parser{Tokens: lexer.Lexer(code), ErrorHandler: errorHandler.NewHandler()}
+	file := pars.Parse()
+	c := New()
+	c.Errors = append(c.Errors, pars.Errors()...)
+	c.CheckFile(file)
+	return c.Errors
+}
+
+// CheckPath reads the file at path and checks it.
+func CheckPath(path string) ([]errorHandler.Error, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return CheckCode(string(code)), nil
+}
+
+// CheckFile checks the file, the errors are added to c.Errors.
+func (c *Checker) CheckFile(file *parser.File) {
+	if file == nil || file.ParseTree == nil {
+		return
+	}
+	c.declare(file.ParseTree.Operations)
+	for _, node := range file.ParseTree.Operations {
+		c.checkNode(node)
+	}
+	// function bodies are checked once every top level declaration is known
+	for len(c.pending) > 0 {
+		next := c.pending[0]
+		c.pending = c.pending[1:]
+		next()
+	}
+	sort.SliceStable(c.Errors, func(i, j int) bool {
+		if c.Errors[i].Line != c.Errors[j].Line {
+			return c.Errors[i].Line < c.Errors[j].Line
+		}
+		return c.Errors[i].Col < c.Errors[j].Col
+	})
+}
+
+// declare registers the types, methods and functions declared at the top level of the file.
+func (c *Checker) declare(nodes []parser.Node) {
+	for _, node := range nodes {
+		switch node.(type) {
+		case parser.StructDecl:
+			tree := node.(parser.StructDecl)
+			c.structs[tree.Name] = &structInfo{decl: eclaDecl.NewStructDecl(tree), methods: make(map[string]parser.FunctionPrototype)}
+		case parser.InterfaceDecl:
+			c.addInterface(node.(parser.InterfaceDecl))
+		}
+	}
+	for _, node := range nodes {
+		switch node.(type) {
+		case parser.StructDecl:
+			tree := node.(parser.StructDecl)
+			for _, m := range tree.Methods {
+				c.addMethod(tree.Name, m)
+			}
+		case parser.FunctionDecl:
+			tree := node.(parser.FunctionDecl)
+			if tree.Receiver != nil {
+				c.addMethod(tree.Receiver.Type, tree)
+			} else {
+				c.scope.addFunction(tree.Name, tree.Prototype)
+			}
+		case parser.ImportStmt:
+			tree := node.(parser.ImportStmt)
+			c.scope.set(parser.GetPackageNameByPath(tree.ModulePath), &symbol{lib: true})
+		}
+	}
+}
+
+// addInterface declares the interface.
+func (c *Checker) addInterface(tree parser.InterfaceDecl) {
+	c.interfaces[tree.Name] = eclaDecl.NewInterfaceDecl(tree)
+	c.interfaceMethods[tree.Name] = make(map[string]parser.FunctionPrototype)
+	for _, m := range tree.Methods {
+		c.interfaceMethods[tree.Name][m.Name] = m.Prototype
+	}
+}
+
+// addMethod binds the method to the struct of its receiver.
+func (c *Checker) addMethod(name string, tree parser.FunctionDecl) {
+	info, ok := c.structs[name]
+	if !ok {
+		c.error(tree, "Cannot declare method "+tree.Name+" on unknown struct "+name)
+		return
+	}
+	if err := info.decl.AddMethod(tree.Name, method(eclaDecl.MethodType(tree.Prototype))); err != nil {
+		c.error(tree, err.Error())
+		return
+	}
+	info.methods[tree.Name] = tree.Prototype
+}
+
+// error reports an error on the code of span.
+func (c *Checker) error(span errorHandler.Span, msg string) {
+	c.Errors = append(c.Errors, errorHandler.NewError(span, msg, errorHandler.LevelError))
+}
@@ -0,0 +1,225 @@
+package checker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Eclalang/Ecla/errorHandler"
+	"github.com/Eclalang/Ecla/lexer"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// expectErrors checks that the code has the expected errors, each given by its line and a part of its message.
+func expectErrors(t *testing.T, code string, expected map[int]string) {
+	t.Helper()
+	errs := CheckCode(code)
+	if len(errs) != len(expected) {
+		t.Errorf("expected %d errors, got %d: %v", len(expected), len(errs), errs)
+		return
+	}
+	for _, err := range errs {
+		msg, ok := expected[err.Line]
+		if !ok {
+			t.Errorf("unexpected error at line %d: %s", err.Line, err.Msg)
+			continue
+		}
+		if !strings.Contains(err.Msg, msg) {
+			t.Errorf("expected error at line %d to contain %q, got %q", err.Line, msg, err.Msg)
+		}
+		if err.Level != errorHandler.LevelError {
+			t.Errorf("expected level %s, got %s", errorHandler.LevelError, err.Level)
+		}
+	}
+}
+
+func TestCheckValid(t *testing.T) {
+	code := `import "console";
+interface Shape {
+	function area() (float);
+}
+struct Rect {
+	w : float;
+	h : float;
+}
+function (r : Rect) area() (float) {
+	return r.w * r.h;
+}
+function total(shapes : []Shape) (float) {
+	var sum float = 0;
+	for (i, s range shapes) {
+		sum += s.area();
+	}
+	return sum;
+}
+function double(x : int) (int) {
+	return x * 2;
+}
+function double(x : string) (string) {
+	return x + x;
+}
+var r Rect = Rect{1.0, 2.0};
+var s Shape = r;
+var list []Shape = [s];
+var total2 float = total(list);
+var d int = double(2);
+var ds string = double("a");
+var m map[string]int = {"a": 1};
+var n int = m["a"];
+var greeting string = "${ds} is ${d + n}" + ` + "`\\raw`" + `;
+x := 1;
+x++;
+f := function(a : int) (int) {
+	return a + x;
+};
+try {
+	throw "fail";
+} catch (e) {
+	console.println(e.message);
+}
+console.println(f(d) + n);
+`
+	expectErrors(t, code, map[int]string{})
+}
+
+func TestCheckErrors(t *testing.T) {
+	code := `struct Point {
+	x : int;
+	y : int;
+}
+interface Named {
+	name : string;
+}
+function add(a : int, b : int) (int) {
+	return "a";
+}
+var a int = "hello";
+var b int = 1;
+b = 1.5;
+b = c;
+var s string = add(1, "2");
+var p Point = Point{1};
+p.z = 1;
+var n Named = p;
+var v bool = 1 - "a";
+var b int = 2;
+var i int = "${b}";
+var u string = "${unknown}";
+`
+	expectErrors(t, code, map[int]string{
+		9:  "Return type of function add is incorrect",
+		11: "cannot create variable of type int with value of type string",
+		13: "Cannot assign float to int",
+		14: "variable c not found",
+		15: "function add called with incorrect arguments",
+		16: "struct does not have the right number of fields",
+		17: "field z does not exist",
+		18: "struct Point does not implement interface Named: missing field name",
+		19: "invalid operation: int - string",
+		20: "Cannot reassign a variable b",
+		21: "cannot create variable of type int with value of type string",
+		22: "variable unknown not found",
+	})
+}
+
+func TestCheckFunctionBodies(t *testing.T) {
+	code := `function f() (int) {
+	return g();
+}
+function g() (string) {
+	return "g";
+}
+function h(x : int) {
+	var y int = x;
+	if (true) {
+		var y string = "shadow";
+		z := y + 1;
+	}
+	return;
+}
+`
+	expectErrors(t, code, map[int]string{
+		2: "Return type of function f is incorrect",
+	})
+}
+
+func TestCheckScopes(t *testing.T) {
+	code := `if (true) {
+	inner := 1;
+}
+var outer int = inner;
+for (var i int = 0, i < 2, i++) {
+	var j int = i;
+}
+var k int = i;
+`
+	expectErrors(t, code, map[int]string{
+		4: "variable inner not found",
+		8: "variable i not found",
+	})
+}
+
+func TestCheckOrder(t *testing.T) {
+	errs := CheckCode(`function f() {
+	var a int = "a";
+}
+var b int = "b";
+`)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(errs))
+	}
+	if errs[0].Line != 2 || errs[1].Line != 4 {
+		t.Errorf("expected errors ordered by position, got lines %d and %d", errs[0].Line, errs[1].Line)
+	}
+}
+
+func TestCheckNil(t *testing.T) {
+	if errs := Check(nil); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	for _, file := range []string{"../DEMO/Test/interface.ecla", "../DEMO/Test/structMethod.ecla", "../DEMO/Test/function.ecla", "../DEMO/Test/for.ecla"} {
+		errs, err := CheckPath(file)
+		if err != nil {
+			t.Error(err)
+		}
+		if len(errs) != 0 {
+			t.Errorf("expected no errors in %s, got %v", file, errs)
+		}
+	}
+	if _, err := CheckPath("../DEMO/Test/doesNotExist.ecla"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestCheckSyntaxErrors(t *testing.T) {
+	errs := CheckCode(`var a int = ;
+var b string = 1 - "a";
+`)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Line != 1 || errs[0].Level != errorHandler.LevelFatal {
+		t.Errorf("expected the syntax error first, got %v", errs[0])
+	}
+	if errs[1].Line != 2 || errs[1].Level != errorHandler.LevelError {
+		t.Errorf("expected the type error of the parsed statement, got %v", errs[1])
+	}
+}
+
+func TestCheckDeclareFunction(t *testing.T) {
+	pars := parser.Parser{Tokens: lexer.Lexer(`var a int = twice(1);
+var b string = twice("a");
+var c int = twice("a");`), ErrorHandler: errorHandler.NewHandler()}
+	file := pars.Parse()
+	c := New()
+	c.DeclareFunction("twice",
+		parser.FunctionPrototype{Parameters: []parser.FunctionParams{{Name: "n", Type: parser.Int}}, ReturnTypes: []string{parser.Int}},
+		parser.FunctionPrototype{Parameters: []parser.FunctionParams{{Name: "s", Type: parser.String}}, ReturnTypes: []string{parser.String}},
+	)
+	c.CheckFile(file)
+	if len(c.Errors) != 1 || c.Errors[0].Line != 3 {
+		t.Errorf("expected an error at line 3, got %v", c.Errors)
+	}
+}
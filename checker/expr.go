@@ -0,0 +1,373 @@
+package checker
+
+import (
+	"fmt"
+
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+	"github.com/Eclalang/Ecla/lexer"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// infer returns the types of the values of the expression, nil if their number is not known.
+func (c *Checker) infer(expr parser.Expr) []string {
+	switch expr.(type) {
+	case parser.Literal:
+		return []string{c.inferLiteral(expr.(parser.Literal))}
+	case parser.BinaryExpr:
+		return []string{c.inferBinaryExpr(expr.(parser.BinaryExpr))}
+	case parser.UnaryExpr:
+		return []string{c.inferUnaryExpr(expr.(parser.UnaryExpr))}
+	case parser.ParenExpr:
+		return c.infer(expr.(parser.ParenExpr).Expression)
+	case parser.ArrayLiteral:
+		return []string{c.inferArrayLiteral(expr.(parser.ArrayLiteral))}
+	case parser.MapLiteral:
+		return []string{c.inferMapLiteral(expr.(parser.MapLiteral))}
+	case parser.InterpolatedStringExpr:
+		for _, part := range expr.(parser.InterpolatedStringExpr).Parts {
+			c.single(part)
+		}
+		return []string{parser.String}
+	case parser.FunctionCallExpr:
+		return c.inferFunctionCallExpr(expr.(parser.FunctionCallExpr))
+	case parser.IndexableAccessExpr:
+		return []string{c.inferIndexableAccessExpr(expr.(parser.IndexableAccessExpr))}
+	case parser.SelectorExpr:
+		return c.inferSelectorExpr(expr.(parser.SelectorExpr))
+	case parser.StructInstantiationExpr:
+		return []string{c.inferStructInstantiationExpr(expr.(parser.StructInstantiationExpr))}
+	case parser.AnonymousFunctionExpr:
+		tree := expr.(parser.AnonymousFunctionExpr)
+		c.checkFunction("", nil, tree.Prototype, tree.Body, c.scope)
+		return []string{eclaDecl.MethodType(tree.Prototype)}
+	case parser.AnonymousFunctionCallExpr:
+		tree := expr.(parser.AnonymousFunctionCallExpr)
+		c.infer(tree.AnonymousFunction)
+		return c.call(tree, "", []parser.FunctionPrototype{tree.AnonymousFunction.Prototype}, tree.Args)
+	}
+	return nil
+}
+
+// single returns the type of an expression used as a single value.
+func (c *Checker) single(expr parser.Expr) string {
+	types := c.infer(expr)
+	if len(types) != 1 {
+		return unknown
+	}
+	return types[0]
+}
+
+// inferLiteral returns the type of a parser.Literal, variables are resolved through the scopes.
+func (c *Checker) inferLiteral(tree parser.Literal) string {
+	switch tree.Type {
+	case lexer.INT:
+		return parser.Int
+	case lexer.FLOAT:
+		return parser.Float
+	case lexer.STRING, lexer.RAWSTRING:
+		return parser.String
+	case lexer.CHAR:
+		return parser.Char
+	case lexer.BOOL:
+		return parser.Bool
+	case "NULL":
+		return parser.Null
+	case "VAR":
+		sym, ok := c.scope.get(tree.Value)
+		if !ok {
+			if _, builtIn := parser.BuiltInFunctions[tree.Value]; !builtIn {
+				c.error(tree, "variable "+tree.Value+" not found")
+			}
+			return unknown
+		}
+		return sym.typ
+	}
+	return unknown
+}
+
+// inferBinaryExpr returns the type of the result of a parser.BinaryExpr.
+func (c *Checker) inferBinaryExpr(tree parser.BinaryExpr) string {
+	left := c.single(tree.LeftExpr)
+	right := c.single(tree.RightExpr)
+	typ, ok := operation(tree.Operator.TokenType, left, right)
+	if !ok {
+		c.error(tree, "invalid operation: "+left+" "+tree.Operator.Value+" "+right)
+	}
+	return typ
+}
+
+// inferUnaryExpr returns the type of the result of a parser.UnaryExpr.
+func (c *Checker) inferUnaryExpr(tree parser.UnaryExpr) string {
+	right := c.single(tree.RightExpr)
+	switch tree.Operator.TokenType {
+	case lexer.SUB:
+		typ, ok := operation(lexer.SUB, parser.Int, right)
+		if !ok {
+			c.error(tree, "invalid operation: -"+right)
+		}
+		return typ
+	case lexer.NOT:
+		v, ok := sample(right)
+		if !ok {
+			return unknown
+		}
+		t, err := v.Not()
+		if err != nil {
+			c.error(tree, "invalid operation: !"+right)
+			return unknown
+		}
+		return t.GetType()
+	}
+	return right
+}
+
+// inferArrayLiteral returns the type of a list, given by its first element.
+func (c *Checker) inferArrayLiteral(tree parser.ArrayLiteral) string {
+	var types []string
+	for _, v := range tree.Values {
+		types = append(types, c.single(v))
+	}
+	if len(types) == 0 || types[0] == unknown {
+		return unknown
+	}
+	return "[]" + types[0]
+}
+
+// inferMapLiteral returns the type of a map, given by its first key and value.
+func (c *Checker) inferMapLiteral(tree parser.MapLiteral) string {
+	var keys, values []string
+	for i := range tree.Keys {
+		keys = append(keys, c.single(tree.Keys[i]))
+		values = append(values, c.single(tree.Values[i]))
+	}
+	if len(keys) == 0 || keys[0] == unknown || values[0] == unknown {
+		return unknown
+	}
+	return parser.Map + "[" + keys[0] + "]" + values[0]
+}
+
+// inferFunctionCallExpr checks the arguments of a call against the prototypes of the function.
+func (c *Checker) inferFunctionCallExpr(tree parser.FunctionCallExpr) []string {
+	sym, ok := c.scope.get(tree.Name)
+	if !ok {
+		return c.inferBuiltInCall(tree)
+	}
+	if len(sym.protos) == 0 {
+		c.args(tree.Args)
+		return nil
+	}
+	return c.call(tree, tree.Name, sym.protos, tree.Args)
+}
+
+// inferBuiltInCall returns the type of the result of a built-in function.
+func (c *Checker) inferBuiltInCall(tree parser.FunctionCallExpr) []string {
+	args, _ := c.args(tree.Args)
+	switch tree.Name {
+	case parser.TypeOf:
+		return []string{parser.String}
+	case parser.Len, parser.SizeOf:
+		return []string{parser.Int}
+	case parser.Append:
+		if len(args) > 0 {
+			return []string{args[0]}
+		}
+		return []string{unknown}
+	case parser.Eval:
+		return nil
+	}
+	c.error(tree, fmt.Sprintf("Function %s not found", tree.Name))
+	return nil
+}
+
+// args returns the types of the arguments of a call, the boolean is false if their number is not known.
+func (c *Checker) args(exprs []parser.Expr) ([]string, bool) {
+	var types []string
+	countKnown := true
+	for _, arg := range exprs {
+		t := c.infer(arg)
+		if t == nil {
+			countKnown = false
+		}
+		types = append(types, t...)
+	}
+	return types, countKnown
+}
+
+// call checks the arguments against the prototypes of the function and returns the types it returns.
+func (c *Checker) call(tree parser.Expr, name string, protos []parser.FunctionPrototype, exprs []parser.Expr) []string {
+	args, countKnown := c.args(exprs)
+	if !countKnown {
+		return nil
+	}
+	for _, proto := range protos {
+		if c.matches(proto, args) {
+			return append([]string{}, proto.ReturnTypes...)
+		}
+	}
+	msg := "function " + name + " called with incorrect arguments"
+	if len(protos) == 1 && len(protos[0].Parameters) == len(args) {
+		for i, param := range protos[0].Parameters {
+			if _, ok := c.interfaces[param.Type]; ok {
+				if err := c.implements(param.Type, args[i]); err != nil {
+					msg += ": " + err.Error()
+					break
+				}
+			}
+		}
+	}
+	c.error(tree, msg)
+	return nil
+}
+
+// matches returns true if the arguments can be passed to a function with the prototype.
+func (c *Checker) matches(proto parser.FunctionPrototype, args []string) bool {
+	if len(proto.Parameters) != len(args) {
+		return false
+	}
+	for i, param := range proto.Parameters {
+		if !c.accepts(param.Type, args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// inferIndexableAccessExpr returns the type of the element accessed by the indexes.
+func (c *Checker) inferIndexableAccessExpr(tree parser.IndexableAccessExpr) string {
+	typ := unknown
+	if sym, ok := c.scope.get(tree.VariableName); ok {
+		typ = sym.typ
+	} else {
+		c.error(tree, fmt.Sprintf("Variable %s not found", tree.VariableName))
+	}
+	for _, index := range tree.Indexes {
+		typ = c.index(typ, index)
+	}
+	return typ
+}
+
+// index returns the type of the element of a value of type typ at the index.
+func (c *Checker) index(typ string, index parser.Expr) string {
+	indexType := c.single(index)
+	if elem, ok := elemType(typ); ok {
+		if !c.accepts(parser.Int, indexType) {
+			c.error(index, "index must be an int")
+		}
+		return elem
+	}
+	if key, value, ok := mapTypes(typ); ok {
+		// a map with string keys is indexed by the string of any value
+		if key != parser.String && !c.accepts(key, indexType) {
+			c.error(index, "index must be of type "+key)
+		}
+		return value
+	}
+	if typ == parser.String {
+		if !c.accepts(parser.Int, indexType) {
+			c.error(index, "index must be an int")
+		}
+		return parser.Char
+	}
+	return unknown
+}
+
+// inferSelectorExpr returns the types of a field or of the values returned by a method, selectors on libraries are not checked.
+func (c *Checker) inferSelectorExpr(tree parser.SelectorExpr) []string {
+	if lit, ok := tree.Expr.(parser.Literal); ok && lit.Type == "VAR" {
+		if sym, found := c.scope.get(lit.Value); found && sym.lib {
+			c.member(unknown, tree.Sel)
+			return nil
+		}
+	}
+	return c.member(c.single(tree.Expr), tree.Sel)
+}
+
+// member returns the types of the field or method sel of a value of type typ.
+func (c *Checker) member(typ string, sel parser.Expr) []string {
+	fields, methods, ok := c.members(typ)
+	switch sel.(type) {
+	case parser.Literal:
+		tree := sel.(parser.Literal)
+		if !ok {
+			return []string{unknown}
+		}
+		if field, found := fields[tree.Value]; found {
+			return []string{field}
+		}
+		if m, found := methods[tree.Value]; found {
+			return []string{eclaDecl.MethodType(m)}
+		}
+		c.error(tree, "field "+tree.Value+" does not exist")
+		return []string{unknown}
+	case parser.FunctionCallExpr:
+		tree := sel.(parser.FunctionCallExpr)
+		if !ok {
+			c.args(tree.Args)
+			return nil
+		}
+		if m, found := methods[tree.Name]; found {
+			return c.call(tree, tree.Name, []parser.FunctionPrototype{m}, tree.Args)
+		}
+		c.args(tree.Args)
+		if _, found := fields[tree.Name]; !found {
+			c.error(tree, "method "+tree.Name+" does not exist on "+typ)
+		}
+		return nil
+	case parser.IndexableAccessExpr:
+		tree := sel.(parser.IndexableAccessExpr)
+		elem := unknown
+		if ok {
+			if field, found := fields[tree.VariableName]; found {
+				elem = field
+			} else {
+				c.error(tree, "field "+tree.VariableName+" does not exist")
+			}
+		}
+		for _, index := range tree.Indexes {
+			elem = c.index(elem, index)
+		}
+		return []string{elem}
+	case parser.SelectorExpr:
+		tree := sel.(parser.SelectorExpr)
+		types := c.member(typ, tree.Expr)
+		if len(types) != 1 {
+			return c.member(unknown, tree.Sel)
+		}
+		return c.member(types[0], tree.Sel)
+	}
+	return nil
+}
+
+// members returns the fields and methods of a struct or interface type.
+func (c *Checker) members(typ string) (map[string]string, map[string]parser.FunctionPrototype, bool) {
+	if info, ok := c.structs[typ]; ok {
+		return info.decl.Fields, info.methods, true
+	}
+	if def, ok := c.interfaces[typ]; ok {
+		return def.Fields, c.interfaceMethods[typ], true
+	}
+	return nil, nil, false
+}
+
+// inferStructInstantiationExpr checks the fields given to a struct.
+func (c *Checker) inferStructInstantiationExpr(tree parser.StructInstantiationExpr) string {
+	var args []string
+	for _, arg := range tree.Args {
+		args = append(args, c.single(arg))
+	}
+	info, ok := c.structs[tree.Name]
+	if !ok {
+		c.error(tree, "unknown type: "+tree.Name)
+		return unknown
+	}
+	if len(args) != len(info.decl.Order) {
+		c.error(tree, "struct does not have the right number of fields")
+		return tree.Name
+	}
+	for i, field := range info.decl.GetFieldsInOrder() {
+		if args[i] != parser.Null && !c.accepts(field.Type, args[i]) {
+			c.error(tree.Args[i], "field "+field.Name+" value is of type "+args[i]+", expected "+field.Type)
+		}
+	}
+	return tree.Name
+}
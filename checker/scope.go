@@ -0,0 +1,66 @@
+package checker
+
+import (
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// symbol is a name known by the checker, functions keep their prototypes to check the calls.
+type symbol struct {
+	typ    string
+	protos []parser.FunctionPrototype
+	lib    bool
+}
+
+// scope is a level of declarations, it is linked to the scope it is nested in.
+type scope struct {
+	vars   map[string]*symbol
+	parent *scope
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{vars: make(map[string]*symbol), parent: parent}
+}
+
+// get returns the symbol with the given name, looking up through the parent scopes.
+func (s *scope) get(name string) (*symbol, bool) {
+	for cur := s; cur != nil; cur = cur.parent {
+		if sym, ok := cur.vars[name]; ok {
+			return sym, true
+		}
+	}
+	return nil, false
+}
+
+// has returns true if the name is declared in this scope.
+func (s *scope) has(name string) bool {
+	_, ok := s.vars[name]
+	return ok
+}
+
+func (s *scope) set(name string, sym *symbol) {
+	s.vars[name] = sym
+}
+
+// addFunction declares the function or adds the prototype to its overloads.
+func (s *scope) addFunction(name string, prototype parser.FunctionPrototype) {
+	if sym, ok := s.vars[name]; ok && len(sym.protos) > 0 {
+		sym.protos = append(sym.protos, prototype)
+		return
+	}
+	s.vars[name] = &symbol{typ: eclaDecl.MethodType(prototype), protos: []parser.FunctionPrototype{prototype}}
+}
+
+// enterScope nests a new scope in the current one and returns the function restoring it.
+func (c *Checker) enterScope() func() {
+	return c.enterScopeFrom(c.scope)
+}
+
+// enterScopeFrom nests a new scope in parent and returns the function restoring the current one.
+func (c *Checker) enterScopeFrom(parent *scope) func() {
+	prev := c.scope
+	c.scope = newScope(parent)
+	return func() {
+		c.scope = prev
+	}
+}
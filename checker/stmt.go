@@ -0,0 +1,364 @@
+package checker
+
+import (
+	"fmt"
+
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+	"github.com/Eclalang/Ecla/lexer"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// function is a function whose body is being checked.
+type function struct {
+	name      string
+	prototype parser.FunctionPrototype
+}
+
+// checkNode checks a node of the syntax tree.
+func (c *Checker) checkNode(node parser.Node) {
+	switch node.(type) {
+	case parser.VariableDecl:
+		c.checkVariableDecl(node.(parser.VariableDecl))
+	case parser.VariableAssignStmt:
+		c.checkVariableAssignStmt(node.(parser.VariableAssignStmt))
+	case parser.FunctionDecl:
+		c.checkFunctionDecl(node.(parser.FunctionDecl))
+	case parser.StructDecl:
+		c.checkStructDecl(node.(parser.StructDecl))
+	case parser.InterfaceDecl:
+		tree := node.(parser.InterfaceDecl)
+		if _, ok := c.interfaces[tree.Name]; !ok {
+			c.addInterface(tree)
+		}
+	case parser.ImportStmt:
+		tree := node.(parser.ImportStmt)
+		c.scope.set(parser.GetPackageNameByPath(tree.ModulePath), &symbol{lib: true})
+	case parser.ReturnStmt:
+		c.checkReturnStmt(node.(parser.ReturnStmt))
+	case parser.IfStmt:
+		c.checkIfStmt(node.(parser.IfStmt))
+	case parser.WhileStmt:
+		tree := node.(parser.WhileStmt)
+		c.infer(tree.Cond)
+		c.checkBlock(tree.Body)
+	case parser.ForStmt:
+		c.checkForStmt(node.(parser.ForStmt))
+	case parser.BlockScopeStmt:
+		c.checkBlock(node.(parser.BlockScopeStmt).Body)
+	case parser.TryStmt:
+		c.checkTryStmt(node.(parser.TryStmt))
+	case parser.ThrowStmt:
+		c.infer(node.(parser.ThrowStmt).Value)
+	case parser.Expr:
+		c.infer(node.(parser.Expr))
+	}
+}
+
+// checkBlock checks the nodes in a new scope.
+func (c *Checker) checkBlock(body []parser.Node) {
+	defer c.enterScope()()
+	for _, node := range body {
+		c.checkNode(node)
+	}
+}
+
+// checkVariableDecl checks a parser.VariableDecl, the variable is declared with its declared or inferred type.
+func (c *Checker) checkVariableDecl(tree parser.VariableDecl) {
+	sym := &symbol{typ: tree.Type}
+	if tree.Value == nil {
+		if c.scope.has(tree.Name) {
+			c.error(tree, "variable "+tree.Name+" already exists")
+			return
+		}
+		c.scope.set(tree.Name, sym)
+		return
+	}
+	value := c.single(tree.Value)
+	if tree.Type == "" {
+		if value != parser.Null {
+			sym.typ = value
+		}
+	} else if !c.acceptsDecl(tree.Type, value) {
+		if _, ok := c.interfaces[tree.Type]; ok {
+			c.error(tree, c.implements(tree.Type, value).Error())
+		} else {
+			c.error(tree, "cannot create variable of type "+tree.Type+" with value of type "+value)
+		}
+	}
+	if fn, ok := tree.Value.(parser.AnonymousFunctionExpr); ok {
+		// declaring a function again adds an overload to it
+		if declared, found := c.scope.get(tree.Name); found {
+			if len(declared.protos) == 0 {
+				c.error(tree, "Cannot overload a non-function variable "+tree.Name)
+				return
+			}
+			for _, proto := range declared.protos {
+				if eclaDecl.MethodType(proto) == value {
+					c.error(tree, "Cannot overwrite this function "+tree.Name)
+					return
+				}
+			}
+			declared.protos = append(declared.protos, fn.Prototype)
+			return
+		}
+		sym.protos = []parser.FunctionPrototype{fn.Prototype}
+	}
+	if c.scope.has(tree.Name) {
+		c.error(tree, "Cannot reassign a variable "+tree.Name)
+		return
+	}
+	c.scope.set(tree.Name, sym)
+}
+
+// checkVariableAssignStmt checks that the assigned values have the types of the variables.
+func (c *Checker) checkVariableAssignStmt(tree parser.VariableAssignStmt) {
+	var values []string
+	countKnown := true
+	if len(tree.Values) > 0 && tree.Values[0] != nil {
+		for _, v := range tree.Values {
+			types := c.infer(v)
+			if types == nil {
+				countKnown = false
+			}
+			values = append(values, types...)
+		}
+	}
+	if c.checkOverride(tree) {
+		return
+	}
+	var targets []string
+	for _, name := range tree.Names {
+		targets = append(targets, c.target(tree, name))
+	}
+	if !countKnown {
+		return
+	}
+	switch {
+	case len(values) == len(targets):
+	case len(values) == 1 && len(targets) > 1:
+		for len(values) < len(targets) {
+			values = append(values, values[0])
+		}
+	case len(values) == 0:
+		if tree.Operator == parser.INCREMENT || tree.Operator == parser.DECREMENT {
+			for _, target := range targets {
+				c.checkAssign(tree, target, parser.Int)
+			}
+		}
+		return
+	default:
+		c.error(tree, fmt.Sprintf("Invalid assignment: %d rValues to %d lValues", len(values), len(targets)))
+		return
+	}
+	for i, target := range targets {
+		if tree.Operator == parser.ASSIGN {
+			c.checkAssign(tree, target, values[i])
+			continue
+		}
+		typ, ok := operation(assignOperations[tree.Operator], target, values[i])
+		if !ok {
+			c.error(tree, "invalid operation: "+target+" "+tree.Operator+" "+values[i])
+			continue
+		}
+		c.checkAssign(tree, target, typ)
+	}
+}
+
+// checkOverride checks the assignment of an anonymous function to a function, it replaces the prototype with the same parameters.
+func (c *Checker) checkOverride(tree parser.VariableAssignStmt) bool {
+	if tree.Operator != parser.ASSIGN || len(tree.Names) != 1 || len(tree.Values) != 1 {
+		return false
+	}
+	fn, ok := tree.Values[0].(parser.AnonymousFunctionExpr)
+	lit, isVar := tree.Names[0].(parser.Literal)
+	if !ok || !isVar || lit.Type != "VAR" {
+		return false
+	}
+	sym, found := c.scope.get(lit.Value)
+	if !found || len(sym.protos) == 0 {
+		return false
+	}
+	for i, proto := range sym.protos {
+		if sameParameters(proto.Parameters, fn.Prototype.Parameters) {
+			sym.protos[i] = fn.Prototype
+			return true
+		}
+	}
+	c.error(tree, "cannot override a prototype that was not implemented")
+	return true
+}
+
+// sameParameters returns true if the parameters have the same types.
+func sameParameters(a []parser.FunctionParams, b []parser.FunctionParams) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type {
+			return false
+		}
+	}
+	return true
+}
+
+// target returns the type of the variable, index or field assigned by the statement.
+func (c *Checker) target(tree parser.VariableAssignStmt, name parser.Expr) string {
+	if lit, ok := name.(parser.Literal); ok && lit.Type == "VAR" {
+		sym, ok := c.scope.get(lit.Value)
+		if !ok {
+			c.error(tree, fmt.Sprintf("variable %s not found", lit.Value))
+			return unknown
+		}
+		return sym.typ
+	}
+	return c.single(name)
+}
+
+// checkAssign reports an error if the value cannot be assigned to a variable of type target.
+func (c *Checker) checkAssign(tree parser.VariableAssignStmt, target string, value string) {
+	if c.accepts(target, value) {
+		return
+	}
+	if _, ok := c.interfaces[target]; ok {
+		c.error(tree, c.implements(target, value).Error())
+		return
+	}
+	c.error(tree, fmt.Sprintf("Cannot assign %s to %s", value, target))
+}
+
+// checkFunctionDecl declares a nested function and queues the check of its body.
+func (c *Checker) checkFunctionDecl(tree parser.FunctionDecl) {
+	// the functions and methods of the top level are declared before the check starts
+	if c.scope.parent != nil {
+		if tree.Receiver != nil {
+			c.addMethod(tree.Receiver.Type, tree)
+		} else {
+			c.scope.addFunction(tree.Name, tree.Prototype)
+		}
+	}
+	c.queueFunction(tree)
+}
+
+// checkStructDecl declares a nested struct and queues the check of the bodies of its methods.
+func (c *Checker) checkStructDecl(tree parser.StructDecl) {
+	if _, ok := c.structs[tree.Name]; !ok {
+		c.structs[tree.Name] = &structInfo{decl: eclaDecl.NewStructDecl(tree), methods: make(map[string]parser.FunctionPrototype)}
+		for _, m := range tree.Methods {
+			c.addMethod(tree.Name, m)
+		}
+	}
+	for _, m := range tree.Methods {
+		c.queueFunction(m)
+	}
+}
+
+// queueFunction queues the check of the body of the function, in the scope it is declared in.
+func (c *Checker) queueFunction(tree parser.FunctionDecl) {
+	parent := c.scope
+	c.pending = append(c.pending, func() {
+		c.checkFunction(tree.Name, tree.Receiver, tree.Prototype, tree.Body, parent)
+	})
+}
+
+// checkFunction checks the body of a function in a new scope nested in parent.
+func (c *Checker) checkFunction(name string, receiver *parser.FunctionParams, prototype parser.FunctionPrototype, body []parser.Node, parent *scope) {
+	defer c.enterScopeFrom(parent)()
+	for _, param := range prototype.Parameters {
+		c.scope.set(param.Name, &symbol{typ: param.Type})
+	}
+	if receiver != nil {
+		c.scope.set(receiver.Name, &symbol{typ: receiver.Type})
+	}
+	c.functions = append(c.functions, &function{name: name, prototype: prototype})
+	defer func() {
+		c.functions = c.functions[:len(c.functions)-1]
+	}()
+	for _, node := range body {
+		c.checkNode(node)
+	}
+}
+
+// checkReturnStmt checks the returned values against the prototype of the function.
+func (c *Checker) checkReturnStmt(tree parser.ReturnStmt) {
+	var values []string
+	countKnown := true
+	for _, v := range tree.ReturnValues {
+		types := c.infer(v)
+		if types == nil {
+			countKnown = false
+		}
+		values = append(values, types...)
+	}
+	if len(c.functions) == 0 || !countKnown || len(tree.ReturnValues) == 0 {
+		return
+	}
+	fn := c.functions[len(c.functions)-1]
+	ok := len(values) == len(fn.prototype.ReturnTypes)
+	for i := 0; ok && i < len(values); i++ {
+		ok = c.acceptsReturn(fn.prototype.ReturnTypes[i], values[i])
+	}
+	if !ok {
+		c.error(tree, "Return type of function "+fn.name+" is incorrect")
+	}
+}
+
+// checkIfStmt checks the condition and the bodies of an if statement and of its else branches.
+func (c *Checker) checkIfStmt(tree parser.IfStmt) {
+	c.infer(tree.Cond)
+	c.checkBlock(tree.Body)
+	if tree.ElseStmt == nil {
+		return
+	}
+	if tree.ElseStmt.IfStmt != nil {
+		c.checkIfStmt(*tree.ElseStmt.IfStmt)
+	} else {
+		c.checkBlock(tree.ElseStmt.Body)
+	}
+}
+
+// checkForStmt checks a for statement, the key and value of a range are typed by the ranged value.
+func (c *Checker) checkForStmt(tree parser.ForStmt) {
+	defer c.enterScope()()
+	if tree.RangeToken != (lexer.Token{}) {
+		key, value := unknown, unknown
+		typ := c.single(tree.RangeExpr)
+		if elem, ok := elemType(typ); ok {
+			key, value = parser.Int, elem
+		} else if k, v, ok := mapTypes(typ); ok {
+			key, value = k, v
+		} else if typ == parser.String {
+			key, value = parser.Int, parser.Char
+		} else if !isDynamic(typ) {
+			c.error(tree.RangeExpr, "type "+typ+" not supported")
+		}
+		c.scope.set(tree.KeyToken.Value, &symbol{typ: key})
+		c.scope.set(tree.ValueToken.Value, &symbol{typ: value})
+	} else {
+		if tree.InitDecl != nil {
+			c.checkNode(tree.InitDecl)
+		}
+		if tree.CondExpr != nil {
+			c.infer(tree.CondExpr)
+		}
+		if tree.PostAssignStmt != nil {
+			c.checkNode(tree.PostAssignStmt)
+		}
+	}
+	c.checkBlock(tree.Body)
+}
+
+// checkTryStmt checks the bodies of a try statement, the caught error is bound in the catch clause.
+func (c *Checker) checkTryStmt(tree parser.TryStmt) {
+	c.checkBlock(tree.Body)
+	if tree.CatchStmt != nil {
+		restore := c.enterScope()
+		if tree.CatchStmt.ErrorName != "" {
+			c.scope.set(tree.CatchStmt.ErrorName, &symbol{typ: parser.Error})
+		}
+		c.checkBlock(tree.CatchStmt.Body)
+		restore()
+	}
+	if tree.FinallyStmt != nil {
+		c.checkBlock(tree.FinallyStmt.Body)
+	}
+}
@@ -0,0 +1,175 @@
+package checker
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Eclalang/Ecla/interpreter/eclaType"
+	"github.com/Eclalang/Ecla/lexer"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// unknown is the type of the expressions the checker cannot infer, it is accepted everywhere.
+const unknown = ""
+
+// isDynamic returns true if the type is not known until execution.
+func isDynamic(typ string) bool {
+	return typ == unknown || strings.HasPrefix(typ, parser.Any)
+}
+
+// elemType returns the type of the elements of a list type.
+func elemType(typ string) (string, bool) {
+	if !strings.HasPrefix(typ, "[]") {
+		return unknown, false
+	}
+	return typ[2:], true
+}
+
+// mapTypes returns the key and value types of a map type.
+func mapTypes(typ string) (string, string, bool) {
+	if !strings.HasPrefix(typ, parser.Map+"[") {
+		return unknown, unknown, false
+	}
+	depth := 0
+	for i := len(parser.Map); i < len(typ); i++ {
+		switch typ[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return typ[len(parser.Map)+1 : i], typ[i+1:], true
+			}
+		}
+	}
+	return unknown, unknown, false
+}
+
+// isTypeDecl returns true if the type is a declared struct or interface.
+func (c *Checker) isTypeDecl(typ string) bool {
+	if _, ok := c.structs[typ]; ok {
+		return true
+	}
+	_, ok := c.interfaces[typ]
+	return ok
+}
+
+// implements returns an error if the value of type typ cannot be used as the interface.
+func (c *Checker) implements(itf string, typ string) error {
+	def := c.interfaces[itf]
+	if _, ok := c.interfaces[typ]; ok || typ == parser.Null {
+		return nil
+	}
+	info, ok := c.structs[typ]
+	if !ok {
+		return errors.New("cannot use value of type " + typ + " as interface " + itf)
+	}
+	return def.IsImplementedBy(info.decl)
+}
+
+// accepts returns true if a value of type value can be used where a value of type target is expected.
+func (c *Checker) accepts(target string, value string) bool {
+	if isDynamic(target) || isDynamic(value) || target == value {
+		return true
+	}
+	if _, ok := c.interfaces[target]; ok {
+		return c.implements(target, value) == nil
+	}
+	return false
+}
+
+// acceptsDecl returns true if a variable of type target can be declared with a value of type value.
+func (c *Checker) acceptsDecl(target string, value string) bool {
+	if target == parser.String || value == parser.Null || (target == parser.Float && value == parser.Int) {
+		return true
+	}
+	return c.accepts(target, value)
+}
+
+// acceptsReturn returns true if a function returning target can return a value of type value.
+func (c *Checker) acceptsReturn(target string, value string) bool {
+	if value == parser.Null && c.isTypeDecl(target) {
+		return true
+	}
+	return c.accepts(target, value)
+}
+
+// sample returns a value of the type, used to find the result of an operation the way the interpreter does.
+func sample(typ string) (eclaType.Type, bool) {
+	switch typ {
+	case parser.Int:
+		return eclaType.Int(1), true
+	case parser.Float:
+		return eclaType.Float(1), true
+	case parser.String:
+		return eclaType.String("a"), true
+	case parser.Char:
+		return eclaType.Char('a'), true
+	case parser.Bool:
+		return eclaType.Bool(true), true
+	}
+	if _, ok := elemType(typ); ok {
+		l, err := eclaType.NewList(typ)
+		return l, err == nil
+	}
+	return nil, false
+}
+
+// operation returns the type of the result of the binary operation, operator is the token type of the operator.
+func operation(operator string, left string, right string) (string, bool) {
+	l, okLeft := sample(left)
+	r, okRight := sample(right)
+	if !okLeft || !okRight {
+		return unknown, true
+	}
+	var t eclaType.Type
+	var err error
+	switch operator {
+	case lexer.ADD:
+		t, err = l.Add(r)
+	case lexer.SUB:
+		t, err = l.Sub(r)
+	case lexer.MULT:
+		t, err = l.Mul(r)
+	case lexer.DIV:
+		t, err = l.Div(r)
+	case lexer.MOD:
+		t, err = l.Mod(r)
+	case lexer.QOT:
+		t, err = l.DivEc(r)
+	case lexer.EQUAL:
+		t, err = l.Eq(r)
+	case lexer.LSS:
+		t, err = l.Lw(r)
+	case lexer.LEQ:
+		t, err = l.LwEq(r)
+	case lexer.GTR:
+		t, err = l.Gt(r)
+	case lexer.GEQ:
+		t, err = l.GtEq(r)
+	case lexer.NEQ:
+		t, err = l.NotEq(r)
+	case lexer.AND:
+		t, err = l.And(r)
+	case lexer.OR:
+		t, err = l.Or(r)
+	case lexer.XOR:
+		t, err = l.Xor(r)
+	default:
+		return unknown, true
+	}
+	if err != nil {
+		return unknown, false
+	}
+	return t.GetType(), true
+}
+
+// assignOperations maps the assignment operators to the operation they apply.
+var assignOperations = map[string]string{
+	parser.ADDASSIGN:  lexer.ADD,
+	parser.SUBASSIGN:  lexer.SUB,
+	parser.DIVASSIGN:  lexer.DIV,
+	parser.MODASSIGN:  lexer.MOD,
+	parser.QOTASSIGN:  lexer.QOT,
+	parser.MULTASSIGN: lexer.MULT,
+}
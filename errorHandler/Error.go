@@ -4,13 +4,51 @@ import "fmt"
 
 // Error is the error struct of ecla errors.
 type Error struct {
-	Line  int
-	Col   int
-	Msg   string
-	Level Level
+	Line int
+	Col  int
+	// EndLine and EndCol are the line and the column right after the code the error is raised on,
+	// they are the ones of its start when the error is raised on a single position
+	EndLine int
+	EndCol  int
+	Msg     string
+	Level   Level
+	// Trace is the functions being executed when the error was raised, the most recent first
+	Trace []Frame
+}
+
+// Span is the code an error is raised on, the nodes of the parser are spans.
+type Span interface {
+	StartLine() int
+	StartPos() int
+	EndLine() int
+	EndPos() int
+}
+
+// NewError returns the error raised on span.
+func NewError(span Span, Message string, LogLevel Level) Error {
+	return Error{
+		Line:    span.StartLine(),
+		Col:     span.StartPos(),
+		EndLine: span.EndLine(),
+		EndCol:  span.EndPos(),
+		Msg:     Message,
+		Level:   LogLevel,
+	}
 }
 
 // String returns the string representation of an error.
+// The position of a traced error is given in the file of the first frame, the one in which it was raised.
 func (e Error) String() string {
-	return fmt.Sprintf("%s Line: %d, Col: %d\n%s", e.Level, e.Line, e.Col, e.Msg)
+	if len(e.Trace) == 0 {
+		return fmt.Sprintf("%s Line: %d, Col: %d\n%s", e.Level, e.Line, e.Col, e.Msg)
+	}
+	if file := e.Trace[0].File; file != "" {
+		return fmt.Sprintf("%s %s Line: %d, Col: %d\n%s\n%s", e.Level, file, e.Line, e.Col, e.Msg, formatTrace(e.Trace))
+	}
+	return fmt.Sprintf("%s Line: %d, Col: %d\n%s\n%s", e.Level, e.Line, e.Col, e.Msg, formatTrace(e.Trace))
+}
+
+// Error returns the string representation of an error.
+func (e Error) Error() string {
+	return e.String()
 }
@@ -2,17 +2,28 @@ package errorHandler
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 )
 
-// eclaExit is a variable to store the os.Exit function only modified for testing purposes.
-var eclaExit = os.Exit
-var oldExit = os.Exit
-
 // ErrorHandler is the error handler of ecla.
 type ErrorHandler struct {
-	Errors []Error
+	Errors   []Error
+	tryDepth int
+	// tracer returns the functions being executed when an error is raised, the errors are not traced when it is nil
+	tracer func(line, col int) []Frame
+	// unwind is true when the fatal errors raised outside of a try block unwind the execution instead of exiting
+	unwind bool
+	// output is where the errors are printed, the fatal errors are printed to the standard output
+	// and the others are logged to the standard error when it is nil
+	output io.Writer
+	// log is the logger of the warnings and the non-fatal errors printed to output
+	log *log.Logger
+	// exit is the function exiting the program after a fatal error, os.Exit when it is nil,
+	// it is only modified for testing purposes
+	exit    func(int)
+	oldExit func(int)
 }
 
 // NewHandler returns a new ErrorHandler.
@@ -22,37 +33,167 @@ func NewHandler() *ErrorHandler {
 	}
 }
 
-// HandleError handles an error.
+// HandleError handles an error raised on a single position.
 func (e *ErrorHandler) HandleError(Line, Col int, Message string, LogLevel Level) {
-	err := Error{
-		Line:  Line,
-		Col:   Col,
-		Msg:   Message,
-		Level: LogLevel,
+	e.handle(Error{
+		Line:    Line,
+		Col:     Col,
+		EndLine: Line,
+		EndCol:  Col,
+		Msg:     Message,
+		Level:   LogLevel,
+	})
+}
+
+// HandleErrorAt handles an error raised on the code of span.
+func (e *ErrorHandler) HandleErrorAt(span Span, Message string, LogLevel Level) {
+	e.handle(NewError(span, Message, LogLevel))
+}
+
+// handle traces the error and raises it.
+func (e *ErrorHandler) handle(err Error) {
+	if err.Level != LevelWarning && e.tracer != nil {
+		err.Trace = e.tracer(err.Line, err.Col)
+	}
+	e.Raise(err)
+}
+
+// Raise handles an error already built, it keeps the trace of the error.
+func (e *ErrorHandler) Raise(err Error) {
+	LogLevel, Message := err.Level, err.Msg
+	if LogLevel == LevelFatal && e.tryDepth > 0 {
+		// the error is raised inside a try block, it is unwound up to the nearest one instead of exiting
+		panic(err)
 	}
 	e.Errors = append(e.Errors, err)
 	switch LogLevel {
 	case LevelWarning:
-		log.Println(LevelToString(LogLevel) + " : " + Message)
+		e.logger().Println(LevelToString(LogLevel) + " : " + Message)
 	case LevelError:
-		log.Println(LevelToString(LogLevel) + " : " + Message)
+		e.logger().Println(LevelToString(LogLevel) + " : " + Message)
 	case LevelFatal:
-		panicEcla(err)
+		if e.unwind {
+			panic(err)
+		}
+		panicEcla(e.out(), e.exitProgram, err)
 	}
 }
 
-// HookExit is used for testing purpose it hooks the eclaExit variable to the function passed as parameter
+// Exit prints the fatal error and exits the program, even inside a try block.
+func (e *ErrorHandler) Exit(err Error) {
+	e.Errors = append(e.Errors, err)
+	panicEcla(e.out(), e.exitProgram, err)
+}
+
+// Collect records an error without interrupting the execution, the collected errors are handled later by HandleErrors.
+func (e *ErrorHandler) Collect(err Error) {
+	e.Errors = append(e.Errors, err)
+}
+
+// HandleErrors handles errors collected while the execution went on, all of them are printed before exiting if one is fatal.
+// Inside a try block the first fatal error is raised instead.
+func (e *ErrorHandler) HandleErrors(errs []Error) {
+	var fatal *Error
+	for i, err := range errs {
+		if err.Level == LevelFatal {
+			if e.tryDepth > 0 {
+				panic(err)
+			}
+			if fatal == nil {
+				fatal = &errs[i]
+			}
+		}
+	}
+	if fatal == nil {
+		return
+	}
+	if e.unwind {
+		panic(*fatal)
+	}
+	for _, err := range errs {
+		fmt.Fprintln(e.out(), err)
+	}
+	e.exitProgram(1)
+}
+
+// EnterTry marks the beginning of a try block, fatal errors are raised as a panic of Error until the matching ExitTry.
+func (e *ErrorHandler) EnterTry() {
+	e.tryDepth++
+}
+
+// ExitTry marks the end of a try block.
+func (e *ErrorHandler) ExitTry() {
+	if e.tryDepth > 0 {
+		e.tryDepth--
+	}
+}
+
+// Unwind makes the fatal errors unwind the execution as a panic of Error instead of exiting the program,
+// like inside a try block. The one executing the code must recover them.
+func (e *ErrorHandler) Unwind() {
+	e.unwind = true
+}
+
+// StopUnwind makes the fatal errors exit the program again.
+func (e *ErrorHandler) StopUnwind() {
+	e.unwind = false
+}
+
+// Unwinding returns true if the fatal errors unwind the execution instead of exiting the program.
+func (e *ErrorHandler) Unwinding() bool {
+	return e.unwind
+}
+
+// InTry returns true if fatal errors are currently caught by a try block.
+func (e *ErrorHandler) InTry() bool {
+	return e.tryDepth > 0
+}
+
+// HookExit is used for testing purpose it hooks the exit of the handler to the function passed as parameter
 func (e *ErrorHandler) HookExit(f func(int)) {
-	oldExit = eclaExit
-	eclaExit = f
+	e.oldExit = e.exit
+	e.exit = f
 }
 
-// RestoreExit is used for testing purpose it restore the hook of eclaExit
+// RestoreExit is used for testing purpose it restore the hook of the exit of the handler
 func (e *ErrorHandler) RestoreExit() {
-	eclaExit = oldExit
+	e.exit = e.oldExit
+}
+
+// exitProgram exits the program with the given code.
+func (e *ErrorHandler) exitProgram(code int) {
+	if e.exit == nil {
+		os.Exit(code)
+	}
+	e.exit(code)
+}
+
+// SetOutput sets the writer to which the errors are printed.
+func (e *ErrorHandler) SetOutput(w io.Writer) {
+	e.output = w
+	e.log = nil
+	if w != nil {
+		e.log = log.New(w, "", log.LstdFlags)
+	}
+}
+
+// out returns the writer to which the fatal errors are printed.
+func (e *ErrorHandler) out() io.Writer {
+	if e.output == nil {
+		return os.Stdout
+	}
+	return e.output
+}
+
+// logger returns the logger of the warnings and the non-fatal errors.
+func (e *ErrorHandler) logger() *log.Logger {
+	if e.log == nil {
+		return log.Default()
+	}
+	return e.log
 }
 
-func panicEcla(err Error) {
-	fmt.Println(err)
-	eclaExit(1)
+func panicEcla(w io.Writer, exit func(int), err Error) {
+	fmt.Fprintln(w, err)
+	exit(1)
 }
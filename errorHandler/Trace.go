@@ -0,0 +1,45 @@
+package errorHandler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Frame is a function being executed when an error is raised.
+type Frame struct {
+	// Function is the name of the function, main for the code outside of any function
+	Function string
+	File     string
+	// Line and Col is the position reached in the function, the position of the error for the first frame
+	// and the position of the call of the previous frame for the others, Line is 0 for a frame outside of the code
+	Line int
+	Col  int
+}
+
+// String returns the string representation of a frame.
+func (f Frame) String() string {
+	if f.Line == 0 {
+		return f.Function
+	}
+	if f.File == "" {
+		return fmt.Sprintf("%s Line: %d, Col: %d", f.Function, f.Line, f.Col)
+	}
+	return fmt.Sprintf("%s %s:%d:%d", f.Function, f.File, f.Line, f.Col)
+}
+
+// SetTracer sets the function returning the functions being executed, the most recent first,
+// for an error raised at the given position. The fatal errors and the errors raised from then on are traced.
+func (e *ErrorHandler) SetTracer(tracer func(line, col int) []Frame) {
+	e.tracer = tracer
+}
+
+// formatTrace returns the string representation of the frames, one per line.
+func formatTrace(frames []Frame) string {
+	var b strings.Builder
+	b.WriteString("Traceback (most recent call first):")
+	for _, f := range frames {
+		b.WriteString("\n\t")
+		b.WriteString(f.String())
+	}
+	return b.String()
+}
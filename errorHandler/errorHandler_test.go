@@ -1,6 +1,9 @@
 package errorHandler
 
 import (
+	"bytes"
+	"io"
+	"strings"
 	"testing"
 )
 
@@ -70,9 +73,9 @@ func TestErrorHandler_HandleError(t *testing.T) {
 		t.Errorf("HandleError() appended the wrong error")
 	}
 	var ok bool
-	eclaExit = func(code int) {
+	e.HookExit(func(code int) {
 		ok = code == 1
-	}
+	})
 	e.HandleError(5, 6, "Test3", LevelFatal)
 	if !ok {
 		t.Errorf("HandleError() did not panic")
@@ -85,13 +88,38 @@ func TestErrorHandler_HandleError(t *testing.T) {
 	}
 }
 
+// testSpan is the code from 1:3 to 2:5.
+type testSpan struct{}
+
+func (testSpan) StartLine() int { return 1 }
+func (testSpan) StartPos() int  { return 3 }
+func (testSpan) EndLine() int   { return 2 }
+func (testSpan) EndPos() int    { return 5 }
+
+func TestErrorHandler_HandleErrorAt(t *testing.T) {
+	e := NewHandler()
+	e.HandleErrorAt(testSpan{}, "Test", LevelError)
+	if len(e.Errors) != 1 {
+		t.Fatalf("HandleErrorAt() did not append the error")
+	}
+	err := e.Errors[0]
+	if err.Line != 1 || err.Col != 3 || err.EndLine != 2 || err.EndCol != 5 || err.Msg != "Test" || err.Level != LevelError {
+		t.Errorf("HandleErrorAt() appended the wrong error %#v", err)
+	}
+	// an error raised on a single position ends where it starts
+	e.HandleError(4, 6, "Test2", LevelWarning)
+	if err := e.Errors[1]; err.EndLine != 4 || err.EndCol != 6 {
+		t.Errorf("HandleError() appended the wrong end %d:%d", err.EndLine, err.EndCol)
+	}
+}
+
 func TestErrorHandler_HookExit(t *testing.T) {
 	e := NewHandler()
 	var ok bool
 	e.HookExit(func(i int) {
 		ok = i == 1
 	})
-	eclaExit(1)
+	e.exitProgram(1)
 	if !ok {
 		t.Errorf("HookExit() did not hook the function")
 	}
@@ -99,23 +127,18 @@ func TestErrorHandler_HookExit(t *testing.T) {
 
 func TestErrorHandler_RestoreExit(t *testing.T) {
 	e := NewHandler()
-	var ok bool
-	e.HookExit(func(i int) {
-		ok = i == 1
-	})
+	e.HookExit(func(i int) {})
 	e.RestoreExit()
-	eclaExit(1)
-	if ok {
+	if e.exit != nil {
 		t.Errorf("RestoreExit() did not restore the function")
 	}
 }
 
 func TestPanicEcla(t *testing.T) {
 	var ok bool
-	eclaExit = func(code int) {
+	panicEcla(io.Discard, func(code int) {
 		ok = code == 1
-	}
-	panicEcla(Error{
+	}, Error{
 		Line:  1,
 		Col:   2,
 		Msg:   "Test",
@@ -125,3 +148,155 @@ func TestPanicEcla(t *testing.T) {
 		t.Errorf("panicEcla() did not panic")
 	}
 }
+
+func TestErrorHandlerTry(t *testing.T) {
+	e := NewHandler()
+	if e.InTry() {
+		t.Errorf("InTry returned true without EnterTry")
+	}
+	e.EnterTry()
+	if !e.InTry() {
+		t.Errorf("InTry returned false after EnterTry")
+	}
+	func() {
+		defer func() {
+			r := recover()
+			err, ok := r.(Error)
+			if !ok {
+				t.Errorf("HandleError did not panic with an Error inside a try")
+				return
+			}
+			if err.Msg != "Test" || err.Line != 1 || err.Col != 2 {
+				t.Errorf("HandleError panicked with wrong error %v", err)
+			}
+		}()
+		e.HandleError(1, 2, "Test", LevelFatal)
+	}()
+	if len(e.Errors) != 0 {
+		t.Errorf("a caught error should not be recorded")
+	}
+	e.ExitTry()
+	if e.InTry() {
+		t.Errorf("InTry returned true after ExitTry")
+	}
+	e.ExitTry()
+	if e.InTry() {
+		t.Errorf("ExitTry without EnterTry should be ignored")
+	}
+}
+
+func TestErrorHandlerCollect(t *testing.T) {
+	e := NewHandler()
+	var exited bool
+	e.HookExit(func(code int) {
+		exited = code == 1
+	})
+	defer e.RestoreExit()
+	e.Collect(Error{Line: 1, Col: 2, Msg: "Test", Level: LevelFatal})
+	if exited {
+		t.Errorf("Collect() should not exit")
+	}
+	if len(e.Errors) != 1 || e.Errors[0].Msg != "Test" {
+		t.Errorf("Collect() did not record the error")
+	}
+}
+
+func TestErrorHandlerHandleErrors(t *testing.T) {
+	e := NewHandler()
+	var exited int
+	e.HookExit(func(code int) {
+		if code == 1 {
+			exited++
+		}
+	})
+	defer e.RestoreExit()
+	e.HandleErrors(nil)
+	e.HandleErrors([]Error{{Line: 1, Col: 2, Msg: "Test", Level: LevelWarning}})
+	if exited != 0 {
+		t.Errorf("HandleErrors() exited without fatal errors")
+	}
+	errs := []Error{
+		{Line: 1, Col: 2, Msg: "first", Level: LevelFatal},
+		{Line: 3, Col: 4, Msg: "second", Level: LevelFatal},
+	}
+	e.HandleErrors(errs)
+	if exited != 1 {
+		t.Errorf("HandleErrors() should exit once, exited %d times", exited)
+	}
+
+	e.EnterTry()
+	defer e.ExitTry()
+	defer func() {
+		err, ok := recover().(Error)
+		if !ok || err.Msg != "first" {
+			t.Errorf("HandleErrors() did not raise the first fatal error inside a try, got %v", err)
+		}
+	}()
+	e.HandleErrors(errs)
+}
+
+func TestErrorHandlerTrace(t *testing.T) {
+	e := NewHandler()
+	e.HandleError(1, 2, "Test", LevelError)
+	if e.Errors[0].Trace != nil {
+		t.Errorf("HandleError() traced an error raised without a tracer")
+	}
+
+	e.SetTracer(func(line, col int) []Frame {
+		return []Frame{
+			{Function: "h", File: "lib.ecla", Line: line, Col: col},
+			{Function: "f", File: "main.ecla", Line: 6, Col: 7},
+			{Function: "main", File: "main.ecla", Line: 10, Col: 3},
+		}
+	})
+	e.HandleError(1, 2, "Test", LevelError)
+	expected := []Frame{
+		{Function: "h", File: "lib.ecla", Line: 1, Col: 2},
+		{Function: "f", File: "main.ecla", Line: 6, Col: 7},
+		{Function: "main", File: "main.ecla", Line: 10, Col: 3},
+	}
+	trace := e.Errors[1].Trace
+	if len(trace) != len(expected) {
+		t.Fatalf("HandleError() returned %d frames instead of %d", len(trace), len(expected))
+	}
+	for i, frame := range expected {
+		if trace[i] != frame {
+			t.Errorf("frame %d is %v instead of %v", i, trace[i], frame)
+		}
+	}
+	// the position of the error is in the file of the first frame
+	if e.Errors[1].String() != "Error lib.ecla Line: 1, Col: 2\nTest\nTraceback (most recent call first):\n\th lib.ecla:1:2\n\tf main.ecla:6:7\n\tmain main.ecla:10:3" {
+		t.Errorf("Error.String() returned wrong string %q", e.Errors[1].String())
+	}
+	code := Error{Line: 1, Col: 2, Msg: "Test", Level: LevelError, Trace: []Frame{{Function: "main", Line: 1, Col: 2}}}
+	if code.String() != "Error Line: 1, Col: 2\nTest\nTraceback (most recent call first):\n\tmain Line: 1, Col: 2" {
+		t.Errorf("Error.String() returned wrong string %q", code.String())
+	}
+
+	e.HandleError(1, 2, "Test", LevelWarning)
+	if e.Errors[2].Trace != nil {
+		t.Errorf("HandleError() traced a warning")
+	}
+}
+
+func TestErrorHandlerSetOutput(t *testing.T) {
+	var out bytes.Buffer
+	e := NewHandler()
+	e.SetOutput(&out)
+	var code int
+	e.HookExit(func(c int) {
+		code = c
+	})
+	defer e.RestoreExit()
+	e.HandleError(1, 2, "warned", LevelWarning)
+	e.HandleError(3, 4, "failed", LevelFatal)
+	if code != 1 {
+		t.Errorf("Expected the exit code 1, got %d", code)
+	}
+	if !strings.Contains(out.String(), "Warning : warned") || !strings.Contains(out.String(), "failed") {
+		t.Errorf("Expected the errors to be printed to the output, got %q", out.String())
+	}
+	if e.logger() != e.logger() {
+		t.Errorf("Expected the logger of the output to be built once")
+	}
+}
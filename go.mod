@@ -4,12 +4,12 @@ go 1.21.1
 
 require (
 	github.com/Eclalang/LibraryController v1.0.0
+	github.com/Eclalang/console v1.0.1
 	github.com/Eclalang/mainthread v0.0.0-20171120011319-8b78f0a41ae3
 )
 
 require (
 	github.com/Eclalang/cast v1.0.0 // indirect
-	github.com/Eclalang/console v1.0.1 // indirect
 	github.com/Eclalang/encoding v1.0.1 // indirect
 	github.com/Eclalang/hash v1.0.0 // indirect
 	github.com/Eclalang/json v1.0.1 // indirect
@@ -13,37 +13,47 @@ import (
 func New(t parser.Literal, env *Env) *Bus {
 	switch t.Type {
 	case lexer.INT:
-		return NewMainBus(eclaType.NewInt(t.Value))
+		i, err := eclaType.NewInt(t.Value)
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(t, err.Error(), errorHandler.LevelFatal)
+		}
+		return NewMainBus(i)
 	case lexer.STRING:
 		str, err := eclaType.NewString(t.Value)
 		if err != nil {
-			env.ErrorHandle.HandleError(t.StartLine(), t.StartPos(), err.Error(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(t, err.Error(), errorHandler.LevelFatal)
 		}
 		return NewMainBus(str)
+	case lexer.RAWSTRING:
+		return NewMainBus(eclaType.String(t.Value))
 	case lexer.BOOL:
 		b, err := eclaType.NewBool(t.Value)
 		if err != nil {
-			env.ErrorHandle.HandleError(t.StartLine(), t.StartPos(), err.Error(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(t, err.Error(), errorHandler.LevelFatal)
 		}
 		return NewMainBus(b)
 	case lexer.FLOAT:
-		return NewMainBus(eclaType.NewFloat(t.Value))
+		f, err := eclaType.NewFloat(t.Value)
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(t, err.Error(), errorHandler.LevelFatal)
+		}
+		return NewMainBus(f)
 	case lexer.CHAR:
 		c, err := eclaType.NewChar(t.Value)
 		if err != nil {
-			env.ErrorHandle.HandleError(t.StartLine(), t.StartPos(), err.Error(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(t, err.Error(), errorHandler.LevelFatal)
 		}
 		return NewMainBus(c)
 	case "VAR":
-		v, ok := env.GetVar(t.Value)
+		v, ok := env.lookupVar(t)
 		if !ok {
-			env.ErrorHandle.HandleError(t.StartLine(), t.StartPos(), "variable "+t.Value+" not found", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(t, "variable "+t.Value+" not found", errorHandler.LevelFatal)
 		}
 		return NewMainBus(v)
 	case "NULL":
 		return NewMainBus(eclaType.NewNull())
 	default:
-		env.ErrorHandle.HandleError(t.StartLine(), t.StartPos(), "Unknown type "+t.Type, errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(t, "Unknown type "+t.Type, errorHandler.LevelFatal)
 		return NewNoneBus()
 	}
 }
@@ -51,82 +61,82 @@ func New(t parser.Literal, env *Env) *Bus {
 // RunVariableDecl executes a parser.VariableDecl.
 func RunVariableDecl(tree parser.VariableDecl, env *Env) {
 	if tree.Value == nil {
-		if env.CheckIfVarExistsInCurrentScope(tree.Name) {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "variable "+tree.Name+" already exists", errorHandler.LevelFatal)
+		if env.isDeclared(tree.Name, tree.Binding) {
+			env.ErrorHandle.HandleErrorAt(tree, "variable "+tree.Name+" already exists", errorHandler.LevelFatal)
 			return
 		}
 		switch tree.Type {
 		case parser.Int:
-			v, err := eclaType.NewVar(tree.Name, tree.Type, eclaType.NewInt("0"))
+			v, err := eclaType.NewVar(tree.Name, tree.Type, eclaType.Int(0))
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
-			env.SetVar(tree.Name, v)
+			env.declareVar(tree.Name, tree.Binding, v)
 		case parser.String:
 			str, err := eclaType.NewString("")
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
 			v, err := eclaType.NewVar(tree.Name, tree.Type, str)
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
-			env.SetVar(tree.Name, v)
+			env.declareVar(tree.Name, tree.Binding, v)
 		case parser.Bool:
 			b, err := eclaType.NewBool("false")
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
 			v, err := eclaType.NewVar(tree.Name, tree.Type, b)
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
-			env.SetVar(tree.Name, v)
+			env.declareVar(tree.Name, tree.Binding, v)
 		case parser.Float:
-			v, err := eclaType.NewVar(tree.Name, tree.Type, eclaType.NewFloat("0.0"))
+			v, err := eclaType.NewVar(tree.Name, tree.Type, eclaType.Float(0))
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
-			env.SetVar(tree.Name, v)
+			env.declareVar(tree.Name, tree.Binding, v)
 		case parser.Any:
 			val, e := eclaType.NewAnyEmpty()
 			if e != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), e.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, e.Error(), errorHandler.LevelFatal)
 			}
 			v, err := eclaType.NewVar(tree.Name, tree.Type, val)
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
-			env.SetVar(tree.Name, v)
+			env.declareVar(tree.Name, tree.Binding, v)
 		case parser.Char:
 			c, err := eclaType.NewChar("")
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
 			v, err := eclaType.NewVar(tree.Name, tree.Type, c)
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
-			env.SetVar(tree.Name, v)
+			env.declareVar(tree.Name, tree.Binding, v)
 		}
 		if eclaType.IsList(tree.Type) {
 			l, err := eclaType.NewList(tree.Type)
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
 			v, err := eclaType.NewVar(tree.Name, tree.Type, l)
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
-			env.SetVar(tree.Name, v)
+			env.declareVar(tree.Name, tree.Binding, v)
 		} else if eclaType.IsMap(tree.Type) {
 			m := eclaType.NewMap()
 			m.SetType(tree.Type)
 			v, err := eclaType.NewVar(tree.Name, tree.Type, m)
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
-			env.SetVar(tree.Name, v)
+			env.declareVar(tree.Name, tree.Binding, v)
 		} else if decl, ok := env.GetTypeDecl(tree.Type); ok {
 			switch decl.(type) {
 			case *eclaDecl.StructDecl:
@@ -134,45 +144,70 @@ func RunVariableDecl(tree parser.VariableDecl, env *Env) {
 				m.SetType(tree.Type)
 				v, err := eclaType.NewVar(tree.Name, tree.Type, m)
 				if err != nil {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 				}
-				env.SetVar(tree.Name, v)
+				env.declareVar(tree.Name, tree.Binding, v)
+			case *eclaDecl.InterfaceDecl:
+				i, err := eclaType.NewInterface(decl.(*eclaDecl.InterfaceDecl), eclaType.NewNull())
+				if err != nil {
+					env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
+				}
+				v, err := eclaType.NewVar(tree.Name, tree.Type, i)
+				if err != nil {
+					env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
+				}
+				env.declareVar(tree.Name, tree.Binding, v)
 			}
 		}
 	} else {
 		busCollection := RunTree(tree.Value, env)
 		if IsMultipleBus(busCollection) {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunVariableDecl.\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunVariableDecl.\nPlease open issue", errorHandler.LevelFatal)
 		}
-		v, err := eclaType.NewVar(tree.Name, tree.Type, busCollection[0].GetVal())
-		if err != nil {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+		declareVariable(tree, busCollection[0].GetVal(), env)
+	}
+}
+
+// declareVariable declares the variable of a parser.VariableDecl with its already evaluated value.
+func declareVariable(tree parser.VariableDecl, value eclaType.Type, env *Env) {
+	if decl, ok := env.GetTypeDecl(tree.Type); ok {
+		switch decl.(type) {
+		case *eclaDecl.InterfaceDecl:
+			i, err := eclaType.NewInterface(decl.(*eclaDecl.InterfaceDecl), value)
+			if err != nil {
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
+			}
+			value = i
 		}
-		if v.IsFunction() {
-			if fn, ok := env.GetVar(tree.Name); ok {
-				if fn.IsFunction() {
-					fn2 := v.GetFunction()
-					if slices.Contains(fn.GetFunction().GetTypes(), fn2.GetType()) {
-						env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "Cannot overwrite this function "+tree.Name, errorHandler.LevelFatal)
-					}
-					fn.GetFunction().AddOverload(fn2.Args[0], fn2.GetBody(), fn2.GetReturn())
-				} else {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "Cannot overload a non-function variable "+tree.Name, errorHandler.LevelFatal)
+	}
+	v, err := eclaType.NewVar(tree.Name, tree.Type, value)
+	if err != nil {
+		env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
+	}
+	if v.IsFunction() {
+		if fn, ok := env.getVar(tree.Name, tree.Binding); ok {
+			if fn.IsFunction() {
+				fn2 := v.GetFunction()
+				if slices.Contains(fn.GetFunction().GetTypes(), fn2.GetType()) {
+					env.ErrorHandle.HandleErrorAt(tree, "Cannot overwrite this function "+tree.Name, errorHandler.LevelFatal)
 				}
+				fn.GetFunction().AddOverload(fn2.Args[0], fn2.GetBody(), fn2.GetReturn())
 			} else {
-				if !env.CheckIfVarExistsInCurrentScope(tree.Name) {
-					env.SetVar(tree.Name, v)
-				} else {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "Cannot reassign a variable "+tree.Name, errorHandler.LevelFatal)
-				}
+				env.ErrorHandle.HandleErrorAt(tree, "Cannot overload a non-function variable "+tree.Name, errorHandler.LevelFatal)
 			}
 		} else {
-			if !env.CheckIfVarExistsInCurrentScope(tree.Name) {
-				env.SetVar(tree.Name, v)
+			if !env.isDeclared(tree.Name, tree.Binding) {
+				env.declareVar(tree.Name, tree.Binding, v)
 			} else {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "Cannot reassign a variable "+tree.Name, errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, "Cannot reassign a variable "+tree.Name, errorHandler.LevelFatal)
 			}
 		}
+	} else {
+		if !env.isDeclared(tree.Name, tree.Binding) {
+			env.declareVar(tree.Name, tree.Binding, v)
+		} else {
+			env.ErrorHandle.HandleErrorAt(tree, "Cannot reassign a variable "+tree.Name, errorHandler.LevelFatal)
+		}
 	}
 }
 
@@ -182,7 +217,7 @@ func RunArrayLiteral(tree parser.ArrayLiteral, env *Env) *Bus {
 	for _, v := range tree.Values {
 		busCollection := RunTree(v, env)
 		if IsMultipleBus(busCollection) {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunArrayLiteral.\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunArrayLiteral.\nPlease open issue", errorHandler.LevelFatal)
 		}
 		values = append(values, busCollection[0].GetVal())
 	}
@@ -195,30 +230,38 @@ func RunArrayLiteral(tree parser.ArrayLiteral, env *Env) *Bus {
 	}
 	l, err := eclaType.NewList(typ)
 	if err != nil {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 	}
 	err = l.SetValue(values)
 	if err != nil {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 	}
 	return NewMainBus(l)
 }
 
 // RunFunctionDecl executes a parser.FunctionDecl.
 func RunFunctionDecl(tree parser.FunctionDecl, env *Env) {
+	if tree.Receiver != nil {
+		RunMethodDecl(tree, env)
+		return
+	}
 	declared, _ := env.Vars.Get(tree.Name)
 	if !env.CheckIfVarExistsInCurrentScope(tree.Name) {
 		fn := eclaType.NewFunction(tree.Name, tree.Prototype.Parameters, tree.Body, tree.Prototype.ReturnTypes)
+		// a function declared in a block uses the variables of the scopes enclosing its declaration
+		if env.Vars.GetDeepestScope() != env.Vars {
+			fn.SetClosure(env.newClosure())
+		}
 		err := env.SetFunction(tree.Name, fn)
 		if err != nil {
-			env.ErrorHandle.HandleError(tree.StartPos(), tree.StartLine(), err.Error(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 		}
 	} else {
 		if !declared.IsFunction() {
-			env.ErrorHandle.HandleError(tree.StartPos(),
-				0,
-				"Variable "+tree.Name+" already exists.",
-				errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, "Variable "+tree.Name+" already exists.", errorHandler.LevelFatal)
+		} else if declared.GetFunction() == nil {
+			// the built-in functions registered from Go are only overloaded from Go
+			env.ErrorHandle.HandleErrorAt(tree, "Cannot use built-in function name "+tree.Name+" as function name", errorHandler.LevelFatal)
 		} else {
 			declared.GetFunction().AddOverload(tree.Prototype.Parameters, tree.Body, tree.Prototype.ReturnTypes)
 		}
@@ -232,14 +275,14 @@ func RunMapLiteral(tree parser.MapLiteral, env *Env) *Bus {
 	for _, v := range tree.Values {
 		busCollection := RunTree(v, env)
 		if IsMultipleBus(busCollection) {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunMapLiteral.\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunMapLiteral.\nPlease open issue", errorHandler.LevelFatal)
 		}
 		values = append(values, busCollection[0].GetVal())
 	}
 	for _, k := range tree.Keys {
 		busCollection := RunTree(k, env)
 		if IsMultipleBus(busCollection) {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunMapLiteral.\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunMapLiteral.\nPlease open issue", errorHandler.LevelFatal)
 		}
 		keys = append(keys, busCollection[0].GetVal())
 	}
@@ -248,7 +291,7 @@ func RunMapLiteral(tree parser.MapLiteral, env *Env) *Bus {
 	m.Values = values
 	err := m.SetAutoType()
 	if err != nil {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 	}
 	return NewMainBus(m)
 }
@@ -256,4 +299,38 @@ func RunMapLiteral(tree parser.MapLiteral, env *Env) *Bus {
 func RunStructDecl(tree parser.StructDecl, env *Env) {
 	strdecl := eclaDecl.NewStructDecl(tree)
 	env.AddTypeDecl(strdecl)
+	for _, method := range tree.Methods {
+		addMethod(strdecl, method, env)
+	}
+}
+
+// RunInterfaceDecl executes a parser.InterfaceDecl.
+func RunInterfaceDecl(tree parser.InterfaceDecl, env *Env) {
+	env.AddTypeDecl(eclaDecl.NewInterfaceDecl(tree))
+}
+
+// RunMethodDecl executes a parser.FunctionDecl with a receiver by binding it to the struct of its receiver.
+func RunMethodDecl(tree parser.FunctionDecl, env *Env) {
+	decl, ok := env.GetTypeDecl(tree.Receiver.Type)
+	strdecl, isStruct := decl.(*eclaDecl.StructDecl)
+	if !ok || !isStruct {
+		env.ErrorHandle.HandleErrorAt(tree, "Cannot declare method "+tree.Name+" on unknown struct "+tree.Receiver.Type, errorHandler.LevelFatal)
+		return
+	}
+	// the built-in error struct is shared by every Env and cannot be extended
+	if strdecl == eclaType.ErrorDecl {
+		env.ErrorHandle.HandleErrorAt(tree, "Cannot declare method "+tree.Name+" on the built-in struct "+parser.Error, errorHandler.LevelFatal)
+		return
+	}
+	addMethod(strdecl, tree, env)
+}
+
+// addMethod binds the method declared by tree to the struct declaration.
+func addMethod(strdecl *eclaDecl.StructDecl, tree parser.FunctionDecl, env *Env) {
+	fn := eclaType.NewMethod(tree.Name, tree.Receiver.Name, tree.Prototype.Parameters, tree.Body, tree.Prototype.ReturnTypes)
+	fn.SetClosure(env.newClosure())
+	err := strdecl.AddMethod(tree.Name, fn)
+	if err != nil {
+		env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
+	}
 }
@@ -2,7 +2,6 @@ package interpreter
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/Eclalang/Ecla/errorHandler"
 	"github.com/Eclalang/Ecla/interpreter/eclaKeyWord"
@@ -23,7 +22,7 @@ func AssignementTypeChecking(tree parser.VariableAssignStmt, type1 string, type2
 		return true
 	}
 	if type1 != type2 {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Cannot assign %s to %s", type2, type1), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Cannot assign %s to %s", type2, type1), errorHandler.LevelFatal)
 	}
 	return false
 }
@@ -31,7 +30,7 @@ func AssignementTypeChecking(tree parser.VariableAssignStmt, type1 string, type2
 // TODO : Remove this function @mkarten
 func HandleError(tree parser.VariableAssignStmt, err error, env *Env) {
 	if err != nil {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 	}
 }
 
@@ -43,20 +42,25 @@ func getPointerToSelectorExpr(tree parser.SelectorExpr, env *Env, parent *eclaTy
 		switch tree.Expr.(type) {
 		case parser.Literal:
 			if tree.Expr.(parser.Literal).Type == "VAR" {
-				variable, ok := env.GetVar(tree.Expr.(parser.Literal).Value)
+				variable, ok := env.lookupVar(tree.Expr.(parser.Literal))
 				if !ok {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "variable "+tree.Expr.(parser.Literal).Value+" not found", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree, "variable "+tree.Expr.(parser.Literal).Value+" not found", errorHandler.LevelFatal)
 				}
 				temp = &variable.Value
 			} else {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Cannot run assignment on type %s", tree.Expr.(parser.Literal).Type), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Cannot run assignment on type %s", tree.Expr.(parser.Literal).Type), errorHandler.LevelFatal)
 			}
 		case parser.IndexableAccessExpr:
 			temp = IndexableAssignmentChecks(tree.Expr.(parser.IndexableAccessExpr), env)
 		default:
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "Cannot run assignment on type "+tree.Expr.(parser.Literal).Type, errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, "Cannot run assignment on type "+tree.Expr.(parser.Literal).Type, errorHandler.LevelFatal)
 		}
 	}
+	// the fields of a value typed by an interface are the ones of its struct
+	switch (*temp).(type) {
+	case *eclaType.Interface:
+		temp = &(*temp).(*eclaType.Interface).Value
+	}
 
 	if parent != nil {
 		switch tree.Expr.(type) {
@@ -66,29 +70,29 @@ func getPointerToSelectorExpr(tree parser.SelectorExpr, env *Env, parent *eclaTy
 				case *eclaType.Struct:
 					temp = (*temp).(*eclaType.Struct).GetField(tree.Expr.(parser.Literal).Value)
 					if temp == nil {
-						env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "field "+tree.Expr.(parser.Literal).Value+" not found", errorHandler.LevelFatal)
+						env.ErrorHandle.HandleErrorAt(tree, "field "+tree.Expr.(parser.Literal).Value+" not found", errorHandler.LevelFatal)
 					}
 				}
 			} else {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Cannot run assignement on type %s", tree.Expr.(parser.Literal).Type), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Cannot run assignement on type %s", tree.Expr.(parser.Literal).Type), errorHandler.LevelFatal)
 			}
 		case parser.IndexableAccessExpr:
 			switch (*temp).(type) {
 			case *eclaType.Struct:
 				temp = (*temp).(*eclaType.Struct).GetField(tree.Expr.(parser.IndexableAccessExpr).VariableName)
 				if temp == nil {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "field "+tree.Expr.(parser.IndexableAccessExpr).VariableName+" not found", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree, "field "+tree.Expr.(parser.IndexableAccessExpr).VariableName+" not found", errorHandler.LevelFatal)
 				}
 				for i := range tree.Expr.(parser.IndexableAccessExpr).Indexes {
 					busCollection := RunTree(tree.Expr.(parser.IndexableAccessExpr).Indexes[i], env)
 					if IsMultipleBus(busCollection) {
-						env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN getPointerToSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
+						env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN getPointerToSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
 					}
 					elem := busCollection[0].GetVal()
 					var err error
 					temp, err = (*temp).GetIndex(elem)
 					if err != nil {
-						env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "indexable variable assign : "+err.Error(), errorHandler.LevelFatal)
+						env.ErrorHandle.HandleErrorAt(tree, "indexable variable assign : "+err.Error(), errorHandler.LevelFatal)
 					}
 				}
 			}
@@ -102,23 +106,23 @@ func getPointerToSelectorExpr(tree parser.SelectorExpr, env *Env, parent *eclaTy
 			case *eclaType.Struct:
 				temp = (*temp).(*eclaType.Struct).GetField(tree.Sel.(parser.Literal).Value)
 				if temp == nil {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "field "+tree.Sel.(parser.Literal).Value+" not found", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree, "field "+tree.Sel.(parser.Literal).Value+" not found", errorHandler.LevelFatal)
 				}
 			}
 		} else {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Cannot run assignement on type %s", tree.Sel.(parser.Literal).Type), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Cannot run assignement on type %s", tree.Sel.(parser.Literal).Type), errorHandler.LevelFatal)
 		}
 	case parser.IndexableAccessExpr:
 		switch (*temp).(type) {
 		case *eclaType.Struct:
 			temp = (*temp).(*eclaType.Struct).GetField(tree.Sel.(parser.IndexableAccessExpr).VariableName)
 			if temp == nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "field "+tree.Sel.(parser.IndexableAccessExpr).VariableName+" not found", errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, "field "+tree.Sel.(parser.IndexableAccessExpr).VariableName+" not found", errorHandler.LevelFatal)
 			}
 			for i := range tree.Sel.(parser.IndexableAccessExpr).Indexes {
 				busCollection := RunTree(tree.Sel.(parser.IndexableAccessExpr).Indexes[i], env)
 				if IsMultipleBus(busCollection) {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN getPointerToSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN getPointerToSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
 				}
 				elem := busCollection[0].GetVal()
 				var err error
@@ -130,16 +134,16 @@ func getPointerToSelectorExpr(tree parser.SelectorExpr, env *Env, parent *eclaTy
 						t := (*parent).(*eclaType.Map)
 						err = t.AddKey(elem)
 						if err != nil {
-							env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+							env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 						}
 						temp, err = t.GetIndex(elem)
 						if err != nil {
-							env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+							env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 						}
 					}
 				default:
 					if err != nil {
-						env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+						env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 					}
 				}
 
@@ -148,36 +152,44 @@ func getPointerToSelectorExpr(tree parser.SelectorExpr, env *Env, parent *eclaTy
 	case parser.SelectorExpr:
 		temp = getPointerToSelectorExpr(tree.Sel.(parser.SelectorExpr), env, temp)
 	default:
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Cannot run this %T", tree.Sel), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Cannot run this %T", tree.Sel), errorHandler.LevelFatal)
 	}
 	return temp
 }
 
 // RunVariableAssignStmt Run assigns a variable.
 func RunVariableAssignStmt(tree parser.VariableAssignStmt, env *Env) {
-	var exprs []eclaType.Type
-	var exprsTypes []string
-	var vars []*eclaType.Type
-	var varsTypes []string
+	var values []eclaType.Type
 	// Calculate values of expressions behind the variable
 	if tree.Values[0] != nil {
 		for _, v := range tree.Values {
 			busses := RunTree(v, env)
 			for _, bus := range busses {
-				busVal := bus.GetVal()
-				switch busVal.(type) {
-				case *eclaType.List:
-					busVal = busVal.GetValue().(eclaType.Type)
-				case *eclaType.Map:
-					busVal = busVal.GetValue().(eclaType.Type)
-				case *eclaType.Var:
-					busVal = busVal.GetValue().(eclaType.Type)
-				}
-				exprs = append(exprs, busVal)
-				exprsTypes = append(exprsTypes, busVal.GetType())
+				values = append(values, bus.GetVal())
 			}
 		}
 	}
+	assignValues(tree, values, env)
+}
+
+// assignValues assigns the already evaluated values of a parser.VariableAssignStmt to its variables.
+func assignValues(tree parser.VariableAssignStmt, values []eclaType.Type, env *Env) {
+	var exprs []eclaType.Type
+	var exprsTypes []string
+	var vars []*eclaType.Type
+	var varsTypes []string
+	for _, busVal := range values {
+		switch busVal.(type) {
+		case *eclaType.List:
+			busVal = busVal.GetValue().(eclaType.Type)
+		case *eclaType.Map:
+			busVal = busVal.GetValue().(eclaType.Type)
+		case *eclaType.Var:
+			busVal = busVal.GetValue().(eclaType.Type)
+		}
+		exprs = append(exprs, busVal)
+		exprsTypes = append(exprsTypes, busVal.GetType())
+	}
 	for _, v := range tree.Names {
 		switch v.(type) {
 		case parser.IndexableAccessExpr:
@@ -186,14 +198,14 @@ func RunVariableAssignStmt(tree parser.VariableAssignStmt, env *Env) {
 			varsTypes = append(varsTypes, (*temp).GetType())
 		case parser.Literal:
 			if v.(parser.Literal).Type == "VAR" {
-				variable, ok := env.GetVar(v.(parser.Literal).Value)
+				variable, ok := env.lookupVar(v.(parser.Literal))
 				if !ok {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("variable %s not found", v.(parser.Literal).Value), errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("variable %s not found", v.(parser.Literal).Value), errorHandler.LevelFatal)
 				}
 				vars = append(vars, &(variable.Value))
 				varsTypes = append(varsTypes, variable.Value.GetType())
 			} else {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Cannot run assignement on type %s", tree.Names[0].(parser.Literal).Type), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Cannot run assignement on type %s", tree.Names[0].(parser.Literal).Type), errorHandler.LevelFatal)
 			}
 		case parser.SelectorExpr:
 			variable := getPointerToSelectorExpr(v.(parser.SelectorExpr), env, nil)
@@ -218,7 +230,7 @@ func RunVariableAssignStmt(tree parser.VariableAssignStmt, env *Env) {
 						fn := (*vars[i]).(*eclaType.Function)
 						err := fn.Override(fnTemp.Args[0], fnTemp.GetBody(), fnTemp.GetReturn())
 						if err != nil {
-							env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+							env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 						}
 					case *eclaType.Any:
 						tmp := (*vars[i]).(*eclaType.Any)
@@ -227,19 +239,29 @@ func RunVariableAssignStmt(tree parser.VariableAssignStmt, env *Env) {
 							fn := tmp.Value.(*eclaType.Function)
 							err := fn.Override(fnTemp.Args[0], fnTemp.GetBody(), fnTemp.GetReturn())
 							if err != nil {
-								env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+								env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 							}
 						default:
 							err := tmp.SetAny(fnTemp)
 							if err != nil {
-								env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+								env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 							}
 						}
 					default:
-						fmt.Printf("%T\n", *vars[i])
-						env.ErrorHandle.HandleError(tree.StartLine(), tree.StartLine(), "cannot assign function to none function", errorHandler.LevelFatal)
+						fmt.Fprintf(env.Stderr(), "%T\n", *vars[i])
+						env.ErrorHandle.HandleErrorAt(tree, "cannot assign function to none function", errorHandler.LevelFatal)
 					}
 				default:
+					switch (*vars[i]).(type) {
+					case *eclaType.Interface:
+						// a variable typed by an interface accepts any struct that implements it
+						itf, err := eclaType.NewInterface((*vars[i]).(*eclaType.Interface).Definition, exprs[i])
+						if err != nil {
+							env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
+						}
+						*vars[i] = itf
+						continue
+					}
 					isAny := AssignementTypeChecking(tree, varsTypes[i], exprsTypes[i], env)
 					if isAny {
 						*vars[i] = eclaType.NewAny(exprs[i])
@@ -317,7 +339,7 @@ func RunVariableAssignStmt(tree parser.VariableAssignStmt, env *Env) {
 			}
 
 		default:
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("%s is not a valid assignement operator", tree.Operator), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("%s is not a valid assignement operator", tree.Operator), errorHandler.LevelFatal)
 		}
 
 	} else if PreExecLen == 1 && NamesLen > 1 {
@@ -372,13 +394,13 @@ func RunVariableAssignStmt(tree parser.VariableAssignStmt, env *Env) {
 			}
 
 		default:
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("%s is not a valid assignement operator", tree.Operator), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("%s is not a valid assignement operator", tree.Operator), errorHandler.LevelFatal)
 		}
 	} else if PreExecLen == 0 && NamesLen >= 1 {
 		switch opp {
 		case parser.INCREMENT:
 			for i := 0; i < NamesLen; i++ {
-				addOne := eclaType.NewInt("1")
+				addOne := eclaType.Int(1)
 				AssignementTypeChecking(tree, varsTypes[i], addOne.GetType(), env)
 				temp, err := (*vars[i]).Add(addOne)
 				HandleError(tree, err, env)
@@ -386,32 +408,32 @@ func RunVariableAssignStmt(tree parser.VariableAssignStmt, env *Env) {
 			}
 		case parser.DECREMENT:
 			for i := 0; i < NamesLen; i++ {
-				subOne := eclaType.NewInt("1")
+				subOne := eclaType.Int(1)
 				AssignementTypeChecking(tree, varsTypes[i], subOne.GetType(), env)
 				temp, err := (*vars[i]).Sub(subOne)
 				HandleError(tree, err, env)
 				*vars[i] = temp
 			}
 		default:
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("%s is not a valid assignement operator", tree.Operator), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("%s is not a valid assignement operator", tree.Operator), errorHandler.LevelFatal)
 		}
 
 	} else {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Invalid assignment: %d rValues to %d lValues", PreExecLen, NamesLen), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Invalid assignment: %d rValues to %d lValues", PreExecLen, NamesLen), errorHandler.LevelFatal)
 	}
 }
 
 // IndexableAssignmentChecks checks if the indexable variable is valid
 func IndexableAssignmentChecks(index parser.IndexableAccessExpr, env *Env) *eclaType.Type {
-	v, ok := env.GetVar(index.VariableName)
+	v, ok := env.getVar(index.VariableName, index.Binding)
 	if !ok {
-		env.ErrorHandle.HandleError(index.StartLine(), index.StartPos(), "variable "+index.VariableName+" not found", errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(index, "variable "+index.VariableName+" not found", errorHandler.LevelFatal)
 	}
 	var temp = &v.Value
 	for i := range index.Indexes {
 		busCollection := RunTree(index.Indexes[i], env)
 		if IsMultipleBus(busCollection) {
-			env.ErrorHandle.HandleError(index.StartLine(), index.StartPos(), "MULTIPLE BUS IN IndexableAssignmentChecks.\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(index, "MULTIPLE BUS IN IndexableAssignmentChecks.\nPlease open issue", errorHandler.LevelFatal)
 			return nil
 		}
 		elem := busCollection[0].GetVal()
@@ -423,16 +445,16 @@ func IndexableAssignmentChecks(index parser.IndexableAccessExpr, env *Env) *ecla
 				t := v.Value.(*eclaType.Map)
 				err = t.AddKey(elem)
 				if err != nil {
-					env.ErrorHandle.HandleError(index.StartLine(), index.StartPos(), err.Error(), errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(index, err.Error(), errorHandler.LevelFatal)
 				}
 				temp, err = t.GetIndex(elem)
 				if err != nil {
-					env.ErrorHandle.HandleError(index.StartLine(), index.StartPos(), err.Error(), errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(index, err.Error(), errorHandler.LevelFatal)
 				}
 			}
 		default:
 			if err != nil {
-				env.ErrorHandle.HandleError(index.StartLine(), index.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(index, err.Error(), errorHandler.LevelFatal)
 			}
 		}
 
@@ -440,6 +462,36 @@ func IndexableAssignmentChecks(index parser.IndexableAccessExpr, env *Env) *ecla
 	return temp
 }
 
+// runLoopBody runs one iteration of a loop body in its own scope.
+// It returns the bus that must be propagated out of the loop (a return, or a break/continue aimed at an outer loop)
+// and whether the loop must stop.
+func runLoopBody(body []parser.Node, label string, env *Env) (*Bus, bool) {
+	env.NewScope(SCOPE_LOOP)
+	defer env.EndScope()
+	for _, stmt := range body {
+		BusCollection := RunTree(stmt, env)
+		if IsMultipleBus(BusCollection) {
+			env.ErrorHandle.HandleErrorAt(stmt, "MULTIPLE BUS IN runLoopBody\nPlease open issue", errorHandler.LevelFatal)
+		}
+		temp := BusCollection[0]
+		switch {
+		case temp.IsReturn():
+			return temp, true
+		case temp.IsBreak():
+			if temp.Targets(label) {
+				return nil, true
+			}
+			return temp, true
+		case temp.IsContinue():
+			if temp.Targets(label) {
+				return nil, false
+			}
+			return temp, true
+		}
+	}
+	return nil, false
+}
+
 // RunWhileStmt runs the while statement
 func RunWhileStmt(tree parser.WhileStmt, env *Env) *Bus {
 	env.NewScope(SCOPE_LOOP)
@@ -447,28 +499,20 @@ func RunWhileStmt(tree parser.WhileStmt, env *Env) *Bus {
 	while := eclaKeyWord.NewWhile(tree.Cond, tree.Body)
 	BusCollection := RunTree(while.Condition, env)
 	if IsMultipleBus(BusCollection) {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunWhileStmt.\nPlease open issue", errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunWhileStmt.\nPlease open issue", errorHandler.LevelFatal)
 	}
 	for BusCollection[0].GetVal().GetString() == "true" { //TODO add error
-		env.NewScope(SCOPE_LOOP)
-		for _, stmt := range while.Body {
-			BusCollection2 := RunTree(stmt, env)
-			if IsMultipleBus(BusCollection2) {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunWhileStmt\nPlease open issue", errorHandler.LevelFatal)
-			}
-			temp := BusCollection2[0]
-
-			// TODO: add break and continue
-			// TODO add multiple bus
-			if temp.IsReturn() {
-				env.EndScope()
-				return temp
-			}
+		// TODO add multiple bus
+		temp, stop := runLoopBody(while.Body, tree.Label, env)
+		if temp != nil {
+			return temp
+		}
+		if stop {
+			break
 		}
-		env.EndScope()
 		BusCollection = RunTree(while.Condition, env)
 		if IsMultipleBus(BusCollection) {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunWhileStmt\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunWhileStmt\nPlease open issue", errorHandler.LevelFatal)
 		}
 	}
 	return NewNoneBus()
@@ -477,11 +521,101 @@ func RunWhileStmt(tree parser.WhileStmt, env *Env) *Bus {
 func generateForRangeKeys(max int) []eclaType.Type {
 	var keys []eclaType.Type
 	for i := 0; i < max; i++ {
-		keys = append(keys, eclaType.NewInt(strconv.Itoa(i)))
+		keys = append(keys, eclaType.Int(i))
 	}
 	return keys
 }
 
+// rangeLoop is the state of a for loop iterating over a list, a string or a map.
+type rangeLoop struct {
+	iterable   eclaType.Type
+	key, value *eclaType.Var
+	keys       []eclaType.Type
+	index      int
+}
+
+// newRangeLoop declares the key and the value of the for loop in the current scope and returns the state of the iteration over iterable.
+func newRangeLoop(For parser.ForStmt, list eclaType.Type, env *Env) *rangeLoop {
+	var typ string
+	var l int
+	switch list.(type) {
+	case *eclaType.Var:
+		list = list.(*eclaType.Var).Value
+	}
+
+	var k *eclaType.Var
+	var err error
+
+	var keys []eclaType.Type
+
+	switch list.(type) {
+	case *eclaType.List:
+		typ = list.(*eclaType.List).GetType()[2:]
+		k, err = eclaType.NewVar(For.KeyToken.Value, parser.Int, eclaType.Int(0))
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(For.RangeExpr, err.Error(), errorHandler.LevelFatal)
+		}
+		l, err = list.(*eclaType.List).Len()
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(For.RangeExpr, err.Error(), errorHandler.LevelFatal)
+		}
+		keys = generateForRangeKeys(l)
+	case eclaType.String:
+		typ = parser.Char
+		k, err = eclaType.NewVar(For.KeyToken.Value, parser.Int, eclaType.Int(0))
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(For.RangeExpr, err.Error(), errorHandler.LevelFatal)
+		}
+		l, err = list.(eclaType.String).Len()
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(For.RangeExpr, err.Error(), errorHandler.LevelFatal)
+		}
+		keys = generateForRangeKeys(l)
+	case *eclaType.Map:
+		k, err = eclaType.NewVar(For.KeyToken.Value, list.(*eclaType.Map).TypKey, list.(*eclaType.Map).Keys[0])
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(For.RangeExpr, err.Error(), errorHandler.LevelFatal)
+		}
+		typ = list.(*eclaType.Map).TypVal
+		l, err = list.(*eclaType.Map).Len()
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(For.RangeExpr, err.Error(), errorHandler.LevelFatal)
+		}
+		keys = list.(*eclaType.Map).Keys
+	default:
+		env.ErrorHandle.HandleErrorAt(For.RangeExpr, "type "+list.GetType()+" not supported", errorHandler.LevelFatal)
+	}
+
+	env.declareVar(For.KeyToken.Value, For.KeyBinding, k)
+	v, err := eclaType.NewVarEmpty(For.ValueToken.Value, typ)
+	if err != nil {
+		env.ErrorHandle.HandleErrorAt(For.RangeExpr, err.Error(), errorHandler.LevelFatal)
+	}
+	env.declareVar(For.ValueToken.Value, For.ValueBinding, v)
+	return &rangeLoop{iterable: list, key: k, value: v, keys: keys[:l]}
+}
+
+// next sets the key and the value of the next iteration, it returns false once every element was iterated over.
+func (r *rangeLoop) next(For parser.ForStmt, env *Env) bool {
+	if r.index >= len(r.keys) {
+		return false
+	}
+	key := r.keys[r.index]
+	r.index++
+	if err := r.key.SetVar(key); err != nil {
+		return false
+	}
+	val, err := r.iterable.GetIndex(key)
+	if err != nil {
+		env.ErrorHandle.HandleErrorAt(For.RangeExpr, err.Error(), errorHandler.LevelFatal)
+	}
+	err = r.value.SetVar(*val)
+	if err != nil {
+		env.ErrorHandle.HandleErrorAt(For.RangeExpr, err.Error(), errorHandler.LevelFatal)
+	}
+	return true
+}
+
 // RunForStmt runs the for statement
 func RunForStmt(For parser.ForStmt, env *Env) *Bus {
 	env.NewScope(SCOPE_LOOP)
@@ -491,118 +625,37 @@ func RunForStmt(For parser.ForStmt, env *Env) *Bus {
 		f := eclaKeyWord.NewForRange([]eclaType.Type{}, For.RangeExpr, For.KeyToken, For.ValueToken, For.Body)
 		BusCollection := RunTree(f.RangeExpr, env)
 		if IsMultipleBus(BusCollection) {
-			env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), "MULTIPLE BUS IN RunForStmt\nPlease open issue", errorHandler.LevelFatal)
-		}
-		list := BusCollection[0].GetVal()
-		var typ string
-		var l int //...
-		//fmt.Printf("%T", list)
-		switch list.(type) {
-		case *eclaType.Var:
-			list = list.(*eclaType.Var).Value
-		}
-
-		var k *eclaType.Var
-		var err error
-
-		var keys []eclaType.Type
-
-		switch list.(type) {
-		case *eclaType.List:
-			typ = list.(*eclaType.List).GetType()[2:]
-			k, err = eclaType.NewVar(f.KeyToken.Value, parser.Int, eclaType.NewInt("0"))
-			if err != nil {
-				env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
-			}
-			l, err = list.(*eclaType.List).Len()
-			if err != nil {
-				env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
-			}
-			keys = generateForRangeKeys(l)
-		case eclaType.String:
-			typ = parser.Char
-			k, err = eclaType.NewVar(f.KeyToken.Value, parser.Int, eclaType.NewInt("0"))
-			if err != nil {
-				env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
-			}
-			l, err = list.(eclaType.String).Len()
-			if err != nil {
-				env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
-			}
-			keys = generateForRangeKeys(l)
-		case *eclaType.Map:
-			k, err = eclaType.NewVar(f.KeyToken.Value, list.(*eclaType.Map).TypKey, list.(*eclaType.Map).Keys[0])
-			if err != nil {
-				env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
-			}
-			typ = list.(*eclaType.Map).TypVal
-			l, err = list.(*eclaType.Map).Len()
-			if err != nil {
-				env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
-			}
-			keys = list.(*eclaType.Map).Keys
-		default:
-			env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), "type "+list.GetType()+" not supported", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(f.RangeExpr, "MULTIPLE BUS IN RunForStmt\nPlease open issue", errorHandler.LevelFatal)
 		}
-
-		env.SetVar(f.KeyToken.Value, k)
-		v, err := eclaType.NewVarEmpty(f.ValueToken.Value, typ)
-		if err != nil {
-			env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
-		}
-		env.SetVar(f.ValueToken.Value, v)
-		for i := 0; i < l; i++ {
-			err := k.SetVar(keys[i])
-			if err != nil {
-				return nil
-			}
-			val, err := list.GetIndex(keys[i])
-			if err != nil {
-				env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
-			}
-			err = v.SetVar(*val)
-			if err != nil {
-				env.ErrorHandle.HandleError(f.RangeExpr.StartLine(), f.RangeExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
+		r := newRangeLoop(For, BusCollection[0].GetVal(), env)
+		for r.next(For, env) {
+			temp, stop := runLoopBody(f.Body, For.Label, env)
+			if temp != nil {
+				return temp
 			}
-			env.NewScope(SCOPE_LOOP)
-			for _, stmt := range f.Body {
-				BusCollection2 := RunTree(stmt, env)
-				if IsMultipleBus(BusCollection2) {
-					env.ErrorHandle.HandleError(stmt.StartLine(), stmt.StartPos(), "MULTIPLE BUS IN RunForStmt\nPlease open issue", errorHandler.LevelFatal)
-				}
-				temp := BusCollection2[0]
-				if temp.IsReturn() {
-					env.EndScope()
-					return temp
-				}
+			if stop {
+				break
 			}
-			env.EndScope()
 		}
 	} else {
 		f := eclaKeyWord.NewForI([]eclaType.Type{}, For.Body, For.CondExpr, For.PostAssignStmt)
 		RunTree(For.InitDecl, env)
 		BusCollection := RunTree(f.Condition, env)
 		if IsMultipleBus(BusCollection) {
-			env.ErrorHandle.HandleError(f.Condition.StartLine(), f.Condition.StartPos(), "MULTIPLE BUS IN RunForStmt\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(f.Condition, "MULTIPLE BUS IN RunForStmt\nPlease open issue", errorHandler.LevelFatal)
 		}
 		for BusCollection[0].GetVal().GetString() == "true" {
-			env.NewScope(SCOPE_LOOP)
-			for _, stmt := range f.Body {
-				BusCollection2 := RunTree(stmt, env)
-				if IsMultipleBus(BusCollection2) {
-					env.ErrorHandle.HandleError(stmt.StartLine(), stmt.StartPos(), "MULTIPLE BUS IN RunForStmt\nPlease open issue", errorHandler.LevelFatal)
-				}
-				temp := BusCollection2[0]
-				if temp.IsReturn() {
-					env.EndScope()
-					return temp
-				}
+			temp, stop := runLoopBody(f.Body, For.Label, env)
+			if temp != nil {
+				return temp
+			}
+			if stop {
+				break
 			}
-			env.EndScope()
 			RunTree(f.Post, env)
 			BusCollection = RunTree(f.Condition, env)
 			if IsMultipleBus(BusCollection) {
-				env.ErrorHandle.HandleError(f.Condition.StartLine(), f.Condition.StartPos(), "MULTIPLE BUS IN RunForStmt\nPlease open issue", errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(f.Condition, "MULTIPLE BUS IN RunForStmt\nPlease open issue", errorHandler.LevelFatal)
 			}
 		}
 	}
@@ -613,7 +666,7 @@ func RunForStmt(For parser.ForStmt, env *Env) *Bus {
 func RunIfStmt(tree parser.IfStmt, env *Env) *Bus {
 	BusCollection := RunTree(tree.Cond, env)
 	if IsMultipleBus(BusCollection) {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunIfStmt\nPlease open issue", errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunIfStmt\nPlease open issue", errorHandler.LevelFatal)
 	}
 	if BusCollection[0].GetVal().GetString() == "true" { //TODO add error
 		env.NewScope(SCOPE_CONDITION)
@@ -621,10 +674,10 @@ func RunIfStmt(tree parser.IfStmt, env *Env) *Bus {
 		for _, stmt := range tree.Body {
 			BusCollection := RunTree(stmt, env)
 			if IsMultipleBus(BusCollection) {
-				env.ErrorHandle.HandleError(stmt.StartLine(), stmt.StartPos(), "MULTIPLE BUS IN RunIfStmt\nPlease open issue", errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(stmt, "MULTIPLE BUS IN RunIfStmt\nPlease open issue", errorHandler.LevelFatal)
 			}
 			temp := BusCollection[0]
-			if temp.IsReturn() {
+			if temp.IsControlFlow() {
 				return temp
 			}
 		}
@@ -637,10 +690,10 @@ func RunIfStmt(tree parser.IfStmt, env *Env) *Bus {
 			for _, stmt := range tree.ElseStmt.Body {
 				BusCollection := RunTree(stmt, env)
 				if IsMultipleBus(BusCollection) {
-					env.ErrorHandle.HandleError(stmt.StartLine(), stmt.StartPos(), "MULTIPLE BUS IN RunIfStmt\nPlease open issue", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(stmt, "MULTIPLE BUS IN RunIfStmt\nPlease open issue", errorHandler.LevelFatal)
 				}
 				temp := BusCollection[0]
-				if temp.IsReturn() {
+				if temp.IsControlFlow() {
 					return temp
 				}
 			}
@@ -661,7 +714,124 @@ func RunReturnStmt(tree parser.ReturnStmt, env *Env) []eclaType.Type {
 	return l
 }
 
+// RunBreakStmt runs the break statement
+func RunBreakStmt(tree parser.BreakStmt, env *Env) *Bus {
+	return NewBreakBus(tree.Label)
+}
+
+// RunContinueStmt runs the continue statement
+func RunContinueStmt(tree parser.ContinueStmt, env *Env) *Bus {
+	return NewContinueBus(tree.Label)
+}
+
 // RunMurlocStmt executes a parser.MurlocStmt.
 func RunMurlocStmt(stmt parser.MurlocStmt, env *Env) {
-	env.ErrorHandle.HandleError(stmt.StartLine(), stmt.StartPos(), "Mrgle, Mmmm Uuua !", errorHandler.LevelFatal)
+	env.ErrorHandle.HandleErrorAt(stmt, "Mrgle, Mmmm Uuua !", errorHandler.LevelFatal)
+}
+
+// RunTryStmt executes a parser.TryStmt.
+func RunTryStmt(tree parser.TryStmt, env *Env) *Bus {
+	bus, caught := runCatchingErrors(env, func() *Bus {
+		return runBlock(tree.Body, SCOPE_TRY, env)
+	})
+	if caught != nil && tree.CatchStmt != nil {
+		catchErr := *caught
+		bus, caught = runCatchingErrors(env, func() *Bus {
+			return runCatchStmt(*tree.CatchStmt, catchErr, env)
+		})
+	}
+	if tree.FinallyStmt != nil {
+		finallyBus := runBlock(tree.FinallyStmt.Body, SCOPE_FINALLY, env)
+		if finallyBus.IsControlFlow() {
+			return finallyBus
+		}
+	}
+	if caught != nil {
+		// the error was not handled, it is raised again to the enclosing try or to the user
+		env.ErrorHandle.Raise(*caught)
+		return NewNoneBus()
+	}
+	return bus
+}
+
+// runCatchingErrors executes f and returns the fatal error raised during its execution instead of exiting.
+// The state of env is restored to what it was before calling f when an error is caught.
+func runCatchingErrors(env *Env, f func() *Bus) (bus *Bus, caught *errorHandler.Error) {
+	state := env.saveState()
+	env.ErrorHandle.EnterTry()
+	defer func() {
+		env.ErrorHandle.ExitTry()
+		if r := recover(); r != nil {
+			err, ok := r.(errorHandler.Error)
+			if !ok {
+				panic(r)
+			}
+			env.restoreState(state)
+			bus, caught = NewNoneBus(), &err
+		}
+	}()
+	return f(), nil
+}
+
+// runCatchStmt executes the body of a parser.CatchStmt with the caught error bound to its error name.
+func runCatchStmt(tree parser.CatchStmt, caught errorHandler.Error, env *Env) *Bus {
+	env.NewScope(SCOPE_CATCH)
+	defer env.EndScope()
+	if tree.ErrorName != "" {
+		v, err := eclaType.NewVar(tree.ErrorName, parser.Error, eclaType.NewError(caught.Msg, caught.Line, caught.Col))
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
+		}
+		env.declareVar(tree.ErrorName, tree.ErrorBinding, v)
+	}
+	return runBody(tree.Body, env)
+}
+
+// runBlock executes a body in a new scope of the given type.
+func runBlock(body []parser.Node, scope ScopeType, env *Env) *Bus {
+	env.NewScope(scope)
+	defer env.EndScope()
+	return runBody(body, env)
+}
+
+// runBody executes a body in the current scope and returns the first return, break or continue bus encountered.
+func runBody(body []parser.Node, env *Env) *Bus {
+	for _, stmt := range body {
+		BusCollection := RunTree(stmt, env)
+		if IsMultipleBus(BusCollection) {
+			env.ErrorHandle.HandleErrorAt(stmt, "MULTIPLE BUS IN runBody\nPlease open issue", errorHandler.LevelFatal)
+		}
+		temp := BusCollection[0]
+		if temp.IsControlFlow() {
+			return temp
+		}
+	}
+	return NewNoneBus()
+}
+
+// RunThrowStmt executes a parser.ThrowStmt.
+// Thrown errors keep their message and position, any other value is used as the message of a new error.
+func RunThrowStmt(tree parser.ThrowStmt, env *Env) {
+	BusCollection := RunTree(tree.Value, env)
+	if IsMultipleBus(BusCollection) {
+		env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunThrowStmt\nPlease open issue", errorHandler.LevelFatal)
+	}
+	value := BusCollection[0].GetVal()
+	switch value.(type) {
+	case *eclaType.Var:
+		value = value.(*eclaType.Var).Value
+	}
+	switch value.(type) {
+	case *eclaType.Any:
+		value = value.(*eclaType.Any).Value
+	}
+	if eclaType.IsError(value) {
+		e := value.(*eclaType.Struct)
+		message, _ := e.Get("message")
+		line, _ := e.Get("line")
+		column, _ := e.Get("column")
+		env.ErrorHandle.HandleError(int(line.(eclaType.Int)), int(column.(eclaType.Int)), message.String(), errorHandler.LevelFatal)
+		return
+	}
+	env.ErrorHandle.HandleErrorAt(tree, value.String(), errorHandler.LevelFatal)
 }
@@ -116,6 +116,8 @@ func Test_getPointerToSelectorExpr(t *testing.T) {
 	}
 
 	env = NewEnv()
+	// the selector parsed alone depends on a variable unknown to its parser
+	env.ErrorHandle.HookExit(func(int) {})
 	env.SetCode("struct A{a : []int;}a := A{[1,2,3]};")
 	env.Execute()
 
@@ -137,6 +139,8 @@ func Test_getPointerToSelectorExpr(t *testing.T) {
 	}
 
 	env = NewEnv()
+	// the selector parsed alone depends on a variable unknown to its parser
+	env.ErrorHandle.HookExit(func(int) {})
 	env.SetCode("struct A{a : map[int]int;}a := A{{1:1,2:2,3:3}};")
 	env.Execute()
 
@@ -173,3 +177,188 @@ func TestRunMurlocStmt(t *testing.T) {
 	}
 
 }
+
+// checkVars checks that the variables of the env hold the expected values, given as their string without the name.
+func checkVars(t *testing.T, env *Env, expected map[string]string) {
+	t.Helper()
+	for name, value := range expected {
+		v, ok := env.GetVar(name)
+		if !ok {
+			t.Errorf("Expected variable %s, got nil", name)
+			continue
+		}
+		if v.String() != name+" = "+value {
+			t.Errorf("Expected %s = %s, got %s", name, value, v.String())
+		}
+	}
+}
+
+func TestRunBreakContinueStmt(t *testing.T) {
+	env := NewEnv()
+	env.SetCode(`
+	var sum int = 0;
+	for (i := 0, i < 10, i++) {
+		if (i == 2) {
+			continue;
+		}
+		if (i == 5) {
+			break;
+		}
+		sum += i;
+	}
+	var n int = 0;
+	while (true) {
+		n++;
+		{
+			if (n == 3) {
+				break;
+			}
+		}
+	}
+	var pairs int = 0;
+	outer: for (a := 0, a < 3, a++) {
+		for (b := 0, b < 3, b++) {
+			if (b == 1) {
+				continue outer;
+			}
+			if (a == 2) {
+				break outer;
+			}
+			pairs++;
+		}
+	}
+	var count int = 0;
+	for (k, v range [1, 2, 3, 4]) {
+		if (v == 2) {
+			continue;
+		}
+		if (v == 4) {
+			break;
+		}
+		count += v;
+	}
+	for (k, v range {"a": 1, "b": 2}) {
+		break;
+	}
+	`)
+	env.Execute()
+
+	checkVars(t, env, map[string]string{"sum": "8", "n": "3", "pairs": "2", "count": "4"})
+}
+
+func TestRunTryStmt(t *testing.T) {
+	exited := false
+	env := NewEnv()
+	env.ErrorHandle.HookExit(func(int) {
+		exited = true
+	})
+	defer env.ErrorHandle.RestoreExit()
+	env.SetCode(`
+	function div(a : int, b : int) (int) {
+		return a / b;
+	}
+	var msg string = "";
+	var line int = 0;
+	var steps string = "";
+	try {
+		steps += "try ";
+		var x int = div(1, 0);
+		steps += "unreachable ";
+	} catch (e) {
+		msg = e.message;
+		line = e.line;
+		steps += "catch ";
+	} finally {
+		steps += "finally";
+	}
+	var thrown string = "";
+	try {
+		throw "boom";
+	} catch (e) {
+		thrown = e.message;
+	}
+	var rethrown string = "";
+	try {
+		try {
+			var l []int = [1];
+			l[5] = 2;
+		} finally {
+			rethrown += "finally ";
+		}
+	} catch (e) {
+		rethrown += "caught";
+	}
+	function f() (int) {
+		try {
+			throw "error";
+		} catch {
+			return 1;
+		}
+		return 2;
+	}
+	var ret int = f();
+	var caughtInLoop int = 0;
+	for (i := 0, i < 4, i++) {
+		try {
+			if (i % 2 == 0) {
+				throw i;
+			}
+		} catch (e) {
+			caughtInLoop++;
+			continue;
+		}
+	}
+	var typ string = "";
+	try {
+		var s string = "a" - 1;
+	} catch (e) {
+		typ = typeOf(e);
+	}
+	`)
+	env.Execute()
+
+	if exited {
+		t.Error("Expected the errors to be caught, got an exit")
+	}
+	checkVars(t, env, map[string]string{
+		"msg":          "cannot divide by zero",
+		"line":         "3",
+		"steps":        "try catch finally",
+		"thrown":       "boom",
+		"rethrown":     "finally caught",
+		"ret":          "1",
+		"caughtInLoop": "2",
+		"typ":          "error",
+	})
+	if env.ErrorHandle.InTry() {
+		t.Error("Expected to be outside of any try block after execution")
+	}
+	if env.Vars.next != nil {
+		t.Error("Expected the scopes of the try blocks to be closed")
+	}
+}
+
+func TestRunThrowStmtUncaught(t *testing.T) {
+	exited := false
+	env := NewEnv()
+	env.ErrorHandle.HookExit(func(int) {
+		exited = true
+	})
+	defer env.ErrorHandle.RestoreExit()
+	env.SetCode(`
+	try {
+		throw "first";
+	} finally {
+		var a int = 0;
+	}
+	`)
+	env.Execute()
+
+	if !exited {
+		t.Error("Expected an uncaught error to exit")
+	}
+	last := env.ErrorHandle.Errors[len(env.ErrorHandle.Errors)-1]
+	if last.Msg != "first" || last.Line != 3 {
+		t.Errorf("Expected the uncaught error first at line 3, got %s at line %d", last.Msg, last.Line)
+	}
+}
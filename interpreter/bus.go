@@ -6,20 +6,18 @@ import "github.com/Eclalang/Ecla/interpreter/eclaType"
 type BusType int
 
 const (
-	BUS_MAIN   BusType = iota // Main bus
-	BUS_RETURN                // Return bus
-	BUS_NONE                  // None bus
+	BUS_MAIN     BusType = iota // Main bus
+	BUS_RETURN                  // Return bus
+	BUS_NONE                    // None bus
+	BUS_BREAK                   // Break bus
+	BUS_CONTINUE                // Continue bus
 )
 
-// noneBus is the Global none bus.
-var noneBus Bus = Bus{
-	Type: BUS_NONE,
-	Val:  nil,
-}
-
 type Bus struct {
 	Type BusType
 	Val  eclaType.Type
+	// Label is the label of the loop targeted by a break or continue bus, empty for the innermost loop.
+	Label string
 }
 
 // TransformTo transforms the bus to the given type.
@@ -37,6 +35,26 @@ func (b *Bus) IsMain() bool {
 	return b.Type == BUS_MAIN
 }
 
+// IsBreak returns true if the bus is a break bus.
+func (b *Bus) IsBreak() bool {
+	return b.Type == BUS_BREAK
+}
+
+// IsContinue returns true if the bus is a continue bus.
+func (b *Bus) IsContinue() bool {
+	return b.Type == BUS_CONTINUE
+}
+
+// IsControlFlow returns true if the bus must interrupt the execution of the current body (return, break or continue).
+func (b *Bus) IsControlFlow() bool {
+	return b.Type == BUS_RETURN || b.Type == BUS_BREAK || b.Type == BUS_CONTINUE
+}
+
+// Targets returns true if the break or continue bus is aimed at the loop with the given label.
+func (b *Bus) Targets(label string) bool {
+	return b.Label == "" || b.Label == label
+}
+
 // IsNone returns true if the bus is a none bus.
 func (b *Bus) IsNone() bool {
 	return b.Type == BUS_NONE
@@ -63,9 +81,28 @@ func NewReturnBus(Val eclaType.Type) *Bus {
 	}
 }
 
-// NewNoneBus returns the Global none bus.
+// NewBreakBus returns a new break bus targeting the loop with the given label.
+func NewBreakBus(label string) *Bus {
+	return &Bus{
+		Type:  BUS_BREAK,
+		Label: label,
+	}
+}
+
+// NewContinueBus returns a new continue bus targeting the loop with the given label.
+func NewContinueBus(label string) *Bus {
+	return &Bus{
+		Type:  BUS_CONTINUE,
+		Label: label,
+	}
+}
+
+// NewNoneBus returns a new none bus.
 func NewNoneBus() *Bus {
-	return &noneBus
+	return &Bus{
+		Type: BUS_NONE,
+		Val:  nil,
+	}
 }
 
 // IsMultipleBus returns true if the bus is a multiple bus.
@@ -28,6 +28,29 @@ func TestIsMain(t *testing.T) {
 	}
 }
 
+func TestIsBreak(t *testing.T) {
+	t1 := NewBreakBus("")
+	if !t1.IsBreak() || !t1.IsControlFlow() {
+		t.Errorf("Expected %s, got %v", "BUS_BREAK", t1.Type)
+	}
+	if !t1.Targets("outer") {
+		t.Errorf("Expected an unlabeled break to target any loop")
+	}
+}
+
+func TestIsContinue(t *testing.T) {
+	t1 := NewContinueBus("outer")
+	if !t1.IsContinue() || !t1.IsControlFlow() {
+		t.Errorf("Expected %s, got %v", "BUS_CONTINUE", t1.Type)
+	}
+	if t1.Targets("") || t1.Targets("inner") {
+		t.Errorf("Expected a labeled continue to only target its loop")
+	}
+	if !t1.Targets("outer") {
+		t.Errorf("Expected a labeled continue to target its loop")
+	}
+}
+
 func TestIsNone(t *testing.T) {
 	t1 := NewNoneBus()
 	if !t1.IsNone() {
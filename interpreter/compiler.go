@@ -0,0 +1,369 @@
+package interpreter
+
+import (
+	"github.com/Eclalang/Ecla/interpreter/eclaType"
+	"github.com/Eclalang/Ecla/lexer"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// opcode is the operation of a bytecode instruction.
+type opcode byte
+
+const (
+	opConst       opcode = iota // push the constant arg
+	opLoad                      // push the variable named arg, n is the node used to report errors
+	opBinary                    // pop the right and the left operands and push the result of the binary operation arg
+	opNeg                       // pop a value and push its opposite
+	opNot                       // pop a value and push its negation
+	opEval                      // evaluate the node arg with the tree walker, n tells what to do with the values
+	opMark                      // remember the height of the stack, the values pushed after it are the arguments of the next opCall or opAssign
+	opCall                      // call the function of the node arg with the values pushed since the last mark, n tells what to do with the values returned
+	opDeclare                   // pop a value and declare the variable of the node arg with it
+	opAssign                    // assign the values pushed since the last mark to the variables of the node arg
+	opExec                      // execute the statement arg with the tree walker and propagate its return, break or continue
+	opJump                      // jump to the instruction arg
+	opJumpIfFalse               // pop a value and jump to the instruction arg if it is not true
+	opEnterScope                // create a new scope of type arg
+	opExitScope                 // end the current scope
+	opLoop                      // enter the loop arg
+	opEndLoop                   // leave the current loop
+	opBreak                     // break the loop labeled arg, or the innermost loop when arg is empty
+	opContinue                  // continue the loop labeled arg, or the innermost loop when arg is empty
+	opRange                     // pop a value and iterate over it in the current loop, arg is the parser.ForStmt
+	opNext                      // set the key and the value of the next iteration of the current loop, jump to the instruction arg once done, n is the parser.ForStmt
+	opInterpolate               // pop arg values and push the string joining their strings
+)
+
+// values tells how many values an instruction leaves on the stack.
+const (
+	valuesOne  = iota // exactly one value
+	valuesAll         // every value
+	valuesNone        // no value
+)
+
+// instruction is a single bytecode instruction.
+type instruction struct {
+	op  opcode
+	arg int
+	n   int
+}
+
+// loopInfo is the label and the jump targets of a compiled loop.
+type loopInfo struct {
+	label      string
+	breakPC    int
+	continuePC int
+}
+
+// chunk is the bytecode of a body, with the constants, names and nodes its instructions refer to.
+type chunk struct {
+	code   []instruction
+	consts []eclaType.Type
+	names  []string
+	nodes  []parser.Node
+	loops  []loopInfo
+}
+
+// binaryOperations are the operations of the binary operators, indexed by the arg of opBinary.
+var binaryOperations = []func(eclaType.Type, eclaType.Type) (eclaType.Type, error){
+	eclaType.Type.Add,
+	eclaType.Type.Sub,
+	eclaType.Type.Mul,
+	eclaType.Type.Div,
+	eclaType.Type.Mod,
+	eclaType.Type.DivEc,
+	eclaType.Type.Eq,
+	eclaType.Type.Lw,
+	eclaType.Type.LwEq,
+	eclaType.Type.Gt,
+	eclaType.Type.GtEq,
+	eclaType.Type.NotEq,
+	eclaType.Type.And,
+	eclaType.Type.Or,
+	eclaType.Type.Xor,
+}
+
+// binaryOperators maps the token type of the binary operators to their index in binaryOperations.
+var binaryOperators = map[string]int{
+	lexer.ADD:   0,
+	lexer.SUB:   1,
+	lexer.MULT:  2,
+	lexer.DIV:   3,
+	lexer.MOD:   4,
+	lexer.QOT:   5,
+	lexer.EQUAL: 6,
+	lexer.LSS:   7,
+	lexer.LEQ:   8,
+	lexer.GTR:   9,
+	lexer.GEQ:   10,
+	lexer.NEQ:   11,
+	lexer.AND:   12,
+	lexer.OR:    13,
+	lexer.XOR:   14,
+}
+
+// compiler compiles a body to a chunk.
+// Expressions and control flow are compiled to instructions, the other statements are executed by the tree walker.
+type compiler struct {
+	chunk *chunk
+}
+
+// compile returns the bytecode of the body.
+func compile(body []parser.Node) *chunk {
+	c := &compiler{chunk: &chunk{}}
+	c.body(body)
+	return c.chunk
+}
+
+// emit appends an instruction and returns its index.
+func (c *compiler) emit(op opcode, arg int, n int) int {
+	c.chunk.code = append(c.chunk.code, instruction{op: op, arg: arg, n: n})
+	return len(c.chunk.code) - 1
+}
+
+// patch sets the jump target of the instruction at index to the next instruction.
+func (c *compiler) patch(index int) {
+	c.chunk.code[index].arg = len(c.chunk.code)
+}
+
+func (c *compiler) node(node parser.Node) int {
+	c.chunk.nodes = append(c.chunk.nodes, node)
+	return len(c.chunk.nodes) - 1
+}
+
+func (c *compiler) name(name string) int {
+	c.chunk.names = append(c.chunk.names, name)
+	return len(c.chunk.names) - 1
+}
+
+func (c *compiler) constant(value eclaType.Type) int {
+	c.chunk.consts = append(c.chunk.consts, value)
+	return len(c.chunk.consts) - 1
+}
+
+func (c *compiler) body(body []parser.Node) {
+	for _, stmt := range body {
+		c.stmt(stmt)
+	}
+}
+
+// stmt compiles a statement.
+func (c *compiler) stmt(node parser.Node) {
+	switch node.(type) {
+	case parser.VariableDecl:
+		tree := node.(parser.VariableDecl)
+		if tree.Value == nil {
+			c.emit(opExec, c.node(node), 0)
+			return
+		}
+		c.expr(tree.Value, valuesOne)
+		c.emit(opDeclare, c.node(node), 0)
+	case parser.VariableAssignStmt:
+		tree := node.(parser.VariableAssignStmt)
+		c.emit(opMark, 0, 0)
+		if tree.Values[0] != nil {
+			for _, v := range tree.Values {
+				c.expr(v, valuesAll)
+			}
+		}
+		c.emit(opAssign, c.node(node), 0)
+	case parser.FunctionCallExpr:
+		c.call(node.(parser.FunctionCallExpr), valuesNone)
+	case parser.IfStmt:
+		c.ifStmt(node.(parser.IfStmt))
+	case parser.WhileStmt:
+		c.whileStmt(node.(parser.WhileStmt))
+	case parser.ForStmt:
+		c.forStmt(node.(parser.ForStmt))
+	case parser.BlockScopeStmt:
+		c.emit(opEnterScope, int(SCOPE_MAIN), 0)
+		c.body(node.(parser.BlockScopeStmt).Body)
+		c.emit(opExitScope, 0, 0)
+	case parser.BreakStmt:
+		c.emit(opBreak, c.name(node.(parser.BreakStmt).Label), 0)
+	case parser.ContinueStmt:
+		c.emit(opContinue, c.name(node.(parser.ContinueStmt).Label), 0)
+	default:
+		c.emit(opExec, c.node(node), 0)
+	}
+}
+
+// expr compiles an expression, values tells how many of its values are pushed on the stack.
+func (c *compiler) expr(node parser.Node, values int) {
+	switch node.(type) {
+	case parser.Literal:
+		tree := node.(parser.Literal)
+		if tree.Type == "VAR" {
+			c.emit(opLoad, c.name(tree.Value), c.node(node))
+			return
+		}
+		if value, ok := literal(tree); ok {
+			c.emit(opConst, c.constant(value), 0)
+			return
+		}
+	case parser.BinaryExpr:
+		tree := node.(parser.BinaryExpr)
+		if operator, ok := binaryOperators[tree.Operator.TokenType]; ok {
+			c.expr(tree.LeftExpr, valuesOne)
+			c.expr(tree.RightExpr, valuesOne)
+			c.emit(opBinary, operator, c.node(node))
+			return
+		}
+	case parser.UnaryExpr:
+		tree := node.(parser.UnaryExpr)
+		switch tree.Operator.TokenType {
+		case lexer.SUB:
+			c.expr(tree.RightExpr, valuesOne)
+			c.emit(opNeg, c.node(tree.RightExpr), 0)
+			return
+		case lexer.NOT:
+			c.expr(tree.RightExpr, valuesOne)
+			c.emit(opNot, c.node(tree.RightExpr), 0)
+			return
+		case lexer.ADD:
+			c.expr(tree.RightExpr, valuesOne)
+			return
+		}
+	case parser.ParenExpr:
+		c.expr(node.(parser.ParenExpr).Expression, values)
+		return
+	case parser.FunctionCallExpr:
+		c.call(node.(parser.FunctionCallExpr), values)
+		return
+	case parser.InterpolatedStringExpr:
+		tree := node.(parser.InterpolatedStringExpr)
+		for _, part := range tree.Parts {
+			c.expr(part, valuesOne)
+		}
+		c.emit(opInterpolate, len(tree.Parts), 0)
+		return
+	}
+	c.emit(opEval, c.node(node), values)
+}
+
+// call compiles a function call, values tells how many of the values returned are pushed on the stack.
+func (c *compiler) call(tree parser.FunctionCallExpr, values int) {
+	c.emit(opMark, 0, 0)
+	for _, arg := range tree.Args {
+		c.expr(arg, valuesAll)
+	}
+	c.emit(opCall, c.node(tree), values)
+}
+
+// literal returns the value of a constant literal, ok is false if it must be evaluated at runtime.
+func literal(tree parser.Literal) (eclaType.Type, bool) {
+	switch tree.Type {
+	case lexer.INT:
+		i, err := eclaType.NewInt(tree.Value)
+		return i, err == nil
+	case lexer.FLOAT:
+		f, err := eclaType.NewFloat(tree.Value)
+		return f, err == nil
+	case lexer.STRING:
+		str, err := eclaType.NewString(tree.Value)
+		return str, err == nil
+	case lexer.RAWSTRING:
+		return eclaType.String(tree.Value), true
+	case lexer.BOOL:
+		b, err := eclaType.NewBool(tree.Value)
+		return b, err == nil
+	case lexer.CHAR:
+		ch, err := eclaType.NewChar(tree.Value)
+		return ch, err == nil
+	case "NULL":
+		return eclaType.NewNull(), true
+	}
+	return nil, false
+}
+
+// ifStmt compiles an if statement and its else branches.
+func (c *compiler) ifStmt(tree parser.IfStmt) {
+	c.expr(tree.Cond, valuesOne)
+	jumpElse := c.emit(opJumpIfFalse, 0, 0)
+	c.emit(opEnterScope, int(SCOPE_CONDITION), 0)
+	c.body(tree.Body)
+	c.emit(opExitScope, 0, 0)
+	if tree.ElseStmt == nil {
+		c.patch(jumpElse)
+		return
+	}
+	jumpEnd := c.emit(opJump, 0, 0)
+	c.patch(jumpElse)
+	if tree.ElseStmt.IfStmt != nil {
+		c.ifStmt(*tree.ElseStmt.IfStmt)
+	} else {
+		c.emit(opEnterScope, int(SCOPE_CONDITION), 0)
+		c.body(tree.ElseStmt.Body)
+		c.emit(opExitScope, 0, 0)
+	}
+	c.patch(jumpEnd)
+}
+
+// loop adds a loop to the chunk and returns its index.
+func (c *compiler) loop(label string) int {
+	c.chunk.loops = append(c.chunk.loops, loopInfo{label: label})
+	return len(c.chunk.loops) - 1
+}
+
+// endLoop compiles the end of the loop, the instructions break jumps to.
+func (c *compiler) endLoop(loop int) {
+	c.chunk.loops[loop].breakPC = len(c.chunk.code)
+	c.emit(opEndLoop, 0, 0)
+	c.emit(opExitScope, 0, 0)
+}
+
+// loopBody compiles one iteration of a loop body in its own scope.
+func (c *compiler) loopBody(body []parser.Node) {
+	c.emit(opEnterScope, int(SCOPE_LOOP), 0)
+	c.body(body)
+	c.emit(opExitScope, 0, 0)
+}
+
+// whileStmt compiles a while loop.
+func (c *compiler) whileStmt(tree parser.WhileStmt) {
+	c.emit(opEnterScope, int(SCOPE_LOOP), 0)
+	loop := c.loop(tree.Label)
+	c.emit(opLoop, loop, 0)
+	cond := len(c.chunk.code)
+	c.chunk.loops[loop].continuePC = cond
+	c.expr(tree.Cond, valuesOne)
+	jumpEnd := c.emit(opJumpIfFalse, 0, 0)
+	c.loopBody(tree.Body)
+	c.emit(opJump, cond, 0)
+	c.patch(jumpEnd)
+	c.endLoop(loop)
+}
+
+// forStmt compiles a for loop, iterating over a range or with a condition.
+func (c *compiler) forStmt(tree parser.ForStmt) {
+	c.emit(opEnterScope, int(SCOPE_LOOP), 0)
+	loop := c.loop(tree.Label)
+	if tree.RangeToken != (lexer.Token{}) {
+		c.expr(tree.RangeExpr, valuesOne)
+		c.emit(opLoop, loop, 0)
+		node := c.node(tree)
+		c.emit(opRange, node, 0)
+		next := c.emit(opNext, 0, node)
+		c.chunk.loops[loop].continuePC = next
+		c.loopBody(tree.Body)
+		c.emit(opJump, next, 0)
+		c.patch(next)
+		c.endLoop(loop)
+		return
+	}
+	if tree.InitDecl != nil {
+		c.stmt(tree.InitDecl)
+	}
+	c.emit(opLoop, loop, 0)
+	cond := len(c.chunk.code)
+	c.expr(tree.CondExpr, valuesOne)
+	jumpEnd := c.emit(opJumpIfFalse, 0, 0)
+	c.loopBody(tree.Body)
+	c.chunk.loops[loop].continuePC = len(c.chunk.code)
+	if tree.PostAssignStmt != nil {
+		c.stmt(tree.PostAssignStmt)
+	}
+	c.emit(opJump, cond, 0)
+	c.patch(jumpEnd)
+	c.endLoop(loop)
+}
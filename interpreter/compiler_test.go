@@ -0,0 +1,85 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/Eclalang/Ecla/errorHandler"
+	"github.com/Eclalang/Ecla/lexer"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// compileCode parses the code and compiles it.
+func compileCode(code string) *chunk {
+	pars := parser.Parser{Tokens: lexer.Lexer(code), ErrorHandler: errorHandler.NewHandler()}
+	return compile(pars.Parse().ParseTree.Operations)
+}
+
+func TestCompile(t *testing.T) {
+	c := compileCode(`var a int = 1 + 2;
+while (a < 10) {
+	a++;
+	if (a == 5) {
+		break;
+	}
+}
+function f() {}
+`)
+	expected := []opcode{
+		opConst, opConst, opBinary, opDeclare,
+		opEnterScope, opLoop, opLoad, opConst, opBinary, opJumpIfFalse,
+		opEnterScope, opMark, opAssign,
+		opLoad, opConst, opBinary, opJumpIfFalse, opEnterScope, opBreak, opExitScope,
+		opExitScope, opJump, opEndLoop, opExitScope,
+		opExec,
+	}
+	if len(c.code) != len(expected) {
+		t.Fatalf("Expected %d instructions, got %d: %v", len(expected), len(c.code), c.code)
+	}
+	for i, op := range expected {
+		if c.code[i].op != op {
+			t.Errorf("Expected opcode %d at %d, got %d", op, i, c.code[i].op)
+		}
+	}
+	if len(c.loops) != 1 || c.loops[0].breakPC != 22 || c.loops[0].continuePC != 6 {
+		t.Errorf("Expected the loop to break at 22 and continue at 6, got %+v", c.loops)
+	}
+	if c.code[9].arg != 22 || c.code[21].arg != 6 {
+		t.Errorf("Expected the jumps of the loop to be patched, got %v", c.code)
+	}
+}
+
+func TestCompileInterpolation(t *testing.T) {
+	c := compileCode(`var a int = 1;
+var s string = "a + 1 = ${a + 1}!";
+`)
+	expected := []opcode{opConst, opDeclare, opConst, opLoad, opConst, opBinary, opConst, opInterpolate, opDeclare}
+	if len(c.code) != len(expected) {
+		t.Fatalf("Expected %d instructions, got %d: %v", len(expected), len(c.code), c.code)
+	}
+	for i, op := range expected {
+		if c.code[i].op != op {
+			t.Errorf("Expected opcode %d at %d, got %d", op, i, c.code[i].op)
+		}
+	}
+	if c.code[7].arg != 3 {
+		t.Errorf("Expected the 3 parts of the string to be joined, got %d", c.code[7].arg)
+	}
+}
+
+func TestCompileFallback(t *testing.T) {
+	c := compileCode(`var l []int = [1, 2];
+var s int = l[0] + sizeOf(l);
+`)
+	expected := []opcode{opEval, opDeclare, opEval, opMark, opLoad, opCall, opBinary, opDeclare}
+	if len(c.code) != len(expected) {
+		t.Fatalf("Expected %d instructions, got %d: %v", len(expected), len(c.code), c.code)
+	}
+	for i, op := range expected {
+		if c.code[i].op != op {
+			t.Errorf("Expected opcode %d at %d, got %d", op, i, c.code[i].op)
+		}
+	}
+	if c.code[5].n != valuesOne {
+		t.Errorf("Expected the call to push one value, got %d", c.code[5].n)
+	}
+}
@@ -0,0 +1,89 @@
+package eclaDecl
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// InterfaceDecl is the interface declaration.
+type InterfaceDecl struct {
+	Fields  map[string]string
+	Order   []string
+	Name    string
+	Methods map[string]string
+}
+
+func NewInterfaceDecl(tree parser.InterfaceDecl) *InterfaceDecl {
+	var itfdecl = InterfaceDecl{
+		Fields:  make(map[string]string),
+		Order:   make([]string, 0),
+		Name:    tree.Name,
+		Methods: make(map[string]string),
+	}
+
+	for _, field := range tree.Fields {
+		itfdecl.Fields[field.Name] = field.Type
+		itfdecl.Order = append(itfdecl.Order, field.Name)
+	}
+	for _, method := range tree.Methods {
+		itfdecl.Methods[method.Name] = MethodType(method.Prototype)
+	}
+	return &itfdecl
+}
+
+// MethodType returns the type of the function described by the prototype, in the form of "function(int,string)(bool)".
+func MethodType(prototype parser.FunctionPrototype) string {
+	var params []string
+	for _, param := range prototype.Parameters {
+		params = append(params, param.Type)
+	}
+	typ := "function(" + strings.Join(params, ",") + ")"
+	if len(prototype.ReturnTypes) > 0 {
+		typ += "(" + strings.Join(prototype.ReturnTypes, ",") + ")"
+	}
+	return typ
+}
+
+func (i *InterfaceDecl) GetFieldsInOrder() []Field {
+	var fields []Field
+	for _, field := range i.Order {
+		fields = append(fields, Field{Name: field, Type: i.Fields[field]})
+	}
+	return fields
+}
+
+func (i *InterfaceDecl) GetName() string {
+	return i.Name
+}
+
+// IsImplementedBy returns an error describing the first field or method of the interface that the struct does not have.
+func (i *InterfaceDecl) IsImplementedBy(s *StructDecl) error {
+	for _, field := range i.Order {
+		typ, ok := s.Fields[field]
+		if !ok {
+			return errors.New("struct " + s.Name + " does not implement interface " + i.Name + ": missing field " + field)
+		}
+		if typ != i.Fields[field] {
+			return errors.New("struct " + s.Name + " does not implement interface " + i.Name + ": field " + field + " is of type " + typ + ", expected " + i.Fields[field])
+		}
+	}
+	names := make([]string, 0, len(i.Methods))
+	for name := range i.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		typ := i.Methods[name]
+		method, ok := s.GetMethod(name)
+		if !ok {
+			return errors.New("struct " + s.Name + " does not implement interface " + i.Name + ": missing method " + name)
+		}
+		if method.GetType() != typ {
+			return errors.New("struct " + s.Name + " does not implement interface " + i.Name + ": method " + name + " is of type " + method.GetType() + ", expected " + typ)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package eclaDecl
+
+import (
+	"github.com/Eclalang/Ecla/parser"
+	"testing"
+)
+
+var shapeTree = parser.InterfaceDecl{
+	Name: "Shape",
+	Fields: []parser.StructField{
+		{Name: "name", Type: "string"},
+	},
+	Methods: []parser.InterfaceMethod{
+		{Name: "area", Prototype: parser.FunctionPrototype{ReturnTypes: []string{"int"}}},
+		{Name: "scale", Prototype: parser.FunctionPrototype{Parameters: []parser.FunctionParams{{Name: "k", Type: "int"}, {Name: "n", Type: "float"}}}},
+	},
+}
+
+func TestNewInterfaceDecl(t *testing.T) {
+	itfdecl := NewInterfaceDecl(shapeTree)
+	if itfdecl.GetName() != "Shape" {
+		t.Error("Expected Shape, got ", itfdecl.GetName())
+	}
+	fields := itfdecl.GetFieldsInOrder()
+	if len(fields) != 1 || fields[0].Name != "name" || fields[0].Type != "string" {
+		t.Error("Expected the field name of type string, got ", fields)
+	}
+	if itfdecl.Methods["area"] != "function()(int)" {
+		t.Error("Expected function()(int), got ", itfdecl.Methods["area"])
+	}
+	if itfdecl.Methods["scale"] != "function(int,float)" {
+		t.Error("Expected function(int,float), got ", itfdecl.Methods["scale"])
+	}
+}
+
+func TestInterfaceDecl_IsImplementedBy(t *testing.T) {
+	itfdecl := NewInterfaceDecl(shapeTree)
+	newStruct := func(fields map[string]string, methods map[string]Method) *StructDecl {
+		return &StructDecl{Name: "Rect", Fields: fields, Methods: methods}
+	}
+	methods := map[string]Method{
+		"area":  testMethod("function()(int)"),
+		"scale": testMethod("function(int,float)"),
+	}
+
+	if err := itfdecl.IsImplementedBy(newStruct(map[string]string{"name": "string", "w": "int"}, methods)); err != nil {
+		t.Error("Expected nil, got ", err)
+	}
+	if err := itfdecl.IsImplementedBy(newStruct(map[string]string{"w": "int"}, methods)); err == nil {
+		t.Error("Expected an error for a missing field, got nil")
+	}
+	if err := itfdecl.IsImplementedBy(newStruct(map[string]string{"name": "int"}, methods)); err == nil {
+		t.Error("Expected an error for a field of the wrong type, got nil")
+	}
+	if err := itfdecl.IsImplementedBy(newStruct(map[string]string{"name": "string"}, map[string]Method{"area": testMethod("function()(int)")})); err == nil {
+		t.Error("Expected an error for a missing method, got nil")
+	} else if err.Error() != "struct Rect does not implement interface Shape: missing method scale" {
+		t.Error("Expected the missing method error, got ", err)
+	}
+	if err := itfdecl.IsImplementedBy(newStruct(map[string]string{"name": "string"}, map[string]Method{"area": testMethod("function()(string)"), "scale": testMethod("function(int,float)")})); err == nil {
+		t.Error("Expected an error for a method of the wrong type, got nil")
+	}
+}
@@ -1,19 +1,30 @@
 package eclaDecl
 
-import "github.com/Eclalang/Ecla/parser"
+import (
+	"errors"
+
+	"github.com/Eclalang/Ecla/parser"
+)
 
 // StructDecl is the struct declaration.
 type StructDecl struct {
-	Fields map[string]string
-	Order  []string
-	Name   string
+	Fields  map[string]string
+	Order   []string
+	Name    string
+	Methods map[string]Method
+}
+
+// Method is a function bound to a struct.
+type Method interface {
+	GetType() string
 }
 
 func NewStructDecl(tree parser.StructDecl) *StructDecl {
 	var strdecl = StructDecl{
-		Fields: make(map[string]string),
-		Order:  make([]string, 0),
-		Name:   tree.Name,
+		Fields:  make(map[string]string),
+		Order:   make([]string, 0),
+		Name:    tree.Name,
+		Methods: make(map[string]Method),
 	}
 
 	for _, field := range tree.Fields {
@@ -34,3 +45,24 @@ func (s *StructDecl) GetFieldsInOrder() []Field {
 func (s *StructDecl) GetName() string {
 	return s.Name
 }
+
+// AddMethod binds the method to the struct.
+func (s *StructDecl) AddMethod(name string, method Method) error {
+	if _, ok := s.Fields[name]; ok {
+		return errors.New("struct " + s.Name + " already has a field named " + name)
+	}
+	if _, ok := s.Methods[name]; ok {
+		return errors.New("method " + name + " is already declared on struct " + s.Name)
+	}
+	if s.Methods == nil {
+		s.Methods = make(map[string]Method)
+	}
+	s.Methods[name] = method
+	return nil
+}
+
+// GetMethod returns the method with the given name.
+func (s *StructDecl) GetMethod(name string) (Method, bool) {
+	method, ok := s.Methods[name]
+	return method, ok
+}
@@ -70,3 +70,39 @@ func TestStructDecl_GetName(t *testing.T) {
 		t.Error("Expected test, got ", strdecl.GetName())
 	}
 }
+
+type testMethod string
+
+func (m testMethod) GetType() string {
+	return string(m)
+}
+
+func TestStructDecl_AddMethod(t *testing.T) {
+	strdecl := NewStructDecl(parser.StructDecl{
+		Name:   "test",
+		Fields: []parser.StructField{{Name: "field1", Type: "int"}},
+	})
+	if err := strdecl.AddMethod("method", testMethod("function()")); err != nil {
+		t.Error("Expected nil, got ", err)
+	}
+	if err := strdecl.AddMethod("method", testMethod("function()")); err == nil {
+		t.Error("Expected an error when declaring the same method twice, got nil")
+	}
+	if err := strdecl.AddMethod("field1", testMethod("function()")); err == nil {
+		t.Error("Expected an error when a method has the name of a field, got nil")
+	}
+}
+
+func TestStructDecl_GetMethod(t *testing.T) {
+	strdecl := StructDecl{Name: "test"}
+	if _, ok := strdecl.GetMethod("method"); ok {
+		t.Error("Expected no method, got one")
+	}
+	_ = strdecl.AddMethod("method", testMethod("function()"))
+	method, ok := strdecl.GetMethod("method")
+	if !ok {
+		t.Error("Expected a method, got none")
+	} else if method.GetType() != "function()" {
+		t.Error("Expected function(), got ", method.GetType())
+	}
+}
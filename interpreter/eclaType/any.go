@@ -119,7 +119,7 @@ func (a *Any) Not() (Type, error) {
 
 func (a *Any) Decrement() {
 	var err error
-	a.Value, err = a.Value.Sub(NewInt("1"))
+	a.Value, err = a.Value.Sub(Int(1))
 	if err != nil {
 		panic(err)
 	}
@@ -127,7 +127,7 @@ func (a *Any) Decrement() {
 
 func (a *Any) Increment() {
 	var err error
-	a.Value, err = a.Value.Add(NewInt("1"))
+	a.Value, err = a.Value.Add(Int(1))
 	if err != nil {
 		panic(err)
 	}
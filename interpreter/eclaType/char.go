@@ -4,24 +4,33 @@ import (
 	"errors"
 	"fmt"
 	"github.com/Eclalang/Ecla/interpreter/utils"
+	"github.com/Eclalang/Ecla/lexer"
 	"strconv"
+	"unicode/utf8"
 )
 
-// NewChar creates a new Char
+// NewChar creates a new Char from the value of a char literal, whose escape sequences are decoded
 func NewChar(value string) (Char, error) {
 	if len(value) == 0 {
 		return Char(0), nil
 	}
-	value = `'` + value + `'`
-	value, err := strconv.Unquote(value)
+	value, err := lexer.Unquote(value, '\'')
 	if err != nil {
 		return 0, err
 	}
-	result := []rune(value)
-	if len(result) > 1 {
+	r, size := utf8.DecodeRuneInString(value)
+	if size != len(value) || (r == utf8.RuneError && size < 2) {
 		return 0, errors.New(fmt.Sprint(value, " is not a char"))
 	}
-	return Char(result[0]), nil
+	return Char(r), nil
+}
+
+// NewCharFromInt creates the Char of the unicode code point i
+func NewCharFromInt(i int) (Char, error) {
+	if i < 0 || i > utf8.MaxRune || !utf8.ValidRune(rune(i)) {
+		return 0, errors.New(strconv.Itoa(i) + " is not a unicode code point")
+	}
+	return Char(i), nil
 }
 
 type Char rune
@@ -412,5 +421,5 @@ func (c Char) Len() (int, error) {
 }
 
 func (c Char) GetValueAsInt() Int {
-	return NewInt(strconv.Itoa(int(c)))
+	return Int(c)
 }
@@ -1141,6 +1141,52 @@ func TestNewCharWithString(t *testing.T) {
 	}
 }
 
+func TestNewCharUnicode(t *testing.T) {
+	tests := map[string]Char{
+		"é":          'é',
+		"😀":          '😀',
+		`\u{1F600}`:  '😀',
+		`\'`:         '\'',
+		`\\`:         '\\',
+		`\n`:         '\n',
+		`\0`:         0,
+		`\x41`:       'A',
+		`\u{10FFFF}`: '\U0010FFFF',
+	}
+	for value, expected := range tests {
+		result, err := NewChar(value)
+		if err != nil {
+			t.Error(err)
+		}
+		if result != expected {
+			t.Errorf("expected %q for %s, got %q", expected, value, result)
+		}
+	}
+}
+
+func TestNewCharInvalidErr(t *testing.T) {
+	for _, value := range []string{`\q`, `\u{110000}`, "\xff", "é😀", `'`} {
+		if _, err := NewChar(value); err == nil {
+			t.Errorf("Expected error when creating a char with %q", value)
+		}
+	}
+}
+
+func TestNewCharFromInt(t *testing.T) {
+	result, err := NewCharFromInt(0x1F600)
+	if err != nil {
+		t.Error(err)
+	}
+	if result != '😀' {
+		t.Errorf("expected 😀, got %q", result)
+	}
+	for _, i := range []int{-1, 0xD800, 0x110000, 1 << 40} {
+		if _, err := NewCharFromInt(i); err == nil {
+			t.Errorf("Expected error when creating the char of %d", i)
+		}
+	}
+}
+
 func TestCharAppendErr(t *testing.T) {
 	t1 := Char(0)
 	t2 := Char(1)
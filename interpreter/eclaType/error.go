@@ -0,0 +1,32 @@
+package eclaType
+
+import (
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// ErrorDecl is the declaration of the built-in error struct, the type of the values caught by a catch clause.
+var ErrorDecl = &eclaDecl.StructDecl{
+	Fields: map[string]string{
+		"message": parser.String,
+		"line":    parser.Int,
+		"column":  parser.Int,
+	},
+	Order: []string{"message", "line", "column"},
+	Name:  parser.Error,
+}
+
+// NewError returns a new error with the given message and position.
+func NewError(message string, line int, column int) *Struct {
+	e := NewStruct(ErrorDecl)
+	e.AddField(0, String(message))
+	e.AddField(1, Int(line))
+	e.AddField(2, Int(column))
+	return e
+}
+
+// IsError returns true if the value is an error with all of its fields set.
+func IsError(value Type) bool {
+	s, ok := value.(*Struct)
+	return ok && s.GetType() == parser.Error && s.Verify() == nil
+}
@@ -0,0 +1,37 @@
+package eclaType
+
+import (
+	"github.com/Eclalang/Ecla/parser"
+	"testing"
+)
+
+func TestNewError(t *testing.T) {
+	e := NewError("division by zero", 3, 7)
+	if e.GetType() != parser.Error {
+		t.Error("Expected error, got ", e.GetType())
+	}
+	if msg, err := e.Get("message"); err != nil || msg != String("division by zero") {
+		t.Error("Expected message to be division by zero, got ", msg)
+	}
+	if line, err := e.Get("line"); err != nil || line != Int(3) {
+		t.Error("Expected line to be 3, got ", line)
+	}
+	if col, err := e.Get("column"); err != nil || col != Int(7) {
+		t.Error("Expected column to be 7, got ", col)
+	}
+	if e.String() != "error{division by zero, 3, 7}" {
+		t.Error("Expected error{division by zero, 3, 7}, got ", e.String())
+	}
+}
+
+func TestIsError(t *testing.T) {
+	if !IsError(NewError("test", 0, 0)) {
+		t.Error("Expected true, got false")
+	}
+	if IsError(NewStruct(ErrorDecl)) {
+		t.Error("Expected false for an error without fields, got true")
+	}
+	if IsError(String("test")) {
+		t.Error("Expected false, got true")
+	}
+}
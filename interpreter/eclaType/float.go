@@ -4,16 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"github.com/Eclalang/Ecla/interpreter/utils"
-	"strconv"
+	"github.com/Eclalang/Ecla/lexer"
 )
 
-// NewFloat creates a new Float
-func NewFloat(value string) Float {
-	result, error := strconv.ParseFloat(value, 32)
-	if error != nil {
-		panic(error)
-	}
-	return Float(result)
+// NewFloat creates a new Float from the value of a float literal, which can have an exponent like 1.5e-3,
+// a malformed literal or an overflow is an error
+func NewFloat(value string) (Float, error) {
+	result, err := lexer.ParseFloat(value)
+	return Float(result), err
 }
 
 type Float float32
@@ -9,11 +9,17 @@ import (
 // Float interacts with Float
 
 func TestNewFloat(t *testing.T) {
-	t1 := NewFloat("0.3")
+	t1, err := NewFloat("0.3")
 
-	if t1 != 0.3 {
+	if err != nil || t1 != 0.3 {
 		t.Error("Error when creating a Float")
 	}
+
+	t2, err := NewFloat("1_000.5e-3")
+
+	if err != nil || t2 != Float(1.0005) {
+		t.Error("Expected 1.0005, got ", t2, err)
+	}
 }
 
 func TestFloatGetValue(t *testing.T) {
@@ -996,13 +1002,15 @@ func TestDivEcFloatErr(t *testing.T) {
 }
 
 func TestNewFloatErr(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected a panic when creating a new float with wrong argument, got nil")
-		}
-	}()
+	_, err := NewFloat("not a float")
+	if err == nil {
+		t.Error("Expected error when creating a new float with wrong argument, got nil")
+	}
 
-	NewFloat("not a float")
+	_, err = NewFloat("1e39")
+	if err == nil {
+		t.Error("Expected error when creating a float which overflows, got nil")
+	}
 }
 
 func TestFloatSetValueErr(t *testing.T) {
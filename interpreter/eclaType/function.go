@@ -14,6 +14,11 @@ type Function struct {
 	Body            map[string][]parser.Node
 	Return          map[string][]string
 	lastIndexOfArgs int
+	// Receiver is the name to which the struct is bound when the function is a method.
+	Receiver string
+	// closure is the context captured by an anonymous function or a method when it is created.
+	// It is stored as any because the context is defined by the interpreter.
+	closure any
 }
 
 // Function Method for interface Type
@@ -178,8 +183,30 @@ func NewAnonymousFunction(args []parser.FunctionParams, body []parser.Node, ret
 	}
 }
 
+// NewMethod returns a new function bound to a struct through the given receiver name.
+func NewMethod(Name string, Receiver string, args []parser.FunctionParams, body []parser.Node, ret []string) *Function {
+	f := NewFunction(Name, args, body, ret)
+	f.Receiver = Receiver
+	return f
+}
+
+// IsMethod returns true if the function is bound to a struct.
+func (f *Function) IsMethod() bool {
+	return f.Receiver != ""
+}
+
 // Method for function
 
+// SetClosure sets the context captured by the function.
+func (f *Function) SetClosure(scope any) {
+	f.closure = scope
+}
+
+// GetClosure returns the context captured by the function, nil if the function does not capture any context.
+func (f *Function) GetClosure() any {
+	return f.closure
+}
+
 func (f *Function) AddOverload(args []parser.FunctionParams, body []parser.Node, ret []string) {
 	f.Args = append(f.Args, args)
 	key := generateArgsString(args)
@@ -224,7 +251,19 @@ func (f *Function) GetReturn() []string {
 	return f.Return[key]
 }
 
-func (f *Function) GetIndexOfArgs(args []Type) int {
+// GetParams returns the parameters of the prototype matched by the last call.
+func (f *Function) GetParams() []parser.FunctionParams {
+	return f.Args[f.lastIndexOfArgs]
+}
+
+// GetIndexOfArgs returns the index of the prototype matching the arguments, -1 if there is none.
+// A struct matches the interfaces of decls it implements.
+func (f *Function) GetIndexOfArgs(args []Type, decls ...eclaDecl.TypeDecl) int {
+	return f.getIndexOfArgs(args, decls)
+}
+
+// getIndexOfArgs returns the index of the prototype matching the arguments, a struct matches the interfaces it implements.
+func (f *Function) getIndexOfArgs(args []Type, decls []eclaDecl.TypeDecl) int {
 	l := len(args)
 	cursor := -1
 	maxNbAny := -1
@@ -237,7 +276,7 @@ func (f *Function) GetIndexOfArgs(args []Type) int {
 		for j, typ := range args {
 			if arg[j].Type == parser.Any {
 				nbAny++
-			} else if typ.GetType() != arg[j].Type {
+			} else if typ.GetType() != arg[j].Type && !implementsInterface(typ, arg[j].Type, decls) {
 				isGoodArgs = false
 				break
 			}
@@ -253,7 +292,7 @@ func (f *Function) GetIndexOfArgs(args []Type) int {
 }
 
 func (f *Function) TypeAndNumberOfArgsIsCorrect(args []Type, StructDecl []eclaDecl.TypeDecl) (bool, map[string]*Var) {
-	indexOfArgs := f.GetIndexOfArgs(args)
+	indexOfArgs := f.getIndexOfArgs(args, StructDecl)
 	f.lastIndexOfArgs = indexOfArgs
 	if indexOfArgs == -1 {
 		return false, nil
@@ -272,17 +311,16 @@ func (f *Function) TypeAndNumberOfArgsIsCorrect(args []Type, StructDecl []eclaDe
 		if paramType == parser.Any {
 			tp = parser.Any
 		}
-		if tp != paramType { //TODO investigate
-			isImplemented := false
-			for _, decl := range StructDecl {
-				if decl.GetName() == paramType {
-					isImplemented = true
-					break
-				}
-			}
-			if !isImplemented {
+		if def, ok := getInterfaceDecl(paramType, StructDecl); ok {
+			// the parameter gets its own value typed by the interface
+			itf, err := NewInterface(def, elem)
+			if err != nil {
 				return false, nil
 			}
+			elem = itf
+			tp = paramType
+		} else if tp != paramType {
+			return false, nil
 		}
 		v, err := NewVar(paramName, tp, elem)
 		if err != nil { //TODO we can never reach this
@@ -294,34 +332,49 @@ func (f *Function) TypeAndNumberOfArgsIsCorrect(args []Type, StructDecl []eclaDe
 	return true, argsType
 }
 
+// CheckInterfaces returns the reason why an argument does not implement the interface of its parameter, nil if there is none.
+func (f *Function) CheckInterfaces(args []Type, StructDecl []eclaDecl.TypeDecl) error {
+	for _, params := range f.Args {
+		if len(params) != len(args) {
+			continue
+		}
+		for i, param := range params {
+			if def, ok := getInterfaceDecl(param.Type, StructDecl); ok {
+				if _, err := NewInterface(def, args[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (f *Function) CheckReturn(ret []Type, StructDecl []eclaDecl.TypeDecl) bool {
 	key := generateArgsString(f.Args[f.lastIndexOfArgs])
-	if len(f.Return[key]) != len(ret) {
+	return CheckReturnTypes(f.Return[key], ret, StructDecl)
+}
+
+// CheckReturnTypes returns true if the values returned match the return types,
+// a value can be returned as an interface it implements and as null for a declared type.
+func CheckReturnTypes(types []string, ret []Type, StructDecl []eclaDecl.TypeDecl) bool {
+	if len(types) != len(ret) {
 		return false
 	}
-	var i int = 0
-	for _, r := range f.Return[key] {
+	for i, r := range types {
 		elem := ret[i]
 		switch elem.(type) {
 		case *Var:
 			elem = elem.(*Var).Value
 		}
-		i++
 		if r == parser.Any {
 			continue
 		}
-		tp := elem.GetType()
-		if tp != r {
-			isImplemented := false
-			for _, decl := range StructDecl {
-				if decl.GetName() == r {
-					isImplemented = true
-					break
-				}
-			}
-			if !isImplemented {
-				return false
-			}
+		if elem.GetType() == r || implementsInterface(elem, r, StructDecl) {
+			continue
+		}
+		// a declared type can be returned as null
+		if !elem.IsNull() || !isTypeDecl(r, StructDecl) {
+			return false
 		}
 	}
 	return true
@@ -2,17 +2,125 @@ package eclaType
 
 import (
 	"errors"
+	"fmt"
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
 	"github.com/Eclalang/Ecla/interpreter/utils"
-	"strconv"
+	"github.com/Eclalang/Ecla/parser"
+	"strings"
 )
 
+// FunctionBuiltIn is a function implemented in Go.
+// The built-in functions without prototype check their arguments themselves,
+// the others are called with the overload whose prototype matches the arguments.
 type FunctionBuiltIn struct {
-	Name string
-	f    func([]Type) ([]Type, error)
+	Name      string
+	f         func([]Type) ([]Type, error)
+	overloads []builtInOverload
+}
+
+// builtInOverload is a prototype of a built-in function and its implementation.
+type builtInOverload struct {
+	prototype parser.FunctionPrototype
+	f         func([]Type) ([]Type, error)
+}
+
+// NewFunctionBuiltIn returns a new built-in function with the given prototype.
+func NewFunctionBuiltIn(name string, prototype parser.FunctionPrototype, f func([]Type) ([]Type, error)) *FunctionBuiltIn {
+	return &FunctionBuiltIn{
+		Name:      name,
+		overloads: []builtInOverload{{prototype: prototype, f: f}},
+	}
+}
+
+// AddOverload adds a prototype to the built-in function, it must have different parameters from the other ones.
+func (f *FunctionBuiltIn) AddOverload(prototype parser.FunctionPrototype, fn func([]Type) ([]Type, error)) error {
+	if f.overloads == nil {
+		return fmt.Errorf("cannot overload the built-in function %s", f.Name)
+	}
+	for _, overload := range f.overloads {
+		if sameParameters(overload.prototype.Parameters, prototype.Parameters) {
+			return fmt.Errorf("the built-in function %s already has the prototype %s", f.Name, eclaDecl.MethodType(prototype))
+		}
+	}
+	f.overloads = append(f.overloads, builtInOverload{prototype: prototype, f: fn})
+	return nil
+}
+
+// GetPrototypes returns the prototypes of the built-in function, nil if it checks its arguments itself.
+func (f *FunctionBuiltIn) GetPrototypes() []parser.FunctionPrototype {
+	var prototypes []parser.FunctionPrototype
+	for _, overload := range f.overloads {
+		prototypes = append(prototypes, overload.prototype)
+	}
+	return prototypes
 }
 
 func (f *FunctionBuiltIn) Call(args []Type) ([]Type, error) {
-	return f.f(args)
+	if f.overloads == nil {
+		return f.f(args)
+	}
+	overload, ok := f.getOverload(args)
+	if !ok {
+		var types []string
+		for _, arg := range args {
+			types = append(types, arg.GetType())
+		}
+		return nil, fmt.Errorf("function %s called with incorrect arguments (%s)", f.Name, strings.Join(types, ","))
+	}
+	results, err := overload.f(args)
+	if err != nil {
+		return nil, err
+	}
+	if !matchTypes(overload.prototype.ReturnTypes, results) {
+		return nil, fmt.Errorf("function %s returned values not matching %s", f.Name, eclaDecl.MethodType(overload.prototype))
+	}
+	return results, nil
+}
+
+// getOverload returns the overload whose parameters match the arguments, the one with the fewest parameters of type any.
+func (f *FunctionBuiltIn) getOverload(args []Type) (builtInOverload, bool) {
+	var found builtInOverload
+	fewestAny := -1
+	for _, overload := range f.overloads {
+		var params []string
+		nbAny := 0
+		for _, param := range overload.prototype.Parameters {
+			params = append(params, param.Type)
+			if param.Type == parser.Any {
+				nbAny++
+			}
+		}
+		if matchTypes(params, args) && (fewestAny == -1 || nbAny < fewestAny) {
+			found, fewestAny = overload, nbAny
+		}
+	}
+	return found, fewestAny != -1
+}
+
+// matchTypes returns true if each value has the type at the same index, any matches every value.
+func matchTypes(types []string, values []Type) bool {
+	if len(types) != len(values) {
+		return false
+	}
+	for i, typ := range types {
+		if typ != parser.Any && values[i].GetType() != typ {
+			return false
+		}
+	}
+	return true
+}
+
+// sameParameters returns true if the parameters have the same types.
+func sameParameters(a, b []parser.FunctionParams) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type {
+			return false
+		}
+	}
+	return true
 }
 
 // implement Type interface
@@ -34,7 +142,9 @@ func (f *FunctionBuiltIn) GetString() String {
 }
 
 func (f *FunctionBuiltIn) GetType() string {
-	//TODO Change this
+	if f.overloads != nil {
+		return eclaDecl.MethodType(f.overloads[0].prototype)
+	}
 	return "function()"
 }
 
@@ -141,7 +251,7 @@ func NewSizeOf() *FunctionBuiltIn {
 			if len(args) != 1 {
 				return nil, errors.New("sizeOf function takes exactly one argument")
 			}
-			return []Type{NewInt(strconv.Itoa(args[0].GetSize()))}, nil
+			return []Type{Int(args[0].GetSize())}, nil
 		},
 	}
 }
@@ -154,7 +264,7 @@ func NewLen() *FunctionBuiltIn {
 				return nil, errors.New("len function takes exactly one argument")
 			}
 			l, err := args[0].Len()
-			return []Type{NewInt(strconv.Itoa(l))}, err
+			return []Type{Int(l)}, err
 		},
 	}
 }
@@ -437,3 +437,43 @@ func TestLenFunctionBuiltIn(t *testing.T) {
 		t.Error("Expected error when getting length BuiltInFunction")
 	}
 }
+
+func TestFunctionBuiltInOverload(t *testing.T) {
+	f := NewFunctionBuiltIn("test", prototype([]string{parser.Int}, parser.String), func(args []Type) ([]Type, error) {
+		return []Type{String("int")}, nil
+	})
+	if f.GetType() != "function(int)(string)" {
+		t.Errorf("Expected function(int)(string), got %s", f.GetType())
+	}
+	err := f.AddOverload(prototype([]string{parser.Any}, parser.String), func(args []Type) ([]Type, error) {
+		return []Type{String("any")}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.AddOverload(prototype([]string{parser.Int}), nil); err == nil {
+		t.Error("Expected an error for an existing prototype, got nil")
+	}
+	if len(f.GetPrototypes()) != 2 {
+		t.Errorf("Expected 2 prototypes, got %d", len(f.GetPrototypes()))
+	}
+	if r, err := f.Call([]Type{Int(1)}); err != nil || r[0] != String("int") {
+		t.Errorf("Expected the int overload, got %v, %v", r, err)
+	}
+	if r, err := f.Call([]Type{Bool(true)}); err != nil || r[0] != String("any") {
+		t.Errorf("Expected the any overload, got %v, %v", r, err)
+	}
+	if _, err := f.Call([]Type{Int(1), Int(2)}); err == nil {
+		t.Error("Expected an error for incorrect arguments, got nil")
+	}
+
+	wrong := NewFunctionBuiltIn("wrong", prototype(nil, parser.Int), func(args []Type) ([]Type, error) {
+		return []Type{String("a")}, nil
+	})
+	if _, err := wrong.Call(nil); err == nil {
+		t.Error("Expected an error for a result not matching the prototype, got nil")
+	}
+	if err := NewTypeOf().AddOverload(prototype(nil), nil); err == nil {
+		t.Error("Expected an error when overloading a built-in function without prototype, got nil")
+	}
+}
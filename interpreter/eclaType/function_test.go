@@ -32,12 +32,13 @@ func TestNewFunction(t *testing.T) {
 	body = append(
 		body, parser.Literal{
 			lexer.Token{
-				"type",
-				"val",
-				0,
-				0},
+				TokenType: "type",
+				Value:     "val",
+				Position:  0,
+				Line:      0},
 			"type",
-			"val"})
+			"val",
+			nil})
 
 	f := NewFunction("test", args, body, ret)
 
@@ -91,12 +92,13 @@ func TestNewAnonymousFunction(t *testing.T) {
 	body = append(
 		body, parser.Literal{
 			lexer.Token{
-				"type",
-				"val",
-				0,
-				0},
+				TokenType: "type",
+				Value:     "val",
+				Position:  0,
+				Line:      0},
 			"type",
-			"val"})
+			"val",
+			nil})
 
 	f := NewAnonymousFunction(args, body, ret)
 
@@ -150,12 +152,13 @@ func TestAddOverload(t *testing.T) {
 	body = append(
 		body, parser.Literal{
 			lexer.Token{
-				"type",
-				"val",
-				0,
-				0},
+				TokenType: "type",
+				Value:     "val",
+				Position:  0,
+				Line:      0},
 			"type",
-			"val"})
+			"val",
+			nil})
 
 	f := NewFunction("test", nil, nil, nil)
 	if f == nil {
@@ -275,12 +278,13 @@ func TestGetBodySimple(t *testing.T) {
 	body = append(
 		body, parser.Literal{
 			lexer.Token{
-				"type",
-				"val",
-				0,
-				0},
+				TokenType: "type",
+				Value:     "val",
+				Position:  0,
+				Line:      0},
 			"type",
-			"val"})
+			"val",
+			nil})
 
 	f := NewFunction("test", args, body, nil)
 
@@ -301,12 +305,13 @@ func TestGetBodyOverload(t *testing.T) {
 	body = append(
 		body, parser.Literal{
 			lexer.Token{
-				"type",
-				"val",
-				0,
-				0},
+				TokenType: "type",
+				Value:     "val",
+				Position:  0,
+				Line:      0},
 			"type",
-			"val"})
+			"val",
+			nil})
 
 	f := NewFunction("test", args, body, nil)
 	f.AddOverload(nil, nil, nil)
@@ -369,12 +374,13 @@ func TestOverride(t *testing.T) {
 	body = append(
 		body, parser.Literal{
 			lexer.Token{
-				"type",
-				"val",
-				0,
-				0},
+				TokenType: "type",
+				Value:     "val",
+				Position:  0,
+				Line:      0},
 			"type",
-			"val"})
+			"val",
+			nil})
 
 	err := f.Override(args, body, ret)
 	if err != nil {
@@ -693,19 +699,38 @@ func TestCheckReturnSimpleStructNotImplemented(t *testing.T) {
 
 func TestCheckReturnSimpleStructImplemented(t *testing.T) {
 	structType := "test"
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: nil, Name: structType}
 	var ret []Type
-	ret = append(ret, Int(0))
+	ret = append(ret, NewStruct(decl))
 	var retStr []string
 	retStr = append(retStr, structType)
 	foo := NewFunction("test", nil, nil, retStr)
 	var structDecl []eclaDecl.TypeDecl
-	structDecl = append(structDecl, &eclaDecl.StructDecl{nil, nil, structType})
+	structDecl = append(structDecl, decl)
 
 	if !foo.CheckReturn(ret, structDecl) {
 		t.Error("Expected true, got false")
 	}
 }
 
+func TestCheckReturnSimpleStructMismatch(t *testing.T) {
+	structType := "test"
+	var ret []Type
+	ret = append(ret, Int(0))
+	var retStr []string
+	retStr = append(retStr, structType)
+	foo := NewFunction("test", nil, nil, retStr)
+	var structDecl []eclaDecl.TypeDecl
+	structDecl = append(structDecl, &eclaDecl.StructDecl{Fields: nil, Order: nil, Name: structType})
+
+	if foo.CheckReturn(ret, structDecl) {
+		t.Error("Expected false, got true")
+	}
+	if !foo.CheckReturn([]Type{NewNull()}, structDecl) {
+		t.Error("Expected true for a null struct, got false")
+	}
+}
+
 // Test errors in function
 
 func TestSetValueFunction(t *testing.T) {
@@ -871,3 +896,31 @@ func TestCheckReturn(t *testing.T) {
 	}
 }
 */
+
+func TestFunctionClosure(t *testing.T) {
+	f := NewAnonymousFunction(nil, nil, nil)
+	if f.GetClosure() != nil {
+		t.Error("Expected no closure, got ", f.GetClosure())
+	}
+	scope := "scope"
+	f.SetClosure(&scope)
+	if f.GetClosure() != &scope {
+		t.Error("Expected the closure to be set")
+	}
+}
+
+func TestNewMethod(t *testing.T) {
+	f := NewMethod("move", "p", []parser.FunctionParams{{Name: "dx", Type: "int"}}, nil, nil)
+	if !f.IsMethod() {
+		t.Error("Expected a method")
+	}
+	if f.Receiver != "p" {
+		t.Error("Expected p, got ", f.Receiver)
+	}
+	if f.Name != "move" {
+		t.Error("Expected move, got ", f.Name)
+	}
+	if NewFunction("move", nil, nil, nil).IsMethod() {
+		t.Error("Expected a function that is not a method")
+	}
+}
@@ -0,0 +1,114 @@
+package eclaType
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Eclalang/Ecla/parser"
+)
+
+var (
+	typeInterface  = reflect.TypeOf((*Type)(nil)).Elem()
+	errorInterface = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// WrapGoFunction returns the implementation of a built-in function with the given prototype calling the Go function fn.
+// fn is either a func([]Type) ([]Type, error) receiving and returning the Ecla values,
+// or a Go function whose parameters and results are converted from and to the types of the prototype,
+// it may return an error after its results.
+func WrapGoFunction(prototype parser.FunctionPrototype, fn any) (func([]Type) ([]Type, error), error) {
+	if raw, ok := fn.(func([]Type) ([]Type, error)); ok {
+		return raw, nil
+	}
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return nil, fmt.Errorf("%T is not a function", fn)
+	}
+	t := v.Type()
+	if t.IsVariadic() {
+		return nil, errors.New("variadic functions are not supported")
+	}
+	if t.NumIn() != len(prototype.Parameters) {
+		return nil, fmt.Errorf("the function takes %d parameters instead of %d", t.NumIn(), len(prototype.Parameters))
+	}
+	for i, param := range prototype.Parameters {
+		if !goConvertible(t.In(i), param.Type) {
+			return nil, fmt.Errorf("the parameter %d of type %s cannot receive a value of type %s", i, t.In(i), param.Type)
+		}
+	}
+	results := t.NumOut()
+	returnsError := results > 0 && t.Out(results-1) == errorInterface
+	if returnsError {
+		results--
+	}
+	if results != len(prototype.ReturnTypes) {
+		return nil, fmt.Errorf("the function returns %d values instead of %d", results, len(prototype.ReturnTypes))
+	}
+	for i, typ := range prototype.ReturnTypes {
+		if !goConvertible(t.Out(i), typ) {
+			return nil, fmt.Errorf("the result %d of type %s cannot be converted to a value of type %s", i, t.Out(i), typ)
+		}
+	}
+	return func(args []Type) ([]Type, error) {
+		in := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			value, err := toGoValue(arg, t.In(i))
+			if err != nil {
+				return nil, err
+			}
+			in[i] = value
+		}
+		out := v.Call(in)
+		if returnsError && !out[results].IsNil() {
+			return nil, out[results].Interface().(error)
+		}
+		values := make([]Type, results)
+		for i := range values {
+			value, err := fromGoValue(out[i], prototype.ReturnTypes[i])
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}, nil
+}
+
+// goConvertible returns true if the values of the Ecla type can be converted to the Go type, and back.
+func goConvertible(goType reflect.Type, typ string) bool {
+	if goType.Implements(typeInterface) || goType == typeInterface || (goType.Kind() == reflect.Interface && goType.NumMethod() == 0) {
+		return true
+	}
+	switch goType.Kind() {
+	case reflect.Pointer:
+		return goConvertible(goType.Elem(), typ)
+	case reflect.Slice, reflect.Array:
+		return strings.HasPrefix(typ, "[]") && goConvertible(goType.Elem(), typ[2:])
+	case reflect.Map:
+		key, val, ok := splitMapType(typ)
+		return ok && goConvertible(goType.Key(), key) && goConvertible(goType.Elem(), val)
+	case reflect.Struct:
+		return goType.Name() == typ
+	case reflect.Int32:
+		if typ == parser.Char {
+			return true
+		}
+	}
+	return typ != "" && staticType(goType) == typ
+}
+
+// toGoValue converts the Ecla value to a value of the Go type.
+func toGoValue(value Type, goType reflect.Type) (reflect.Value, error) {
+	v := reflect.New(goType).Elem()
+	if err := fromEcla(value, v); err != nil {
+		return reflect.Value{}, err
+	}
+	return v, nil
+}
+
+// fromGoValue converts the Go value to an Ecla value of the given type.
+func fromGoValue(v reflect.Value, typ string) (Type, error) {
+	return toEcla(v, typ, nil)
+}
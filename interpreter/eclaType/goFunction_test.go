@@ -0,0 +1,80 @@
+package eclaType
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eclalang/Ecla/parser"
+)
+
+func prototype(params []string, returns ...string) parser.FunctionPrototype {
+	var p parser.FunctionPrototype
+	for i, typ := range params {
+		p.Parameters = append(p.Parameters, parser.FunctionParams{Name: string(rune('a' + i)), Type: typ})
+	}
+	p.ReturnTypes = returns
+	return p
+}
+
+func TestWrapGoFunction(t *testing.T) {
+	f, err := WrapGoFunction(prototype([]string{parser.Int, parser.Float, parser.String, parser.Bool, parser.Char}, parser.String),
+		func(i int64, f float64, s string, b bool, c rune) string {
+			if !b {
+				return ""
+			}
+			return s + string(c) + string(rune('0'+i)) + string(rune('0'+int(f)))
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := f([]Type{Int(1), Float(2), String("a"), Bool(true), Char('b')})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r) != 1 || r[0] != String("ab12") {
+		t.Errorf("Expected ab12, got %v", r)
+	}
+
+	// an error returned after the results is returned by the built-in function
+	f, err = WrapGoFunction(prototype([]string{parser.Any}, parser.Int), func(v Type) (int, error) {
+		if v.GetType() != parser.Int {
+			return 0, errors.New("not an int")
+		}
+		return int(v.(Int)) * 2, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, err := f([]Type{Int(2)}); err != nil || r[0] != Int(4) {
+		t.Errorf("Expected 4, got %v, %v", r, err)
+	}
+	if _, err := f([]Type{String("a")}); err == nil || err.Error() != "not an int" {
+		t.Errorf("Expected the error of the function, got %v", err)
+	}
+
+	raw := func(args []Type) ([]Type, error) {
+		return args, nil
+	}
+	if _, err := WrapGoFunction(parser.FunctionPrototype{}, raw); err != nil {
+		t.Errorf("Expected a raw function to be accepted, got %v", err)
+	}
+}
+
+func TestWrapGoFunctionError(t *testing.T) {
+	cases := []struct {
+		prototype parser.FunctionPrototype
+		fn        any
+	}{
+		{prototype(nil), 1},
+		{prototype([]string{parser.Int}), func() {}},
+		{prototype([]string{parser.Int}), func(string) {}},
+		{prototype(nil, parser.Int), func() string { return "" }},
+		{prototype(nil, parser.Int), func() {}},
+		{prototype([]string{parser.Int}), func(...int) {}},
+	}
+	for i, c := range cases {
+		if _, err := WrapGoFunction(c.prototype, c.fn); err == nil {
+			t.Errorf("Expected an error for the case %d, got nil", i)
+		}
+	}
+}
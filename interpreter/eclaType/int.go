@@ -3,13 +3,15 @@ package eclaType
 import (
 	"errors"
 	"github.com/Eclalang/Ecla/interpreter/utils"
+	"github.com/Eclalang/Ecla/lexer"
 	"strconv"
 )
 
-// NewInt creates a new Int
-func NewInt(value string) Int {
-	result, _ := strconv.Atoi(value)
-	return Int(result)
+// NewInt creates a new Int from the value of an int literal, which can be prefixed by 0x, 0o or 0b
+// and whose digits can be separated by _, a malformed literal or an overflow is an error
+func NewInt(value string) (Int, error) {
+	result, err := lexer.ParseInt(value)
+	return Int(result), err
 }
 
 type Int int
@@ -8,11 +8,29 @@ import (
 // Int interacts with Int
 
 func TestNewInt(t *testing.T) {
-	t1 := NewInt("0")
+	t1, err := NewInt("0")
 
-	if t1 != 0 {
+	if err != nil || t1 != 0 {
 		t.Error("Error when creating a Int")
 	}
+
+	t2, err := NewInt("0xFF_FF")
+
+	if err != nil || t2 != 65535 {
+		t.Error("Expected 65535, got ", t2, err)
+	}
+}
+
+func TestNewIntErr(t *testing.T) {
+	_, err := NewInt("0x")
+	if err == nil {
+		t.Error("Expected error when creating a new int with wrong argument, got nil")
+	}
+
+	_, err = NewInt("99999999999999999999")
+	if err == nil {
+		t.Error("Expected error when creating an int which overflows, got nil")
+	}
 }
 
 func TestIntGetValue(t *testing.T) {
@@ -0,0 +1,190 @@
+package eclaType
+
+import (
+	"errors"
+
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+)
+
+// Interface is a value typed by an interface, it holds a struct that implements the interface.
+type Interface struct {
+	Value      Type
+	Definition *eclaDecl.InterfaceDecl
+}
+
+func (i *Interface) String() string {
+	return string(i.Value.GetString())
+}
+
+func (i *Interface) GetString() String {
+	return i.Value.GetString()
+}
+
+func (i *Interface) GetValue() any {
+	return i.Value.GetValue()
+}
+
+func (i *Interface) SetValue(value any) error {
+	return i.Value.SetValue(value)
+}
+
+// GetType returns the name of the interface
+func (i *Interface) GetType() string {
+	return i.Definition.Name
+}
+
+func (i *Interface) GetIndex(index Type) (*Type, error) {
+	return i.Value.GetIndex(index)
+}
+
+// Add adds two Type objects
+func (i *Interface) Add(other Type) (Type, error) {
+	return i.Value.Add(other)
+}
+
+// Sub subtracts two Type objects
+func (i *Interface) Sub(other Type) (Type, error) {
+	return i.Value.Sub(other)
+}
+
+// Mul multiplies two Type objects
+func (i *Interface) Mul(other Type) (Type, error) {
+	return i.Value.Mul(other)
+}
+
+// Div divides two Type objects
+func (i *Interface) Div(other Type) (Type, error) {
+	return i.Value.Div(other)
+}
+
+// Mod modulos two Type objects
+func (i *Interface) Mod(other Type) (Type, error) {
+	return i.Value.Mod(other)
+}
+
+// DivEc divides two Type objects
+func (i *Interface) DivEc(other Type) (Type, error) {
+	return i.Value.DivEc(other)
+}
+
+// Eq returns true if the two Type objects are equal
+func (i *Interface) Eq(other Type) (Type, error) {
+	return i.Value.Eq(other)
+}
+
+// NotEq returns true if the two Type objects are not equal
+func (i *Interface) NotEq(other Type) (Type, error) {
+	return i.Value.NotEq(other)
+}
+
+// Gt returns true if the first Type object is greater than the second
+func (i *Interface) Gt(other Type) (Type, error) {
+	return i.Value.Gt(other)
+}
+
+// GtEq returns true if the first Type object is greater than or equal to the second
+func (i *Interface) GtEq(other Type) (Type, error) {
+	return i.Value.GtEq(other)
+}
+
+// Lw returns true if the first Type object is lower than the second
+func (i *Interface) Lw(other Type) (Type, error) {
+	return i.Value.Lw(other)
+}
+
+// LwEq returns true if the first Type object is lower than or equal to the second
+func (i *Interface) LwEq(other Type) (Type, error) {
+	return i.Value.LwEq(other)
+}
+
+// And returns true if the two Type objects are true
+func (i *Interface) And(other Type) (Type, error) {
+	return i.Value.And(other)
+}
+
+// Or returns true if either Type objects is true
+func (i *Interface) Or(other Type) (Type, error) {
+	return i.Value.Or(other)
+}
+
+// Xor returns true if either Type objects is true, but not both
+func (i *Interface) Xor(other Type) (Type, error) {
+	return i.Value.Xor(other)
+}
+
+// Not returns the opposite of the Type object
+func (i *Interface) Not() (Type, error) {
+	return i.Value.Not()
+}
+
+func (i *Interface) Append(other Type) (Type, error) {
+	return i.Value.Append(other)
+}
+
+func (i *Interface) IsNull() bool {
+	return i.Value.IsNull()
+}
+
+func (i *Interface) GetSize() int {
+	return i.Value.GetSize()
+}
+
+func (i *Interface) Len() (int, error) {
+	return i.Value.Len()
+}
+
+// NewInterface returns the value typed by the interface, the value must be null or a struct that implements the interface.
+func NewInterface(def *eclaDecl.InterfaceDecl, value Type) (*Interface, error) {
+	switch value.(type) {
+	case *Var:
+		value = value.(*Var).Value
+	}
+	switch value.(type) {
+	case *Any:
+		value = value.(*Any).Value
+	case *Interface:
+		value = value.(*Interface).Value
+	}
+	if value.IsNull() {
+		return &Interface{Value: NewNullType(def.Name), Definition: def}, nil
+	}
+	s, ok := value.(*Struct)
+	if !ok || s.Definition == nil {
+		return nil, errors.New("cannot use value of type " + value.GetType() + " as interface " + def.Name)
+	}
+	if err := def.IsImplementedBy(s.Definition); err != nil {
+		return nil, err
+	}
+	return &Interface{Value: s, Definition: def}, nil
+}
+
+// getInterfaceDecl returns the declaration of the interface with the given name.
+func getInterfaceDecl(name string, decls []eclaDecl.TypeDecl) (*eclaDecl.InterfaceDecl, bool) {
+	for _, decl := range decls {
+		if decl.GetName() == name {
+			def, ok := decl.(*eclaDecl.InterfaceDecl)
+			return def, ok
+		}
+	}
+	return nil, false
+}
+
+// isTypeDecl returns true if a type is declared with the given name.
+func isTypeDecl(name string, decls []eclaDecl.TypeDecl) bool {
+	for _, decl := range decls {
+		if decl.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// implementsInterface returns true if typ is the name of an interface implemented by the value.
+func implementsInterface(value Type, typ string, decls []eclaDecl.TypeDecl) bool {
+	def, ok := getInterfaceDecl(typ, decls)
+	if !ok {
+		return false
+	}
+	_, err := NewInterface(def, value)
+	return err == nil
+}
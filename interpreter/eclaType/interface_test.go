@@ -0,0 +1,118 @@
+package eclaType
+
+import (
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+	"github.com/Eclalang/Ecla/parser"
+	"testing"
+)
+
+var namedDecl = &eclaDecl.InterfaceDecl{
+	Fields:  map[string]string{"name": parser.String},
+	Order:   []string{"name"},
+	Name:    "Named",
+	Methods: map[string]string{"hello": "function()(string)"},
+}
+
+func newNamedStruct(name string, withMethod bool) *Struct {
+	decl := &eclaDecl.StructDecl{
+		Fields: map[string]string{"name": parser.String},
+		Order:  []string{"name"},
+		Name:   name,
+	}
+	if withMethod {
+		_ = decl.AddMethod("hello", NewFunction("hello", nil, nil, []string{parser.String}))
+	}
+	s := NewStruct(decl)
+	s.AddField(0, String(name))
+	return s
+}
+
+func TestNewInterface(t *testing.T) {
+	s := newNamedStruct("Person", true)
+	i, err := NewInterface(namedDecl, s)
+	if err != nil {
+		t.Error("Expected nil, got ", err)
+	}
+	if i.GetType() != "Named" {
+		t.Error("Expected Named, got ", i.GetType())
+	}
+	if i.Value != s {
+		t.Error("Expected the interface to hold the struct")
+	}
+
+	i2, err := NewInterface(namedDecl, &Var{Name: "v", Value: i})
+	if err != nil {
+		t.Error("Expected nil, got ", err)
+	}
+	if i2 == i || i2.Value != s {
+		t.Error("Expected a new interface holding the same struct")
+	}
+
+	null, err := NewInterface(namedDecl, NewNull())
+	if err != nil {
+		t.Error("Expected nil, got ", err)
+	}
+	if !null.IsNull() || null.GetType() != "Named" {
+		t.Error("Expected a null value of type Named")
+	}
+}
+
+func TestNewInterfaceErrors(t *testing.T) {
+	if _, err := NewInterface(namedDecl, newNamedStruct("Person", false)); err == nil {
+		t.Error("Expected an error for a struct without the method, got nil")
+	}
+	if _, err := NewInterface(namedDecl, Int(0)); err == nil {
+		t.Error("Expected an error for a value that is not a struct, got nil")
+	}
+}
+
+func TestInterfaceDelegates(t *testing.T) {
+	s := newNamedStruct("Person", true)
+	i, _ := NewInterface(namedDecl, s)
+	if i.String() != s.String() {
+		t.Errorf("Expected %s, got %s", s.String(), i.String())
+	}
+	field, err := i.GetIndex(String("name"))
+	if err != nil || (*field).String() != "Person" {
+		t.Error("Expected the field of the struct, got ", err)
+	}
+	if _, err := i.Add(Int(1)); err == nil {
+		t.Error("Expected an error when adding an int to a struct, got nil")
+	}
+}
+
+func TestFunctionWithInterfaceParameter(t *testing.T) {
+	decls := []eclaDecl.TypeDecl{namedDecl}
+	f := NewFunction("greet", []parser.FunctionParams{{Name: "n", Type: "Named"}}, nil, []string{"Named"})
+
+	ok, args := f.TypeAndNumberOfArgsIsCorrect([]Type{newNamedStruct("Person", true)}, decls)
+	if !ok {
+		t.Fatal("Expected true, got false")
+	}
+	if _, isInterface := args["n"].Value.(*Interface); !isInterface {
+		t.Errorf("Expected the parameter to be typed by the interface, got %T", args["n"].Value)
+	}
+	if !f.CheckReturn([]Type{newNamedStruct("Person", true)}, decls) {
+		t.Error("Expected true for a returned struct that implements the interface, got false")
+	}
+	if f.CheckReturn([]Type{newNamedStruct("Robot", false)}, decls) {
+		t.Error("Expected false for a returned struct that does not implement the interface, got true")
+	}
+	if ok, _ := f.TypeAndNumberOfArgsIsCorrect([]Type{newNamedStruct("Robot", false)}, decls); ok {
+		t.Error("Expected false for a struct that does not implement the interface, got true")
+	}
+	if err := f.CheckInterfaces([]Type{newNamedStruct("Robot", false)}, decls); err == nil {
+		t.Error("Expected an error for a struct that does not implement the interface, got nil")
+	}
+}
+
+func TestNewVarNullInterface(t *testing.T) {
+	null, _ := NewInterface(namedDecl, NewNull())
+	v, err := NewVar("n", "Named", null)
+	if err != nil {
+		t.Error("Expected nil, got ", err)
+	}
+	if _, ok := v.Value.(*Interface); !ok {
+		t.Errorf("Expected the variable to keep its interface, got %T", v.Value)
+	}
+}
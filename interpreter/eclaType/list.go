@@ -107,7 +107,7 @@ func (l *List) Add(other Type) (Type, error) {
 			for _, elem := range other.(*List).Value {
 				i := int(elem.(Int))
 				var err error = nil
-				c, err := NewChar(string(rune(i)))
+				c, err := NewCharFromInt(i)
 				if err != nil {
 					return nil, err
 				}
@@ -347,7 +347,7 @@ func (l *List) Append(other Type) (Type, error) {
 		if l.GetValueType() == parser.Char && other.GetType() == parser.Int {
 			i := int(*(other.(*Int)))
 			var err error = nil
-			c, err := NewChar(string(rune(i)))
+			c, err := NewCharFromInt(i)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,562 @@
+package eclaType
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// The Go values are converted to Ecla values as follows :
+//   - bool to bool, the integers to int, the floats to float and string to string
+//   - the slices and the arrays to lists, the maps to maps
+//   - the structs to structs of the same name, their exported fields are named by their `ecla:"name"` tag,
+//     or by their Go name without tag, the fields tagged `ecla:"-"` are ignored
+//   - the pointers and the interfaces to the value they hold, nil to null
+//   - the Ecla values are kept as is
+//
+// A rune is an int32 for Go, it is converted to a char only when the Ecla type asked or declared is char.
+
+// ToEcla converts the Go value to an Ecla value.
+// A struct is converted to the struct with the same name in decls, or to a struct declared from its fields when there is none.
+// A float64 that a float cannot hold exactly, like 0.1, is an error instead of being rounded.
+func ToEcla(value any, decls ...eclaDecl.TypeDecl) (Type, error) {
+	return toEcla(reflect.ValueOf(value), "", decls)
+}
+
+// ToEclaAs converts the Go value to an Ecla value of the given type, like ToEcla.
+func ToEclaAs(value any, typ string, decls ...eclaDecl.TypeDecl) (Type, error) {
+	t, err := toEcla(reflect.ValueOf(value), typ, decls)
+	if err != nil {
+		return nil, err
+	}
+	if typ != parser.Any && t.GetType() != typ {
+		return nil, fmt.Errorf("cannot convert %T to %s", value, typ)
+	}
+	return t, nil
+}
+
+// FromEcla stores the Ecla value in the Go value target points to, it is the reverse conversion of ToEcla.
+// An Ecla value stored in an interface{} is converted to int, float64, string, bool, rune, []any,
+// map[any]any for a map, map[string]any for a struct, or nil for null.
+func FromEcla(value Type, target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("cannot store a value in %T, a non-nil pointer is expected", target)
+	}
+	return fromEcla(value, v.Elem())
+}
+
+// staticType returns the Ecla type of the values of the Go type, an empty string when it depends on the value.
+func staticType(t reflect.Type) string {
+	if t.Implements(typeInterface) {
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return parser.Bool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return parser.Int
+	case reflect.Float32, reflect.Float64:
+		return parser.Float
+	case reflect.String:
+		return parser.String
+	case reflect.Slice, reflect.Array:
+		if elem := staticType(t.Elem()); elem != "" {
+			return "[]" + elem
+		}
+	case reflect.Map:
+		key, val := staticType(t.Key()), staticType(t.Elem())
+		if key != "" && val != "" {
+			return parser.Map + "[" + key + "]" + val
+		}
+	case reflect.Struct:
+		return t.Name()
+	case reflect.Pointer:
+		return staticType(t.Elem())
+	}
+	return ""
+}
+
+// toEcla converts the Go value to an Ecla value of the given type, the type is found from the value when it is empty or any.
+func toEcla(v reflect.Value, typ string, decls []eclaDecl.TypeDecl) (Type, error) {
+	if typ == parser.Any {
+		typ = ""
+	}
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) {
+		if v.CanInterface() {
+			if t, ok := v.Interface().(Type); ok {
+				return t, nil
+			}
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		if typ == "" {
+			return NewNull(), nil
+		}
+		return NewNullType(typ), nil
+	}
+	if v.CanInterface() {
+		if t, ok := v.Interface().(Type); ok {
+			return t, nil
+		}
+	}
+	if typ == "" {
+		typ = staticType(v.Type())
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if typ == parser.Bool {
+			return Bool(v.Bool()), nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch typ {
+		case parser.Int:
+			return Int(v.Int()), nil
+		case parser.Char:
+			if !utf8.ValidRune(rune(v.Int())) || int64(rune(v.Int())) != v.Int() {
+				return nil, fmt.Errorf("%d is not a valid char", v.Int())
+			}
+			return Char(v.Int()), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if typ == parser.Int {
+			if v.Uint() > math.MaxInt {
+				return nil, fmt.Errorf("%d overflows int", v.Uint())
+			}
+			return Int(v.Uint()), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if typ == parser.Float {
+			f := v.Float()
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return Float(f), nil
+			}
+			if math.Abs(f) > math.MaxFloat32 {
+				return nil, fmt.Errorf("%g overflows float", f)
+			}
+			// float is a 32 bits float, the values it cannot hold exactly are not rounded silently
+			if float64(float32(f)) != f {
+				return nil, fmt.Errorf("%g cannot be represented exactly by float", f)
+			}
+			return Float(f), nil
+		}
+	case reflect.String:
+		if typ == parser.String {
+			return String(v.String()), nil
+		}
+	case reflect.Slice, reflect.Array:
+		return toEclaList(v, typ, decls)
+	case reflect.Map:
+		return toEclaMap(v, typ, decls)
+	case reflect.Struct:
+		return toEclaStruct(v, typ, decls)
+	}
+	if typ == "" {
+		return nil, fmt.Errorf("cannot convert %s to an Ecla value", v.Type())
+	}
+	return nil, fmt.Errorf("cannot convert %s to %s", v.Type(), typ)
+}
+
+// elementsType returns the type of the values, they must all have the same type.
+func elementsType(values []Type) (string, error) {
+	if len(values) == 0 {
+		return parser.Any, nil
+	}
+	typ := values[0].GetType()
+	for _, value := range values[1:] {
+		if value.GetType() != typ {
+			return "", fmt.Errorf("cannot mix %s and %s", typ, value.GetType())
+		}
+	}
+	return typ, nil
+}
+
+func toEclaList(v reflect.Value, typ string, decls []eclaDecl.TypeDecl) (Type, error) {
+	elem := ""
+	if typ != "" {
+		if !strings.HasPrefix(typ, "[]") {
+			return nil, fmt.Errorf("cannot convert %s to %s", v.Type(), typ)
+		}
+		elem = typ[2:]
+	}
+	values := make([]Type, v.Len())
+	for i := range values {
+		value, err := toEcla(v.Index(i), elem, decls)
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %s", i, err)
+		}
+		values[i] = value
+	}
+	if elem == "" || elem == parser.Any {
+		var err error
+		if elem, err = elementsType(values); err != nil {
+			return nil, fmt.Errorf("cannot convert %s to a list: %s", v.Type(), err)
+		}
+	}
+	return &List{Value: values, Typ: "[]" + elem}, nil
+}
+
+func toEclaMap(v reflect.Value, typ string, decls []eclaDecl.TypeDecl) (Type, error) {
+	keyTyp, valTyp := "", ""
+	if typ != "" {
+		var ok bool
+		if keyTyp, valTyp, ok = splitMapType(typ); !ok {
+			return nil, fmt.Errorf("cannot convert %s to %s", v.Type(), typ)
+		}
+	}
+	m := NewMap()
+	for _, key := range v.MapKeys() {
+		k, err := toEcla(key, keyTyp, decls)
+		if err != nil {
+			return nil, fmt.Errorf("key %v: %s", key, err)
+		}
+		val, err := toEcla(v.MapIndex(key), valTyp, decls)
+		if err != nil {
+			return nil, fmt.Errorf("value of %v: %s", key, err)
+		}
+		m.Keys = append(m.Keys, k)
+		m.Values = append(m.Values, val)
+	}
+	// the map of Go is not ordered, the keys are sorted to always get the same Ecla map
+	sort.Sort(byKey{m})
+	var err error
+	if keyTyp == "" || keyTyp == parser.Any {
+		if keyTyp, err = elementsType(m.Keys); err != nil {
+			return nil, fmt.Errorf("cannot convert %s to a map: %s", v.Type(), err)
+		}
+	}
+	if valTyp == "" || valTyp == parser.Any {
+		if valTyp, err = elementsType(m.Values); err != nil {
+			return nil, fmt.Errorf("cannot convert %s to a map: %s", v.Type(), err)
+		}
+	}
+	m.TypKey, m.TypVal = keyTyp, valTyp
+	m.Typ = parser.Map + "[" + keyTyp + "]" + valTyp
+	return m, nil
+}
+
+// byKey sorts the entries of a map by the string of their key.
+type byKey struct {
+	m *Map
+}
+
+func (b byKey) Len() int {
+	return len(b.m.Keys)
+}
+
+func (b byKey) Less(i, j int) bool {
+	return b.m.Keys[i].String() < b.m.Keys[j].String()
+}
+
+func (b byKey) Swap(i, j int) {
+	b.m.Keys[i], b.m.Keys[j] = b.m.Keys[j], b.m.Keys[i]
+	b.m.Values[i], b.m.Values[j] = b.m.Values[j], b.m.Values[i]
+}
+
+// splitMapType returns the type of the keys and the type of the values of a map type.
+func splitMapType(typ string) (string, string, bool) {
+	if !strings.HasPrefix(typ, parser.Map+"[") {
+		return "", "", false
+	}
+	depth := 0
+	for i := len(parser.Map); i < len(typ); i++ {
+		switch typ[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return typ[len(parser.Map)+1 : i], typ[i+1:], true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// structField is a field of a Go struct converted to an Ecla struct.
+type structField struct {
+	name  string
+	index int
+}
+
+// structFields returns the fields of the Go struct type converted to the fields of an Ecla struct.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("ecla"); ok {
+			tag, _, _ = strings.Cut(tag, ",")
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, structField{name: name, index: i})
+	}
+	return fields
+}
+
+func toEclaStruct(v reflect.Value, typ string, decls []eclaDecl.TypeDecl) (Type, error) {
+	if typ == "" {
+		return nil, fmt.Errorf("cannot convert the anonymous struct %s", v.Type())
+	}
+	fields := structFields(v.Type())
+	var def *eclaDecl.StructDecl
+	for _, decl := range decls {
+		if d, ok := decl.(*eclaDecl.StructDecl); ok && d.Name == typ {
+			def = d
+		}
+	}
+	if def == nil {
+		// the struct is declared from the Go fields
+		def = &eclaDecl.StructDecl{Fields: make(map[string]string), Name: typ, Methods: make(map[string]eclaDecl.Method)}
+		for _, field := range fields {
+			fieldTyp := staticType(v.Type().Field(field.index).Type)
+			if fieldTyp == "" {
+				fieldTyp = parser.Any
+			}
+			def.Fields[field.name] = fieldTyp
+			def.Order = append(def.Order, field.name)
+		}
+	}
+	s := NewStruct(def)
+	for _, field := range fields {
+		fieldTyp, ok := def.Fields[field.name]
+		if !ok {
+			return nil, fmt.Errorf("struct %s has no field %s", typ, field.name)
+		}
+		value, err := toEcla(v.Field(field.index), fieldTyp, decls)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %s", field.name, err)
+		}
+		s.Fields[field.name] = &value
+	}
+	if err := s.Verify(); err != nil {
+		return nil, fmt.Errorf("cannot convert %s to %s: %s", v.Type(), typ, err)
+	}
+	return s, nil
+}
+
+// fromEcla stores the Ecla value in the settable Go value.
+func fromEcla(value Type, v reflect.Value) error {
+	switch value.(type) {
+	case *Var:
+		value = value.(*Var).Value
+	case *Any:
+		value = value.(*Any).Value
+	case *Interface:
+		value = value.(*Interface).Value
+	}
+	if reflect.TypeOf(value).AssignableTo(v.Type()) && (v.Kind() != reflect.Interface || v.NumMethod() > 0) {
+		v.Set(reflect.ValueOf(value))
+		return nil
+	}
+	if _, ok := value.(Null); ok {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+			v.SetZero()
+			return nil
+		}
+		return fmt.Errorf("cannot convert null to %s", v.Type())
+	}
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := fromEcla(value, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+	if v.Kind() == reflect.Interface {
+		if v.NumMethod() > 0 {
+			return fmt.Errorf("cannot convert %s to %s", value.GetType(), v.Type())
+		}
+		natural, err := naturalGoValue(value)
+		if err != nil {
+			return err
+		}
+		if natural.IsValid() {
+			v.Set(natural)
+		} else {
+			v.SetZero()
+		}
+		return nil
+	}
+	switch value.(type) {
+	case Bool:
+		if v.Kind() == reflect.Bool {
+			v.SetBool(bool(value.(Bool)))
+			return nil
+		}
+	case Int, Char:
+		var i int64
+		if c, ok := value.(Char); ok {
+			i = int64(c)
+		} else {
+			i = int64(value.(Int))
+		}
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.OverflowInt(i) {
+				return fmt.Errorf("%d overflows %s", i, v.Type())
+			}
+			v.SetInt(i)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if i < 0 || v.OverflowUint(uint64(i)) {
+				return fmt.Errorf("%d overflows %s", i, v.Type())
+			}
+			v.SetUint(uint64(i))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			if _, ok := value.(Int); ok {
+				v.SetFloat(float64(i))
+				return nil
+			}
+		case reflect.String:
+			if _, ok := value.(Char); ok {
+				v.SetString(string(rune(i)))
+				return nil
+			}
+		}
+	case Float:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			v.SetFloat(float64(value.(Float)))
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return fmt.Errorf("cannot convert float %s to %s without losing its fractional part", value.String(), v.Type())
+		}
+	case String:
+		if v.Kind() == reflect.String {
+			v.SetString(string(value.(String)))
+			return nil
+		}
+	case *List:
+		return fromEclaList(value.(*List), v)
+	case *Map:
+		return fromEclaMap(value.(*Map), v)
+	case *Struct:
+		return fromEclaStruct(value.(*Struct), v)
+	}
+	return fmt.Errorf("cannot convert %s to %s", value.GetType(), v.Type())
+}
+
+func fromEclaList(l *List, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(v.Type(), len(l.Value), len(l.Value))
+		for i, value := range l.Value {
+			if err := fromEcla(value, slice.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %s", i, err)
+			}
+		}
+		v.Set(slice)
+		return nil
+	case reflect.Array:
+		if v.Len() != len(l.Value) {
+			return fmt.Errorf("cannot convert a list of %d elements to %s", len(l.Value), v.Type())
+		}
+		for i, value := range l.Value {
+			if err := fromEcla(value, v.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %s", i, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot convert %s to %s", l.GetType(), v.Type())
+}
+
+func fromEclaMap(m *Map, v reflect.Value) error {
+	if v.Kind() != reflect.Map {
+		return fmt.Errorf("cannot convert %s to %s", m.GetType(), v.Type())
+	}
+	result := reflect.MakeMapWithSize(v.Type(), len(m.Keys))
+	for i, key := range m.Keys {
+		k := reflect.New(v.Type().Key()).Elem()
+		if err := fromEcla(key, k); err != nil {
+			return fmt.Errorf("key %s: %s", key.String(), err)
+		}
+		val := reflect.New(v.Type().Elem()).Elem()
+		if err := fromEcla(m.Values[i], val); err != nil {
+			return fmt.Errorf("value of %s: %s", key.String(), err)
+		}
+		result.SetMapIndex(k, val)
+	}
+	v.Set(result)
+	return nil
+}
+
+func fromEclaStruct(s *Struct, v reflect.Value) error {
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot convert %s to %s", s.GetType(), v.Type())
+	}
+	for _, field := range structFields(v.Type()) {
+		value, ok := s.Fields[field.name]
+		if !ok {
+			return fmt.Errorf("struct %s has no field %s", s.GetType(), field.name)
+		}
+		if err := fromEcla(*value, v.Field(field.index)); err != nil {
+			return fmt.Errorf("field %s: %s", field.name, err)
+		}
+	}
+	return nil
+}
+
+// naturalGoValue returns the Go value an Ecla value is converted to when it is stored in an interface{}.
+func naturalGoValue(value Type) (reflect.Value, error) {
+	var target reflect.Value
+	switch value.(type) {
+	case Null:
+		return reflect.Value{}, nil
+	case Int:
+		target = reflect.New(reflect.TypeOf(0))
+	case Float:
+		target = reflect.New(reflect.TypeOf(float64(0)))
+	case String:
+		target = reflect.New(reflect.TypeOf(""))
+	case Bool:
+		target = reflect.New(reflect.TypeOf(false))
+	case Char:
+		target = reflect.New(reflect.TypeOf(rune(0)))
+	case *List:
+		target = reflect.New(reflect.TypeOf([]any{}))
+	case *Map:
+		target = reflect.New(reflect.TypeOf(map[any]any{}))
+	case *Struct:
+		s := value.(*Struct)
+		fields := make(map[string]any, len(s.Fields))
+		for name, field := range s.Fields {
+			natural, err := naturalGoValue(*field)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %s: %s", name, err)
+			}
+			if natural.IsValid() {
+				fields[name] = natural.Interface()
+			} else {
+				fields[name] = nil
+			}
+		}
+		return reflect.ValueOf(fields), nil
+	default:
+		return reflect.ValueOf(value), nil
+	}
+	if err := fromEcla(value, target.Elem()); err != nil {
+		return reflect.Value{}, err
+	}
+	return target.Elem(), nil
+}
@@ -0,0 +1,215 @@
+package eclaType
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+type point struct {
+	X      int     `ecla:"x"`
+	Y      float64 `ecla:"y"`
+	Label  string
+	Hidden bool `ecla:"-"`
+	secret int
+}
+
+func TestToEcla(t *testing.T) {
+	tests := []struct {
+		value any
+		typ   string
+		str   string
+	}{
+		{42, parser.Int, "42"},
+		{uint8(7), parser.Int, "7"},
+		{1.5, parser.Float, "1.5"},
+		{float32(0.1), parser.Float, "0.1"},
+		{math.Inf(-1), parser.Float, "-Inf"},
+		{math.NaN(), parser.Float, "NaN"},
+		{"hello", parser.String, "hello"},
+		{true, parser.Bool, "true"},
+		{'a', parser.Int, "97"},
+		{[]int{1, 2}, "[]int", "[1, 2]"},
+		{[2]string{"a", "b"}, "[]string", "[a, b]"},
+		{[][]int{{1}, {2, 3}}, "[][]int", "[[1], [2, 3]]"},
+		{[]any{1, 2}, "[]int", "[1, 2]"},
+		{map[string]int{"b": 2, "a": 1}, "map[string]int", "{a: 1, b: 2}"},
+		{map[any]any{"a": true}, "map[string]bool", "{a: true}"},
+		{Int(3), parser.Int, "3"},
+	}
+	for _, test := range tests {
+		v, err := ToEcla(test.value)
+		if err != nil {
+			t.Errorf("%v: expected nil, got %v", test.value, err)
+			continue
+		}
+		if v.GetType() != test.typ || v.String() != test.str {
+			t.Errorf("%v: expected %s of type %s, got %s of type %s", test.value, test.str, test.typ, v.String(), v.GetType())
+		}
+	}
+
+	v, err := ToEcla(point{X: 1, Y: 2.5, Label: "p", Hidden: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, ok := v.(*Struct)
+	if !ok || s.GetType() != "point" {
+		t.Fatalf("Expected a struct point, got %v", v)
+	}
+	if !reflect.DeepEqual(s.Definition.Order, []string{"x", "y", "Label"}) {
+		t.Errorf("Expected the fields x, y and Label, got %v", s.Definition.Order)
+	}
+	if *s.Fields["x"] != Int(1) || *s.Fields["y"] != Float(2.5) || *s.Fields["Label"] != String("p") {
+		t.Errorf("Expected the values of the fields, got %v", s.String())
+	}
+
+	var p *point
+	if v, err := ToEcla(p); err != nil || v.GetType() != parser.Null {
+		t.Errorf("Expected null for a nil pointer, got %v, %v", v, err)
+	}
+}
+
+func TestToEclaAs(t *testing.T) {
+	if v, err := ToEclaAs('a', parser.Char); err != nil || v != Char('a') {
+		t.Errorf("Expected the char a, got %v, %v", v, err)
+	}
+	if v, err := ToEclaAs([]rune("ab"), "[]char"); err != nil || v.String() != "[a, b]" {
+		t.Errorf("Expected a list of chars, got %v, %v", v, err)
+	}
+	if v, err := ToEclaAs([]int{}, "[]int"); err != nil || v.GetType() != "[]int" {
+		t.Errorf("Expected an empty list of int, got %v, %v", v, err)
+	}
+	if _, err := ToEclaAs(1, parser.String); err == nil {
+		t.Error("Expected an error when converting an int to a string, got nil")
+	}
+
+	// the struct is converted to the declared struct
+	decl := &eclaDecl.StructDecl{
+		Fields:  map[string]string{"x": parser.Int, "y": parser.Float, "Label": parser.String},
+		Order:   []string{"Label", "x", "y"},
+		Name:    "point",
+		Methods: map[string]eclaDecl.Method{},
+	}
+	v, err := ToEclaAs(point{X: 1}, "point", decl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.(*Struct).Definition != decl {
+		t.Error("Expected the struct to use the given declaration")
+	}
+}
+
+func TestToEclaError(t *testing.T) {
+	tests := []any{
+		[]any{1, "a"},
+		map[any]int{1: 1, "a": 2},
+		struct{ A int }{1},
+		make(chan int),
+		func() {},
+		uint64(math.MaxUint64),
+		math.MaxFloat64,
+		0.1,
+		float64(math.SmallestNonzeroFloat32) / 2,
+		complex(1, 2),
+	}
+	for _, test := range tests {
+		if v, err := ToEcla(test); err == nil {
+			t.Errorf("%T: expected an error, got %v", test, v)
+		}
+	}
+	if _, err := ToEclaAs(rune(-1), parser.Char); err == nil {
+		t.Error("Expected an error for an invalid char, got nil")
+	}
+}
+
+func TestFromEcla(t *testing.T) {
+	var i int8
+	if err := FromEcla(Int(12), &i); err != nil || i != 12 {
+		t.Errorf("Expected 12, got %v, %v", i, err)
+	}
+	var f float64
+	if err := FromEcla(Int(2), &f); err != nil || f != 2 {
+		t.Errorf("Expected 2, got %v, %v", f, err)
+	}
+	var r rune
+	if err := FromEcla(Char('a'), &r); err != nil || r != 'a' {
+		t.Errorf("Expected a, got %v, %v", r, err)
+	}
+	var s string
+	if err := FromEcla(String("a"), &s); err != nil || s != "a" {
+		t.Errorf("Expected a, got %v, %v", s, err)
+	}
+	var list []int
+	if err := FromEcla(&List{Value: []Type{Int(1), Int(2)}, Typ: "[]int"}, &list); err != nil || !reflect.DeepEqual(list, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v, %v", list, err)
+	}
+	m, _ := ToEcla(map[string]int{"a": 1})
+	var goMap map[string]int64
+	if err := FromEcla(m, &goMap); err != nil || !reflect.DeepEqual(goMap, map[string]int64{"a": 1}) {
+		t.Errorf("Expected map[a:1], got %v, %v", goMap, err)
+	}
+	v, _ := ToEcla(point{X: 1, Y: 2.5, Label: "p", Hidden: true})
+	var p point
+	if err := FromEcla(v, &p); err != nil || p != (point{X: 1, Y: 2.5, Label: "p"}) {
+		t.Errorf("Expected {1 2.5 p false 0}, got %v, %v", p, err)
+	}
+	var ptr *point
+	if err := FromEcla(v, &ptr); err != nil || ptr == nil || ptr.X != 1 {
+		t.Errorf("Expected a pointer to the point, got %v, %v", ptr, err)
+	}
+	if err := FromEcla(NewNull(), &ptr); err != nil || ptr != nil {
+		t.Errorf("Expected nil, got %v, %v", ptr, err)
+	}
+	var e Type
+	if err := FromEcla(Int(1), &e); err != nil || e != Int(1) {
+		t.Errorf("Expected the Ecla value, got %v, %v", e, err)
+	}
+
+	var natural any
+	if err := FromEcla(&List{Value: []Type{Int(1), Float(1.5)}, Typ: "[]any"}, &natural); err != nil || !reflect.DeepEqual(natural, []any{1, 1.5}) {
+		t.Errorf("Expected [1 1.5], got %v, %v", natural, err)
+	}
+	if err := FromEcla(v, &natural); err != nil || !reflect.DeepEqual(natural, map[string]any{"x": 1, "y": 2.5, "Label": "p"}) {
+		t.Errorf("Expected the fields of the struct, got %v, %v", natural, err)
+	}
+}
+
+func TestFromEclaError(t *testing.T) {
+	var i8 int8
+	if err := FromEcla(Int(300), &i8); err == nil {
+		t.Error("Expected an error for an overflow, got nil")
+	}
+	var u uint
+	if err := FromEcla(Int(-1), &u); err == nil {
+		t.Error("Expected an error for a negative uint, got nil")
+	}
+	var i int
+	if err := FromEcla(Float(1.5), &i); err == nil {
+		t.Error("Expected an error for a float converted to an int, got nil")
+	}
+	if err := FromEcla(String("a"), &i); err == nil {
+		t.Error("Expected an error for a string converted to an int, got nil")
+	}
+	if err := FromEcla(NewNull(), &i); err == nil {
+		t.Error("Expected an error for null converted to an int, got nil")
+	}
+	var array [3]int
+	if err := FromEcla(&List{Value: []Type{Int(1)}, Typ: "[]int"}, &array); err == nil {
+		t.Error("Expected an error for a list of the wrong length, got nil")
+	}
+	if err := FromEcla(Int(1), i); err == nil {
+		t.Error("Expected an error when the target is not a pointer, got nil")
+	}
+	type other struct {
+		X     int `ecla:"x"`
+		Extra string
+	}
+	v, _ := ToEcla(point{X: 1})
+	var o other
+	if err := FromEcla(v, &o); err == nil {
+		t.Error("Expected an error for a field missing from the struct, got nil")
+	}
+}
@@ -3,8 +3,9 @@ package eclaType
 import (
 	"errors"
 	"github.com/Eclalang/Ecla/interpreter/utils"
+	"github.com/Eclalang/Ecla/lexer"
 	"github.com/Eclalang/Ecla/parser"
-	"strconv"
+	"unicode/utf8"
 )
 
 func StringAreEscapedChar(value string) bool {
@@ -18,14 +19,13 @@ func StringAreEscapedChar(value string) bool {
 
 }
 
-// NewString creates a new String
+// NewString creates a new String from the value of a string literal, whose escape sequences are decoded
 func NewString(value string) (String, error) {
 	// check if there are any escape characters
 	if StringAreEscapedChar(value) {
 		return String(""), errors.New("cannot create string with escape characters")
 	}
-	value = `"` + value + `"`
-	value, err := strconv.Unquote(value)
+	value, err := lexer.Unquote(value, '"')
 	if err != nil {
 		return String(""), err
 	}
@@ -59,7 +59,7 @@ func (s String) GetType() string {
 }
 
 // TODO refactor with switch ?
-// GetIndex returns a single character
+// GetIndex returns the character at the index, a unicode code point
 func (s String) GetIndex(other Type) (*Type, error) {
 	switch other.(type) {
 	case *Var:
@@ -72,12 +72,16 @@ func (s String) GetIndex(other Type) (*Type, error) {
 	}
 	if other.GetType() == "int" {
 		ind := int(other.GetValue().(Int))
-		if ind >= len(s) || ind < 0 {
-			return nil, errors.New("index out of range")
+		if ind >= 0 {
+			for _, r := range string(s) {
+				if ind == 0 {
+					temp := Type(Char(r))
+					return &temp, nil
+				}
+				ind--
+			}
 		}
-		res := Char(s[ind])
-		temp := Type(res)
-		return &temp, nil
+		return nil, errors.New("index out of range")
 	}
 	return nil, errors.New("index must be an int")
 }
@@ -270,6 +274,7 @@ func (s String) GetSize() int {
 	return utils.Sizeof(s)
 }
 
+// Len returns the number of characters of the string, which are unicode code points
 func (s String) Len() (int, error) {
-	return len(s), nil
+	return utf8.RuneCountInString(string(s)), nil
 }
@@ -823,6 +823,54 @@ func TestStringNewStringErr(t *testing.T) {
 	}
 }
 
+func TestNewStringEscapes(t *testing.T) {
+	t1, err := NewString(`a\tb\n\u{e9}\\\"\x41\033`)
+	if err != nil {
+		t.Error(err)
+	}
+	if t1 != "a\tb\né\\\"A\x1b" {
+		t.Errorf("expected the escape sequences to be decoded, got %q", t1)
+	}
+}
+
+func TestStringNewStringInvalidEscapeErr(t *testing.T) {
+	_, err := NewString(`\u{D800}`)
+
+	if err == nil {
+		t.Error("expected error when creating string with an invalid escape sequence")
+	}
+}
+
+func TestGetIndexStringUnicode(t *testing.T) {
+	t1 := String("été 😀")
+
+	result, err := t1.GetIndex(Int(4))
+	if err != nil {
+		t.Error(err)
+	}
+	if (*result).GetValue() != Char('😀') {
+		t.Error("Expected \"😀\", got ", result)
+	}
+	if _, err = t1.GetIndex(Int(5)); err == nil {
+		t.Error("Expected error when indexing out of range")
+	}
+	if _, err = t1.GetIndex(Int(-1)); err == nil {
+		t.Error("Expected error when indexing out of range")
+	}
+}
+
+func TestStringLenUnicode(t *testing.T) {
+	t1 := String("été 😀")
+
+	result, err := t1.Len()
+	if err != nil {
+		t.Error(err)
+	}
+	if result != 5 {
+		t.Errorf("expected 5, got %d", result)
+	}
+}
+
 func TestGetIndexStringOutOfRangeErr(t *testing.T) {
 	t1 := String("123")
 	t2 := Int(3)
@@ -14,7 +14,7 @@ func TestNewStruct(t *testing.T) {
 	m[fName] = vName
 	sName := "struct"
 
-	decl := &eclaDecl.StructDecl{m, []string{fName}, sName}
+	decl := &eclaDecl.StructDecl{Fields: m, Order: []string{fName}, Name: sName}
 	t1 := NewStruct(decl)
 	if t1.Typ != sName {
 		t.Errorf("Expected %s, got %s", sName, t1.Typ)
@@ -35,7 +35,7 @@ func TestNewStruct(t *testing.T) {
 }
 
 func TestStructAddField(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{}, Order: []string{"field0"}, Name: "testStruct"}
 	s := &Struct{map[string]*Type{}, "testType", decl}
 
 	i := Int(42)
@@ -51,7 +51,7 @@ func TestStructAddField(t *testing.T) {
 }
 
 func TestStructVerify(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = Int(42)
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	err := s.Verify()
@@ -78,7 +78,7 @@ func TestStructGetType(t *testing.T) {
 }
 
 func TestStructString(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -90,7 +90,7 @@ func TestStructString(t *testing.T) {
 }
 
 func TestStructGetString(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -112,7 +112,7 @@ func TestStructSetType(t *testing.T) {
 }
 
 func TestStructGet(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -126,7 +126,7 @@ func TestStructGet(t *testing.T) {
 }
 
 func TestStructGetIndex(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -148,7 +148,7 @@ func TestStructIsNull(t *testing.T) {
 }
 
 func TestStructGetField(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -159,7 +159,7 @@ func TestStructGetField(t *testing.T) {
 }
 
 func TestStructGetFieldFalse(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{}, Name: ""}
 	s := &Struct{map[string]*Type{}, "", decl}
 	result := s.GetField("field0")
 	if result != nil {
@@ -168,7 +168,7 @@ func TestStructGetFieldFalse(t *testing.T) {
 }
 
 func TestStructLen(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -182,7 +182,7 @@ func TestStructLen(t *testing.T) {
 }
 
 func TestStructGetSize(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -218,7 +218,7 @@ func TestIsStructFalse(t *testing.T) {
 }
 
 func TestSetValueStruct(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	expected := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -242,7 +242,7 @@ func TestSetValueStruct(t *testing.T) {
 }
 
 func TestSetValueStructVar(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	v := &Var{"", &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}}
@@ -267,7 +267,7 @@ func TestSetValueStructVar(t *testing.T) {
 }
 
 func TestSetValueStructAny(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	a := &Any{&Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}, ""}
@@ -292,7 +292,7 @@ func TestSetValueStructAny(t *testing.T) {
 }
 
 func TestSetStruct(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0"}, Name: ""}
 	var i Type = Int(0)
 	expected := Int(42)
 	fieldName := "field0"
@@ -308,7 +308,7 @@ func TestSetStruct(t *testing.T) {
 }
 
 func TestSetStructVar(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0"}, Name: ""}
 	var i Type = Int(0)
 	expected := Int(42)
 	fieldName := "field0"
@@ -324,7 +324,7 @@ func TestSetStructVar(t *testing.T) {
 }
 
 func TestSetStructAnyArg(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0"}, Name: ""}
 	var i Type = Int(0)
 	expected := Int(42)
 	fieldName := "field0"
@@ -340,7 +340,7 @@ func TestSetStructAnyArg(t *testing.T) {
 }
 
 func TestSetStructAnyField(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": parser.Any}, []string{"field0"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": parser.Any}, Order: []string{"field0"}, Name: ""}
 	var i Type = &Any{Int(0), "test"}
 	expected := Int(42)
 	fieldName := "field0"
@@ -358,7 +358,7 @@ func TestSetStructAnyField(t *testing.T) {
 }
 
 func TestAddStructString(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -373,7 +373,7 @@ func TestAddStructString(t *testing.T) {
 }
 
 func TestAddStructVar(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -388,7 +388,7 @@ func TestAddStructVar(t *testing.T) {
 }
 
 func TestAddStructAny(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{"field0", "field1"}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{"field0", "field1"}, Name: ""}
 	var i Type = Int(42)
 	var str Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &i, "field1": &str}, "", decl}
@@ -535,7 +535,7 @@ func TestStructNotEqTrueAny(t *testing.T) {
 // Tests struct errors
 
 func TestStructVerifyError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{}, Order: []string{"field0"}, Name: "testStruct"}
 	s := &Struct{map[string]*Type{}, "testStruct", decl}
 	err := s.Verify()
 	if err == nil {
@@ -544,7 +544,7 @@ func TestStructVerifyError(t *testing.T) {
 }
 
 func TestStructGetError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{}, Name: ""}
 	s := &Struct{map[string]*Type{}, "", decl}
 	_, err := s.Get("field0")
 	if err == nil {
@@ -561,7 +561,7 @@ func TestStructGetIndexErrorType(t *testing.T) {
 }
 
 func TestStructGetIndexGetError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{nil, []string{}, ""}
+	decl := &eclaDecl.StructDecl{Fields: nil, Order: []string{}, Name: ""}
 	s := &Struct{map[string]*Type{}, "", decl}
 	_, err := s.GetIndex(String("field0"))
 	if err == nil {
@@ -578,7 +578,7 @@ func TestStructAddError(t *testing.T) {
 }
 
 func TestStructSubError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Sub(Int(0))
@@ -588,7 +588,7 @@ func TestStructSubError(t *testing.T) {
 }
 
 func TestStructMulError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Mul(Int(0))
@@ -598,7 +598,7 @@ func TestStructMulError(t *testing.T) {
 }
 
 func TestStructDivError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Div(Int(0))
@@ -608,7 +608,7 @@ func TestStructDivError(t *testing.T) {
 }
 
 func TestStructDivEcError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.DivEc(Int(0))
@@ -618,7 +618,7 @@ func TestStructDivEcError(t *testing.T) {
 }
 
 func TestStructModError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Mod(Int(0))
@@ -628,7 +628,7 @@ func TestStructModError(t *testing.T) {
 }
 
 func TestStructEqError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Eq(Int(0))
@@ -638,7 +638,7 @@ func TestStructEqError(t *testing.T) {
 }
 
 func TestStructNotEqError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.NotEq(Int(0))
@@ -648,7 +648,7 @@ func TestStructNotEqError(t *testing.T) {
 }
 
 func TestStructAndError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.And(Int(0))
@@ -658,7 +658,7 @@ func TestStructAndError(t *testing.T) {
 }
 
 func TestStructOrError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Or(Int(0))
@@ -668,7 +668,7 @@ func TestStructOrError(t *testing.T) {
 }
 
 func TestStructXorError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Xor(Int(0))
@@ -678,7 +678,7 @@ func TestStructXorError(t *testing.T) {
 }
 
 func TestStructGtError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Gt(Int(0))
@@ -688,7 +688,7 @@ func TestStructGtError(t *testing.T) {
 }
 
 func TestStructGtEqError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.GtEq(Int(0))
@@ -698,7 +698,7 @@ func TestStructGtEqError(t *testing.T) {
 }
 
 func TestStructLwError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Lw(Int(0))
@@ -708,7 +708,7 @@ func TestStructLwError(t *testing.T) {
 }
 
 func TestStructLwEqError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.LwEq(Int(0))
@@ -718,7 +718,7 @@ func TestStructLwEqError(t *testing.T) {
 }
 
 func TestStructNotError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Not()
@@ -728,7 +728,7 @@ func TestStructNotError(t *testing.T) {
 }
 
 func TestStructAppendError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	_, err := s.Append(Int(0))
@@ -738,7 +738,7 @@ func TestStructAppendError(t *testing.T) {
 }
 
 func TestStructSetValueError(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	err := s.SetValue(Int(0))
@@ -748,7 +748,7 @@ func TestStructSetValueError(t *testing.T) {
 }
 
 func TestStructSetErrorNotExist(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	err := s.Set("wrong", Int(0))
@@ -758,7 +758,7 @@ func TestStructSetErrorNotExist(t *testing.T) {
 }
 
 func TestStructSetErrorType(t *testing.T) {
-	decl := &eclaDecl.StructDecl{map[string]string{"field0": "string"}, []string{"field0"}, "testStruct"}
+	decl := &eclaDecl.StructDecl{Fields: map[string]string{"field0": "string"}, Order: []string{"field0"}, Name: "testStruct"}
 	var v Type = String("test")
 	s := &Struct{map[string]*Type{"field0": &v}, "testStruct", decl}
 	err := s.Set("field0", Int(0))
@@ -150,7 +150,7 @@ func (v *Var) Xor(other Type) (Type, error) {
 
 func (v *Var) Decrement() {
 	var err error
-	v.Value, err = v.Value.Sub(NewInt("1"))
+	v.Value, err = v.Value.Sub(Int(1))
 	if err != nil {
 		panic(err)
 	}
@@ -158,7 +158,7 @@ func (v *Var) Decrement() {
 
 func (v *Var) Increment() {
 	var err error
-	v.Value, err = v.Value.Add(NewInt("1"))
+	v.Value, err = v.Value.Add(Int(1))
 	if err != nil {
 		panic(err)
 	}
@@ -224,9 +224,13 @@ func NewVar(name string, Type string, value Type) (*Var, error) {
 		}, nil
 	}
 	if Type == parser.Float && value.GetType() == parser.Int {
+		f, err := NewFloat(value.String())
+		if err != nil {
+			return nil, err
+		}
 		return &Var{
 			Name:  name,
-			Value: NewFloat(value.String()),
+			Value: f,
 		}, nil
 
 	}
@@ -246,8 +250,13 @@ func NewVar(name string, Type string, value Type) (*Var, error) {
 	} else if Type != value.GetType() && !value.IsNull() {
 		return nil, errors.New("cannot create variable of type " + Type + " with value of type " + value.GetType())
 	}
-	if value.IsNull() {
-		value = NewNullType(Type)
+	switch value.(type) {
+	case *Interface:
+		// a null value typed by an interface keeps its interface
+	default:
+		if value.IsNull() {
+			value = NewNullType(Type)
+		}
 	}
 
 	switch value.(type) {
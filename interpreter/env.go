@@ -1,10 +1,11 @@
 package interpreter
 
 import (
+	"errors"
 	"fmt"
 	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
+	"io/fs"
 	"os"
-	"path/filepath"
 	"runtime"
 
 	"github.com/Eclalang/Ecla/errorHandler"
@@ -42,17 +43,40 @@ func InitBuildIn() *Scope {
 
 // Env is the environment in which the code is executed.
 type Env struct {
-	Vars         *Scope
-	OS           string
-	ARCH         string
-	SyntaxTree   *parser.File
-	Tokens       []lexer.Token
-	File         string
-	Code         string
-	Libs         map[string]libs.Lib
-	ErrorHandle  *errorHandler.ErrorHandler
-	ExecutedFunc []*eclaType.Function
+	Vars        *Scope
+	OS          string
+	ARCH        string
+	SyntaxTree  *parser.File
+	Tokens      []lexer.Token
+	File        string
+	Code        string
+	Libs        map[string]libs.Lib
+	ErrorHandle *errorHandler.ErrorHandler
+	// ExecutedFunc is the stack of the calls being executed, the code executed outside of any function first.
+	ExecutedFunc []Call
 	TypeDecl     []eclaDecl.TypeDecl
+	// Bytecode executes the code with the vm instead of the tree walker.
+	Bytecode bool
+	// chunks holds the bytecode of the bodies already compiled, by their first node.
+	chunks map[*parser.Node]*chunk
+	// module is the Env of the imported module whose code is executed, nil while executing the code of env itself.
+	module *Env
+	// home holds the libs and the type declarations of env while the code of another module is executed.
+	home moduleContext
+	// callSite is the call expression being executed, it is where the next function executed is called.
+	callSite parser.Node
+	// streams is the input, the output and the diagnostics of the code.
+	streams *streams
+	// limiter bounds the execution of the code.
+	limiter *limiter
+	// policy restricts the imports of the code.
+	policy *ImportPolicy
+	// fsys is the file system from which the files are read, the files of the os are read when it is nil
+	fsys fs.FS
+	// modules is the registry of the modules imported.
+	modules *modules
+	// builtIns is the registry of the built-in functions registered from Go, they are declared in the modules too.
+	builtIns map[string]*eclaType.FunctionBuiltIn
 }
 
 // NewEnv returns a new Env.
@@ -63,7 +87,13 @@ func NewEnv() *Env {
 		Vars:         InitBuildIn(),
 		Libs:         make(map[string]libs.Lib),
 		ErrorHandle:  errorHandler.NewHandler(),
-		ExecutedFunc: []*eclaType.Function{},
+		ExecutedFunc: []Call{},
+		TypeDecl:     []eclaDecl.TypeDecl{eclaType.ErrorDecl},
+		streams:      newStreams(),
+		limiter:      &limiter{},
+		policy:       &ImportPolicy{},
+		modules:      newModules(),
+		builtIns:     make(map[string]*eclaType.FunctionBuiltIn),
 	}
 }
 
@@ -76,7 +106,13 @@ func NewTemporaryEnv(ErrorHandler *errorHandler.ErrorHandler) *Env {
 		Vars:         InitBuildIn(),
 		Libs:         make(map[string]libs.Lib),
 		ErrorHandle:  ErrorHandler,
-		ExecutedFunc: []*eclaType.Function{},
+		ExecutedFunc: []Call{},
+		TypeDecl:     []eclaDecl.TypeDecl{eclaType.ErrorDecl},
+		streams:      newStreams(),
+		limiter:      &limiter{},
+		policy:       &ImportPolicy{},
+		modules:      newModules(),
+		builtIns:     make(map[string]*eclaType.FunctionBuiltIn),
 	}
 }
 
@@ -94,6 +130,12 @@ func (env *Env) SetFile(file string) {
 	env.File = file
 }
 
+// SetFS sets the file system from which the file of the code and the modules it imports are read.
+// The paths of the files are then slash-separated paths of fsys, as for fs.ReadFile.
+func (env *Env) SetFS(fsys fs.FS) {
+	env.fsys = fsys
+}
+
 // SetVar sets the value of the variable with the given name.
 func (env *Env) SetVar(name string, value *eclaType.Var) {
 	env.Vars.Set(name, value)
@@ -105,6 +147,36 @@ func (env *Env) GetVar(name string) (*eclaType.Var, bool) {
 	return v, ok
 }
 
+// declareVar sets the variable declared with the given binding in the current scope.
+func (env *Env) declareVar(name string, binding *parser.Binding, value *eclaType.Var) {
+	if binding.IsResolved() {
+		env.Vars.SetSlot(binding.Slot, value)
+	} else {
+		env.Vars.Set(name, value)
+	}
+}
+
+// lookupVar returns the variable used by the literal, from its slot when it was resolved and by its name otherwise.
+func (env *Env) lookupVar(lit parser.Literal) (*eclaType.Var, bool) {
+	return env.getVar(lit.Value, lit.Binding)
+}
+
+// getVar returns the variable with the given name and binding, from its slot when it was resolved and by its name otherwise.
+func (env *Env) getVar(name string, binding *parser.Binding) (*eclaType.Var, bool) {
+	if binding.IsResolved() {
+		return env.Vars.GetSlot(binding.Depth, binding.Slot)
+	}
+	return env.Vars.Get(name)
+}
+
+// isDeclared returns true if the variable with the given name and binding is already declared in the current scope.
+func (env *Env) isDeclared(name string, binding *parser.Binding) bool {
+	if binding.IsResolved() {
+		return env.Vars.CheckIfSlotIsSetInCurrentScope(binding.Slot)
+	}
+	return env.Vars.CheckIfVarExistsInCurrentScope(name)
+}
+
 // CheckIfVarExistsInCurrentScope returns true if the variable exists in the current scope.
 func (env *Env) CheckIfVarExistsInCurrentScope(name string) bool {
 	return env.Vars.CheckIfVarExistsInCurrentScope(name)
@@ -115,6 +187,11 @@ func (env *Env) NewScope(Type ScopeType) {
 	env.Vars.GoDeep(Type)
 }
 
+// NewClosureScope creates a new function scope in which variables are resolved in the given closure.
+func (env *Env) NewClosureScope(closure *Scope) {
+	env.Vars.GoDeepWithClosure(closure)
+}
+
 // SetScope sets the most deep scope.
 func (env *Env) SetScope(s *Scope) {
 	env.Vars.GoDeepWithSpecificScope(s)
@@ -135,6 +212,39 @@ func (env *Env) SetFunction(name string, f *eclaType.Function) error {
 	return nil
 }
 
+// RegisterBuiltIn declares the Go function fn as a built-in function with the given prototype.
+// fn is either a func([]eclaType.Type) ([]eclaType.Type, error) or a Go function whose parameters and results
+// are converted from and to the types of the prototype, see eclaType.WrapGoFunction.
+// Registering another prototype with the same name adds an overload to the built-in function.
+func (env *Env) RegisterBuiltIn(name string, prototype parser.FunctionPrototype, fn any) error {
+	f, err := eclaType.WrapGoFunction(prototype, fn)
+	if err != nil {
+		return fmt.Errorf("built-in function %s: %s", name, err)
+	}
+	if builtIn, ok := env.builtIns[name]; ok {
+		return builtIn.AddOverload(prototype, f)
+	}
+	if _, ok := env.Vars.Var[name]; ok {
+		return fmt.Errorf("%s is already declared", name)
+	}
+	builtIn := eclaType.NewFunctionBuiltIn(name, prototype, f)
+	if err := declareBuiltIn(env, name, builtIn); err != nil {
+		return err
+	}
+	env.builtIns[name] = builtIn
+	return nil
+}
+
+// declareBuiltIn declares the built-in function in the main scope of env.
+func declareBuiltIn(env *Env, name string, builtIn *eclaType.FunctionBuiltIn) error {
+	v, err := eclaType.NewVar(name, "", builtIn)
+	if err != nil {
+		return err
+	}
+	env.Vars.Var[name] = v
+	return nil
+}
+
 // Execute executes Env.Code or Env.File.
 func (env *Env) Execute() {
 	// catch all panics
@@ -145,33 +255,46 @@ func (env *Env) Execute() {
 				errorHandler.LevelFatal)
 		}
 	}()
+	defer env.startLimits()()
 
 	if env.File != "" {
 		var err error
-		env.Code, err = readFile(env.File)
+		env.Code, err = env.readFile(env.File)
 		if err != nil {
 			env.ErrorHandle.HandleError(0, 0, err.Error(), errorHandler.LevelFatal)
 		}
 	}
+	env.execute()
+}
+
+// execute lexes, parses and executes Env.Code.
+func (env *Env) execute() {
+	// the modules are loaded again by each run, they start from their initial state
+	env.modules = newModules()
+
 	// Lexing
 	env.Tokens = lexer.Lexer(env.Code)
 
 	// Parsing
-	pars := parser.Parser{Tokens: env.Tokens, ErrorHandler: env.ErrorHandle}
+	pars := parser.Parser{Tokens: env.Tokens, ErrorHandler: env.ErrorHandle, Output: env.Stderr()}
 	env.SyntaxTree = pars.Parse()
+	env.ErrorHandle.HandleErrors(pars.Errors())
+	env.checkImports()
 
 	// Execute
 	Run(env)
 }
 
 func (env *Env) ExecuteMetrics() met.Metrics {
+	defer env.startLimits()()
 	if env.File != "" {
 		var err error
-		env.Code, err = readFile(env.File)
+		env.Code, err = env.readFile(env.File)
 		if err != nil {
 			env.ErrorHandle.HandleError(0, 0, err.Error(), errorHandler.LevelFatal)
 		}
 	}
+	env.modules = newModules()
 	m := met.NewMetrics()
 	m.StartTimers()
 	// Lexing
@@ -181,8 +304,10 @@ func (env *Env) ExecuteMetrics() met.Metrics {
 
 	// Parsing
 	m.StartParserTimer()
-	pars := parser.Parser{Tokens: env.Tokens, ErrorHandler: env.ErrorHandle}
+	pars := parser.Parser{Tokens: env.Tokens, ErrorHandler: env.ErrorHandle, Output: env.Stderr()}
 	env.SyntaxTree = pars.Parse()
+	env.ErrorHandle.HandleErrors(pars.Errors())
+	env.checkImports()
 	m.StopParserTimer()
 
 	// Execute
@@ -196,7 +321,7 @@ func (env *Env) ExecuteMetrics() met.Metrics {
 // Load the file
 func (env *Env) Load() {
 	var err error
-	env.Code, err = readFile(env.File)
+	env.Code, err = env.readFile(env.File)
 	if err != nil {
 		env.ErrorHandle.HandleError(0, 0, err.Error(), errorHandler.LevelFatal)
 	}
@@ -204,48 +329,136 @@ func (env *Env) Load() {
 	env.Tokens = lexer.Lexer(env.Code)
 
 	// Parsing
-	pars := parser.Parser{Tokens: env.Tokens, ErrorHandler: env.ErrorHandle}
+	pars := parser.Parser{Tokens: env.Tokens, ErrorHandler: env.ErrorHandle, Output: env.Stderr()}
 	env.SyntaxTree = pars.Parse()
+	env.ErrorHandle.HandleErrors(pars.Errors())
+	env.checkImports()
 
 	Load(env)
 }
 
+// Call calls the function declared by the code with the given name, once loaded by Load or executed.
+// The arguments are Ecla values or Go values converted by eclaType.ToEcla, they select the prototype of the function.
+// The results are checked against the return types of the prototype, a function without return types has no results.
+// An error is returned instead of exiting the program when the function is not found, its arguments are incorrect
+// or a fatal error is raised by its execution.
+func (env *Env) Call(name string, args ...any) ([]eclaType.Type, error) {
+	v, ok := env.GetVar(name)
+	if !ok {
+		return nil, fmt.Errorf("function '%s' not found", name)
+	}
+	values := make([]eclaType.Type, len(args))
+	for i, arg := range args {
+		value, err := eclaType.ToEcla(arg, env.TypeDecl...)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d of %s: %s", i, name, err)
+		}
+		values[i] = value
+	}
+	var results []eclaType.Type
+	switch fn := v.Value.(type) {
+	case *eclaType.Function:
+		var returnTypes []string
+		var callErr error
+		err := env.protect(func() {
+			defer env.enterHost()()
+			results, returnTypes, callErr = runFunctionCall(name, env, fn, nil, values)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if callErr != nil {
+			return nil, callErr
+		}
+		if len(returnTypes) == 0 {
+			return nil, nil
+		}
+		if len(results) != len(returnTypes) {
+			return nil, fmt.Errorf("function %s returned %d values instead of %d", name, len(results), len(returnTypes))
+		}
+		for i, typ := range returnTypes {
+			if !eclaType.CheckReturnTypes(returnTypes[i:i+1], results[i:i+1], env.TypeDecl) {
+				return nil, fmt.Errorf("function %s returned %s instead of %s", name, results[i].GetType(), typ)
+			}
+		}
+	case *eclaType.FunctionBuiltIn:
+		var callErr error
+		err := env.protect(func() {
+			results, callErr = fn.Call(values)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if callErr != nil {
+			return nil, callErr
+		}
+	default:
+		return nil, fmt.Errorf("'%s' is not a function", name)
+	}
+	return results, nil
+}
+
 // Import executes an import statement.
 func (env *Env) Import(stmt parser.ImportStmt) {
 	file := stmt.ModulePath
-	temp := libs.Import(file)
+	if err := env.checkImport(file); err != nil {
+		env.ErrorHandle.HandleErrorAt(stmt, err.Error(), errorHandler.LevelFatal)
+	}
+	temp := env.importLib(file)
 	if temp == nil {
-		if !filepath.IsAbs(file) {
-			file = filepath.Join(filepath.Dir(env.File), stmt.ModulePath)
-		}
-		if _, err := os.Stat(file); os.IsNotExist(err) {
-			env.ErrorHandle.HandleError(stmt.StartLine(), stmt.StartPos(), fmt.Sprintf("module '%s' not found", file), errorHandler.LevelFatal)
+		file = env.modulePath(file)
+		if err := env.statFile(file); errors.Is(err, fs.ErrNotExist) {
+			env.ErrorHandle.HandleErrorAt(stmt, fmt.Sprintf("module '%s' not found", file), errorHandler.LevelFatal)
 		} else if err != nil {
-			env.ErrorHandle.HandleError(stmt.StartLine(), stmt.StartPos(), err.Error(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(stmt, err.Error(), errorHandler.LevelFatal)
 		}
-		tempsEnv := NewTemporaryEnv(env.ErrorHandle)
-		tempsEnv.SetFile(file)
-		tempsEnv.Load()
-
-		temp = tempsEnv.ConvertToLib(env)
+		module, err := env.loadModule(file, func(module *Env) {
+			// the code of the module is traced as called by the import statement
+			module.ExecutedFunc = append([]Call{}, env.ExecutedFunc...)
+			module.callSite = stmt
+			defer env.ErrorHandle.SetTracer(env.trace)
+			module.Load()
+		})
+		if err != nil {
+			env.ErrorHandle.HandleErrorAt(stmt, err.Error(), errorHandler.LevelFatal)
+			return
+		}
+		temp = module.ConvertToLib(env)
 	}
 	name := parser.GetPackageNameByPath(file)
 	env.Libs[name] = temp
 	v, err := eclaType.NewVar(name, "", eclaType.NewLib(name))
 	if err != nil {
-		env.ErrorHandle.HandleError(stmt.StartLine(), stmt.StartPos(), err.Error(), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(stmt, err.Error(), errorHandler.LevelFatal)
 	}
 	env.Vars.Set(name, v)
 }
 
+// Call is a call being executed.
+type Call struct {
+	// Function is the function called, nil for the code executed outside of any function
+	Function *eclaType.Function
+	Name     string
+	// File is the file in which the function is declared
+	File string
+	// Line and Col is the position of the call in the caller
+	Line int
+	Col  int
+}
+
 // AddFunctionExecuted adds a function to the pile of executed functions.
 func (env *Env) AddFunctionExecuted(f *eclaType.Function) {
-	env.ExecutedFunc = append(env.ExecutedFunc, f)
+	env.ExecutedFunc = append(env.ExecutedFunc, Call{Function: f, Name: f.Name})
 }
 
-// GetFunctionExecuted returns the last function executed.
+// GetFunctionExecuted returns the last function executed, nil outside of any function.
 func (env *Env) GetFunctionExecuted() *eclaType.Function {
-	return env.ExecutedFunc[len(env.ExecutedFunc)-1]
+	for i := len(env.ExecutedFunc) - 1; i >= 0; i-- {
+		if fn := env.ExecutedFunc[i].Function; fn != nil {
+			return fn
+		}
+	}
+	return nil
 }
 
 // RemoveFunctionExecuted removes the last function executed.
@@ -253,15 +466,198 @@ func (env *Env) RemoveFunctionExecuted() {
 	env.ExecutedFunc = env.ExecutedFunc[:len(env.ExecutedFunc)-1]
 }
 
+// pushCall pushes the call of fn with the given name at the position of env.callSite.
+// It returns the function that pops it.
+func (env *Env) pushCall(fn *eclaType.Function, name string) func() {
+	c := Call{Function: fn, Name: name, File: env.currentModule().File}
+	if env.callSite != nil {
+		c.Line, c.Col = env.callSite.StartLine(), env.callSite.StartPos()
+		env.callSite = nil
+	}
+	n := len(env.ExecutedFunc)
+	env.ExecutedFunc = append(env.ExecutedFunc, c)
+	return func() {
+		env.ExecutedFunc = env.ExecutedFunc[:n]
+	}
+}
+
+// enterMain starts the execution of the code outside of any function, the errors raised are traced from then on.
+// It returns the function that ends it.
+func (env *Env) enterMain() func() {
+	env.ErrorHandle.SetTracer(env.trace)
+	return env.pushCall(nil, "main")
+}
+
+// enterHost starts the execution of a function called from Go, the errors raised are traced from then on.
+// The function is traced as called by a <host> frame, which has no position in the code.
+// It returns the function that ends it.
+func (env *Env) enterHost() func() {
+	env.ErrorHandle.SetTracer(env.trace)
+	n := len(env.ExecutedFunc)
+	env.ExecutedFunc = append(env.ExecutedFunc, Call{Name: "<host>"})
+	return func() {
+		env.ExecutedFunc = env.ExecutedFunc[:n]
+	}
+}
+
+// enterCall records the call of fn with the given name at the position of env.callSite.
+// It returns the function that ends the call.
+func (env *Env) enterCall(fn *eclaType.Function, name string) func() {
+	// the depth is checked first, a call aborted is not pushed
+	env.enterDepth()
+	pop := env.pushCall(fn, name)
+	return func() {
+		env.exitDepth()
+		pop()
+	}
+}
+
+// trace returns the calls being executed, the most recent first, for an error raised at the given position.
+func (env *Env) trace(line, col int) []errorHandler.Frame {
+	if len(env.ExecutedFunc) == 0 {
+		return nil
+	}
+	frames := make([]errorHandler.Frame, 0, len(env.ExecutedFunc))
+	for i := len(env.ExecutedFunc) - 1; i >= 0; i-- {
+		c := env.ExecutedFunc[i]
+		frames = append(frames, errorHandler.Frame{Function: c.Name, File: c.File, Line: line, Col: col})
+		line, col = c.Line, c.Col
+	}
+	return frames
+}
+
+// closure is the context captured by an anonymous function or a method when it is created.
+type closure struct {
+	scope  *Scope
+	module *Env
+}
+
+// newClosure returns the context captured by a function created at the current point of the execution.
+func (env *Env) newClosure() *closure {
+	return &closure{
+		scope:  env.Vars.GetDeepestScope(),
+		module: env.currentModule(),
+	}
+}
+
+// moduleContext is the libs and the type declarations used to execute the code of a module.
+type moduleContext struct {
+	libs     map[string]libs.Lib
+	typeDecl []eclaDecl.TypeDecl
+}
+
+// currentModule returns the Env of the module whose code is executed.
+func (env *Env) currentModule() *Env {
+	if env.module != nil {
+		return env.module
+	}
+	return env
+}
+
+// enterModule switches env to the libs and the type declarations of the given module.
+// It returns the function that switches back to the previous module.
+func (env *Env) enterModule(module *Env) func() {
+	last := moduleContext{libs: env.Libs, typeDecl: env.TypeDecl}
+	lastModule := env.module
+	if module == env {
+		if env.module != nil {
+			env.Libs, env.TypeDecl = env.home.libs, env.home.typeDecl
+		}
+		env.module = nil
+	} else {
+		if env.module == nil {
+			env.home = last
+		}
+		env.Libs, env.TypeDecl = module.Libs, module.TypeDecl
+		env.module = module
+	}
+	return func() {
+		env.Libs, env.TypeDecl = last.libs, last.typeDecl
+		env.module = lastModule
+	}
+}
+
+// envState is a snapshot of the execution state of an Env taken when entering a try block.
+type envState struct {
+	scope        *Scope
+	libs         map[string]libs.Lib
+	typeDecl     []eclaDecl.TypeDecl
+	module       *Env
+	executedFunc int
+}
+
+// saveState returns a snapshot of the current execution state.
+func (env *Env) saveState() envState {
+	return envState{
+		scope:        env.Vars.GetDeepestScope(),
+		libs:         env.Libs,
+		typeDecl:     env.TypeDecl,
+		module:       env.module,
+		executedFunc: len(env.ExecutedFunc),
+	}
+}
+
+// restoreState unwinds the execution state back to the given snapshot after an error was caught.
+func (env *Env) restoreState(state envState) {
+	env.Vars.GoUpTo(state.scope)
+	env.Libs = state.libs
+	env.TypeDecl = state.typeDecl
+	env.module = state.module
+	env.ExecutedFunc = env.ExecutedFunc[:state.executedFunc]
+}
+
+// protect executes f with the fatal errors unwinding the execution instead of exiting the program.
+// The state of the environment is restored when a fatal error is raised, the fatal errors raised are returned as an *Error.
+func (env *Env) protect(f func()) (err error) {
+	if !env.ErrorHandle.Unwinding() {
+		env.ErrorHandle.Unwind()
+		defer env.ErrorHandle.StopUnwind()
+	}
+	defer env.startLimits()()
+	state := env.saveState()
+	start := len(env.ErrorHandle.Errors)
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		e := &Error{}
+		if a, ok := r.(abort); ok {
+			e.Cause = a.cause
+		} else if _, ok := r.(errorHandler.Error); !ok {
+			env.ErrorHandle.Collect(errorHandler.Error{
+				Msg:   fmt.Sprintf("an internal error occured please report it to the developers on https://github.com/Eclalang/Ecla/issues : %v", r),
+				Level: errorHandler.LevelFatal,
+			})
+		}
+		env.restoreState(state)
+		env.callSite = nil
+		for _, raised := range env.ErrorHandle.Errors[start:] {
+			if raised.Level == errorHandler.LevelFatal {
+				e.Errors = append(e.Errors, raised)
+			}
+		}
+		err = e
+	}()
+	f()
+	return nil
+}
+
 // envLib represents a library and that uses to compartiment the scope of the library and the scope of the main program.
 type envLib struct {
-	Var  *Scope
-	Libs map[string]libs.Lib
-	env  *Env
+	Var    *Scope
+	Libs   map[string]libs.Lib
+	env    *Env
+	module *Env
 }
 
 // Call calls the function with the given name and arguments.
 func (lib *envLib) Call(name string, args []eclaType.Type) ([]eclaType.Type, error) {
+	return lib.callFrom(lib.env, name, args)
+}
+
+// callFrom calls the function with the given name and arguments in the scopes of the Env executing the call.
+func (lib *envLib) callFrom(env *Env, name string, args []eclaType.Type) ([]eclaType.Type, error) {
 	function, ok := lib.Var.Get(name)
 	if !ok {
 		return nil, fmt.Errorf("function '%s' not found", name)
@@ -276,14 +672,12 @@ func (lib *envLib) Call(name string, args []eclaType.Type) ([]eclaType.Type, err
 
 	// TODO : Change this to more clean code
 
-	// Save the current libs
-	temps := lib.env.Libs
-	// Set the libs of the lib
-	lib.env.Libs = lib.Libs
+	// Set the libs and the type declarations of the lib
+	exitModule := env.enterModule(lib.module)
 	// Run the function
-	r1, r2 := RunFunctionCallExprWithArgs(name, lib.env, f, args)
-	// Restore the libs
-	lib.env.Libs = temps
+	r1, r2 := RunFunctionCallExprWithArgs(name, env, f, args)
+	// Restore the libs and the type declarations
+	exitModule()
 	return r1, r2
 }
 
@@ -294,9 +688,10 @@ func (lib *envLib) GetVar(name string) (*eclaType.Var, bool) {
 // ConvertToLib converts the Env to a Lib.
 func (env *Env) ConvertToLib(MainEnv *Env) libs.Lib {
 	return &envLib{
-		Var:  env.Vars,
-		Libs: env.Libs,
-		env:  MainEnv,
+		Var:    env.Vars,
+		Libs:   env.Libs,
+		env:    MainEnv,
+		module: env,
 	}
 }
 
@@ -313,6 +708,29 @@ func (env *Env) GetTypeDecl(name string) (eclaDecl.TypeDecl, bool) {
 	return nil, false
 }
 
+// readFile reads the file at the given path in the file system of env and returns its contents as a string.
+func (env *Env) readFile(file string) (string, error) {
+	if env.fsys == nil {
+		return readFile(file)
+	}
+	v, err := fs.ReadFile(env.fsys, file)
+	if err != nil {
+		return "", err
+	}
+	return string(v), nil
+}
+
+// statFile returns an error if the file cannot be found in the file system of env.
+func (env *Env) statFile(file string) error {
+	var err error
+	if env.fsys == nil {
+		_, err = os.Stat(file)
+	} else {
+		_, err = fs.Stat(env.fsys, file)
+	}
+	return err
+}
+
 // readFile reads the file at the given path and returns its contents as a string.
 func readFile(file string) (string, error) {
 	v, err := os.ReadFile(file)
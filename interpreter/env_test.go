@@ -1,10 +1,16 @@
 package interpreter
 
 import (
+	"errors"
+	"github.com/Eclalang/Ecla/errorHandler"
 	"github.com/Eclalang/Ecla/interpreter/eclaDecl"
 	"github.com/Eclalang/Ecla/interpreter/eclaType"
 	"github.com/Eclalang/Ecla/parser"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestEnv_NewEnv(t *testing.T) {
@@ -156,6 +162,25 @@ func TestEnv_Execute(t *testing.T) {
 	env.Execute()
 }
 
+func TestEnv_ExecuteSyntaxErrors(t *testing.T) {
+	env := NewEnv()
+	var exits int
+	env.ErrorHandle.HookExit(func(code int) {
+		if code == 1 {
+			exits++
+		}
+	})
+	defer env.ErrorHandle.RestoreExit()
+	env.SetCode("import \"console\";\nvar a int = ;\nvar b int = 1;\nvar c = 2;\n")
+	env.Execute()
+	if len(env.ErrorHandle.Errors) < 2 {
+		t.Errorf("Execute() did not report every syntax error, got %v", env.ErrorHandle.Errors)
+	}
+	if exits == 0 {
+		t.Errorf("Execute() did not exit on syntax errors")
+	}
+}
+
 func TestEnv_ExecuteFile(t *testing.T) {
 	env := NewEnv()
 	env.SetFile("../DEMO/AllTests.ecla")
@@ -240,3 +265,311 @@ func TestEnvLib_Call(t *testing.T) {
 		t.Error("Expected an error, got nil")
 	}
 }
+
+func TestEnv_Trace(t *testing.T) {
+	dir := t.TempDir()
+	module := filepath.Join(dir, "mod.ecla")
+	err := os.WriteFile(module, []byte(`function boom(x : int) (int) {
+	var l []int = [1];
+	return l[x];
+}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	main := filepath.Join(dir, "main.ecla")
+	err = os.WriteFile(main, []byte(`import "mod.ecla";
+
+function f(n : int) (int) {
+	return mod.boom(n);
+}
+
+var a int = f(0);
+try {
+	var b int = f(5);
+} catch (e) {
+}
+var c int = f(5);
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, bytecode := range []bool{false, true} {
+		env := NewEnv()
+		env.Bytecode = bytecode
+		env.ErrorHandle.HookExit(func(int) {})
+		env.SetFile(main)
+		env.Execute()
+		env.ErrorHandle.RestoreExit()
+
+		if len(env.ErrorHandle.Errors) == 0 {
+			t.Fatal("Expected an error, got none")
+		}
+		err := env.ErrorHandle.Errors[0]
+		expected := []errorHandler.Frame{
+			{Function: "boom", File: module, Line: 3, Col: 9},
+			{Function: "f", File: main, Line: 4, Col: 13},
+			{Function: "main", File: main, Line: 12, Col: 13},
+		}
+		if len(err.Trace) != len(expected) {
+			t.Fatalf("Expected %d frames, got %v", len(expected), err.Trace)
+		}
+		for i, frame := range expected {
+			if err.Trace[i] != frame {
+				t.Errorf("Expected frame %d to be %v, got %v", i, frame, err.Trace[i])
+			}
+		}
+	}
+}
+
+func TestEnv_RegisterBuiltIn(t *testing.T) {
+	env := NewEnv()
+	intProto := parser.FunctionPrototype{Parameters: []parser.FunctionParams{{Name: "n", Type: parser.Int}}, ReturnTypes: []string{parser.Int}}
+	strProto := parser.FunctionPrototype{Parameters: []parser.FunctionParams{{Name: "s", Type: parser.String}}, ReturnTypes: []string{parser.String}}
+	if err := env.RegisterBuiltIn("double", intProto, func(n int) int { return n * 2 }); err != nil {
+		t.Fatal(err)
+	}
+	err := env.RegisterBuiltIn("double", strProto, func(args []eclaType.Type) ([]eclaType.Type, error) {
+		return []eclaType.Type{args[0].(eclaType.String) + args[0].(eclaType.String)}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := env.RegisterBuiltIn("double", intProto, func(n int) int { return n }); err == nil {
+		t.Error("Expected an error for an existing prototype, got nil")
+	}
+	if err := env.RegisterBuiltIn("typeOf", intProto, func(n int) int { return n }); err == nil {
+		t.Error("Expected an error when overloading typeOf, got nil")
+	}
+	if err := env.RegisterBuiltIn("wrong", intProto, func(s string) int { return 0 }); err == nil {
+		t.Error("Expected an error for a function not matching the prototype, got nil")
+	}
+
+	for _, bytecode := range []bool{false, true} {
+		i := NewInterpreter(Options{Bytecode: bytecode})
+		i.Env().RegisterBuiltIn("double", intProto, func(n int) int { return n * 2 })
+		i.Env().RegisterBuiltIn("double", strProto, func(s string) string { return s + s })
+		err := i.RunString(`var a int = double(21);
+var b string = double("ab");
+var c string = typeOf(double);
+function apply(f : function(int)(int), n : int) (int) {
+	return f(n);
+}
+var d int = apply(double, 2);`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]string{"a": "a = 42", "b": "b = abab", "c": "c = function(int)(int)", "d": "d = 4"}
+		for name, value := range expected {
+			if v, ok := i.Env().GetVar(name); !ok || v.String() != value {
+				t.Errorf("Expected %s, got %v", value, v)
+			}
+		}
+		if err := i.RunString(`double(true);`); err == nil {
+			t.Error("Expected an error for incorrect arguments, got nil")
+		}
+	}
+}
+
+func TestEnv_RegisterBuiltInModule(t *testing.T) {
+	intProto := parser.FunctionPrototype{Parameters: []parser.FunctionParams{{Name: "n", Type: parser.Int}}, ReturnTypes: []string{parser.Int}}
+	fsys := fstest.MapFS{
+		"main.ecla": {Data: []byte(`import "m.ecla";
+var a int = m.quad(3);`)},
+		"m.ecla": {Data: []byte(`function quad(n : int) (int) { return double(double(n)); }`)},
+	}
+	for _, bytecode := range []bool{false, true} {
+		i := NewInterpreter(Options{Bytecode: bytecode, FS: fsys})
+		if err := i.Env().RegisterBuiltIn("double", intProto, func(n int) int { return n * 2 }); err != nil {
+			t.Fatal(err)
+		}
+		// the built-in functions registered from Go are declared in the modules imported by the code
+		if err := i.RunFile("main.ecla"); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		if v, ok := i.Env().GetVar("a"); !ok || v.String() != "a = 12" {
+			t.Errorf("Expected a = 12, got %v", v)
+		}
+		err := i.RunString(`function double(s : string) (string) { return s + s; }`)
+		if err == nil || !strings.Contains(err.Error(), "Cannot use built-in function name double as function name") {
+			t.Errorf("Expected an error when declaring a function named like a built-in function, got %v", err)
+		}
+	}
+}
+
+func TestEnv_Call(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "main.ecla")
+	err := os.WriteFile(file, []byte(`function add(a : int, b : int) (int) {
+	return a + b;
+}
+function add(a : string, b : string) (string) {
+	return a + b;
+}
+function sum(l : []int) (int) {
+	var total int = 0;
+	for (i, v range l) {
+		total += v;
+	}
+	return total;
+}
+function fail(n : int) {
+	var x int = n / 0;
+}
+var notFunction int = 1;
+interface Shape {
+	function area() (int);
+}
+struct Sq {
+	side : int;
+}
+function (s : Sq) area() (int) {
+	return s.side * s.side;
+}
+function mk(n : int) (Shape) {
+	return Sq{n};
+}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, bytecode := range []bool{false, true} {
+		env := NewEnv()
+		env.Bytecode = bytecode
+		env.SetFile(file)
+		env.Load()
+
+		// the prototype is selected by the arguments
+		if r, err := env.Call("add", 1, 2); err != nil || len(r) != 1 || r[0] != eclaType.Int(3) {
+			t.Errorf("Expected 3, got %v, %v", r, err)
+		}
+		if r, err := env.Call("add", "a", eclaType.String("b")); err != nil || len(r) != 1 || r[0] != eclaType.String("ab") {
+			t.Errorf("Expected ab, got %v, %v", r, err)
+		}
+		if r, err := env.Call("sum", []int{1, 2, 3}); err != nil || len(r) != 1 || r[0] != eclaType.Int(6) {
+			t.Errorf("Expected 6, got %v, %v", r, err)
+		}
+		if r, err := env.Call("typeOf", 1); err != nil || len(r) != 1 || r[0] != eclaType.String("int") {
+			t.Errorf("Expected int, got %v, %v", r, err)
+		}
+		// a value is returned as an interface it implements
+		if r, err := env.Call("mk", 2); err != nil || len(r) != 1 {
+			t.Errorf("Expected a Sq, got %v, %v", r, err)
+		}
+
+		for _, call := range []struct {
+			name string
+			args []any
+		}{
+			{"missing", nil},
+			{"notFunction", nil},
+			{"add", []any{1}},
+			{"add", []any{1, "a"}},
+			{"add", []any{make(chan int), 1}},
+		} {
+			if r, err := env.Call(call.name, call.args...); err == nil {
+				t.Errorf("%s%v: expected an error, got %v", call.name, call.args, r)
+			}
+		}
+
+		// a fatal error raised by the function is returned with its trace
+		_, err := env.Call("fail", 1)
+		var e *Error
+		if !errors.As(err, &e) || len(e.Errors) != 1 || e.Errors[0].Line != 15 {
+			t.Fatalf("Expected the error of line 15, got %v", err)
+		}
+		if len(e.Errors[0].Trace) != 2 || e.Errors[0].Trace[0].Function != "fail" {
+			t.Errorf("Expected the error to be raised in fail, got %v", e.Errors[0].Trace)
+		} else if host := e.Errors[0].Trace[1]; host.String() != "<host>" {
+			t.Errorf("Expected fail to be called by the host, got %v", host)
+		}
+		if env.Vars.GetDeepestScope() != env.Vars {
+			t.Error("Expected the scopes of the failed call to be ended")
+		}
+		if env.ErrorHandle.Unwinding() {
+			t.Error("Expected the fatal errors to exit the program again after the call")
+		}
+		if r, err := env.Call("add", 1, 1); err != nil || r[0] != eclaType.Int(2) {
+			t.Errorf("Expected 2, got %v, %v", r, err)
+		}
+
+		// a panic of a built-in function is returned as an error
+		proto := parser.FunctionPrototype{Parameters: []parser.FunctionParams{{Name: "n", Type: parser.Int}}, ReturnTypes: []string{parser.Int}}
+		if err := env.RegisterBuiltIn("crash", proto, func(n int) int { panic("crash") }); err != nil {
+			t.Fatal(err)
+		}
+		if r, err := env.Call("crash", 1); err == nil {
+			t.Errorf("Expected an error, got %v", r)
+		}
+	}
+}
+
+func TestEnv_ImportOnce(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"counter.ecla": `var count int = 0;
+function inc() {
+	count++;
+}`,
+		"user.ecla": `import "counter.ecla";
+function bump() {
+	counter.inc();
+}`,
+		"main.ecla": `import "counter.ecla";
+import "user.ecla";
+import "./counter.ecla";
+user.bump();
+counter.inc();
+var c int = counter.count;`,
+	}
+	for name, code := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, bytecode := range []bool{false, true} {
+		i := NewInterpreter(Options{Bytecode: bytecode})
+		if err := i.RunFile(filepath.Join(dir, "main.ecla")); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		// the module is loaded once, its variables are shared by the code importing it
+		if v, ok := i.Env().GetVar("c"); !ok || v.String() != "c = 2" {
+			t.Errorf("Expected c = 2, got %v", v)
+		}
+		if len(i.Env().modules.loaded) != 2 {
+			t.Errorf("Expected 2 modules loaded, got %d", len(i.Env().modules.loaded))
+		}
+	}
+}
+
+func TestEnv_ImportCycle(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.ecla":    `import "b.ecla";`,
+		"b.ecla":    `import "c.ecla";`,
+		"c.ecla":    `import "a.ecla";`,
+		"self.ecla": `import "self.ecla";`,
+	}
+	for name, code := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a, b, c := filepath.Join(dir, "a.ecla"), filepath.Join(dir, "b.ecla"), filepath.Join(dir, "c.ecla")
+	tests := []struct {
+		file  string
+		chain string
+	}{
+		{a, "import cycle: " + a + " -> " + b + " -> " + c + " -> " + a},
+		{filepath.Join(dir, "self.ecla"), "import cycle: " + filepath.Join(dir, "self.ecla") + " -> " + filepath.Join(dir, "self.ecla")},
+	}
+	for _, test := range tests {
+		i := NewInterpreter(Options{})
+		err := i.RunFile(test.file)
+		var e *Error
+		if !errors.As(err, &e) || len(e.Errors) != 1 || e.Errors[0].Msg != test.chain {
+			t.Errorf("Expected %q, got %v", test.chain, err)
+		}
+		if len(i.Env().modules.loading) != 0 {
+			t.Errorf("Expected no module to be loading after the error, got %v", i.Env().modules.loading)
+		}
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"github.com/Eclalang/Ecla/interpreter/eclaType"
 	"github.com/Eclalang/Ecla/lexer"
 	"github.com/Eclalang/Ecla/parser"
+	"strings"
 )
 
 // RunTree executes a parser.Node
 func RunTree(tree parser.Node, env *Env) []*Bus {
+	env.step(tree)
 	switch tree.(type) {
 	case parser.Literal:
 		return []*Bus{New(tree.(parser.Literal), env)}
@@ -42,18 +44,28 @@ func RunTree(tree parser.Node, env *Env) []*Bus {
 		return []*Bus{RunIndexableAccessExpr(tree.(parser.IndexableAccessExpr), env)}
 	case parser.MapLiteral:
 		return []*Bus{RunMapLiteral(tree.(parser.MapLiteral), env)}
+	case parser.InterpolatedStringExpr:
+		return []*Bus{RunInterpolatedStringExpr(tree.(parser.InterpolatedStringExpr), env)}
 	case parser.ReturnStmt:
 		r := RunReturnStmt(tree.(parser.ReturnStmt), env)
 		fn := env.GetFunctionExecuted()
 		ok := fn.CheckReturn(r, env.TypeDecl)
 		if !ok {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "Return type of function "+fn.Name+" is incorrect", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, "Return type of function "+fn.Name+" is incorrect", errorHandler.LevelFatal)
 		}
 		var temp []*Bus
 		for _, v := range r {
 			temp = append(temp, NewReturnBus(v))
 		}
 		return temp
+	case parser.BreakStmt:
+		return []*Bus{RunBreakStmt(tree.(parser.BreakStmt), env)}
+	case parser.ContinueStmt:
+		return []*Bus{RunContinueStmt(tree.(parser.ContinueStmt), env)}
+	case parser.TryStmt:
+		return []*Bus{RunTryStmt(tree.(parser.TryStmt), env)}
+	case parser.ThrowStmt:
+		RunThrowStmt(tree.(parser.ThrowStmt), env)
 	case parser.MurlocStmt:
 		RunMurlocStmt(tree.(parser.MurlocStmt), env)
 	case parser.AnonymousFunctionExpr:
@@ -64,12 +76,14 @@ func RunTree(tree parser.Node, env *Env) []*Bus {
 		return RunAnonymousFunctionCallExpr(tree.(parser.AnonymousFunctionCallExpr), env)
 	case parser.StructDecl:
 		RunStructDecl(tree.(parser.StructDecl), env)
+	case parser.InterfaceDecl:
+		RunInterfaceDecl(tree.(parser.InterfaceDecl), env)
 	case parser.SelectorExpr:
 		return RunSelectorExpr(tree.(parser.SelectorExpr), env, nil)
 	case parser.StructInstantiationExpr:
 		return RunStructInstantiationExpr(tree.(parser.StructInstantiationExpr), env)
 	default:
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Not implemented : %T\n", tree), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Not implemented : %T\n", tree), errorHandler.LevelFatal)
 	}
 
 	return []*Bus{NewNoneBus()}
@@ -77,6 +91,8 @@ func RunTree(tree parser.Node, env *Env) []*Bus {
 
 func RunAnonymousFunctionExpr(AnonymousFunc parser.AnonymousFunctionExpr, env *Env) []*Bus {
 	fn := eclaType.NewAnonymousFunction(AnonymousFunc.Prototype.Parameters, AnonymousFunc.Body, AnonymousFunc.Prototype.ReturnTypes)
+	// capture the scope in which the function is created, its variables are shared with the function
+	fn.SetClosure(env.newClosure())
 	returnBus := []*Bus{NewMainBus(fn)}
 	return returnBus
 }
@@ -92,6 +108,8 @@ func RunTreeLoad(tree parser.Node, env *Env) []*Bus {
 		RunImportStmt(tree.(parser.ImportStmt), env)
 	case parser.StructDecl:
 		RunStructDecl(tree.(parser.StructDecl), env)
+	case parser.InterfaceDecl:
+		RunInterfaceDecl(tree.(parser.InterfaceDecl), env)
 	}
 	return []*Bus{NewNoneBus()}
 }
@@ -100,12 +118,12 @@ func RunTreeLoad(tree parser.Node, env *Env) []*Bus {
 func RunBinaryExpr(tree parser.BinaryExpr, env *Env) *Bus {
 	BusCollection := RunTree(tree.LeftExpr, env)
 	if IsMultipleBus(BusCollection) {
-		env.ErrorHandle.HandleError(tree.LeftExpr.StartLine(), tree.LeftExpr.StartPos(), "MULTIPLE BUS IN RunBinaryExpr.\nPlease open issue", errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree.LeftExpr, "MULTIPLE BUS IN RunBinaryExpr.\nPlease open issue", errorHandler.LevelFatal)
 	}
 	left := BusCollection[0].GetVal()
 	BusCollection = RunTree(tree.RightExpr, env)
 	if IsMultipleBus(BusCollection) {
-		env.ErrorHandle.HandleError(tree.RightExpr.StartLine(), tree.RightExpr.StartPos(), "MULTIPLE BUS IN RunBinaryExpr.\nPlease open issue", errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree.RightExpr, "MULTIPLE BUS IN RunBinaryExpr.\nPlease open issue", errorHandler.LevelFatal)
 	}
 	right := BusCollection[0].GetVal()
 	var t eclaType.Type
@@ -145,7 +163,7 @@ func RunBinaryExpr(tree parser.BinaryExpr, env *Env) *Bus {
 		return NewNoneBus()
 	}
 	if err != nil {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 	}
 	return NewMainBus(t)
 }
@@ -154,13 +172,13 @@ func RunBinaryExpr(tree parser.BinaryExpr, env *Env) *Bus {
 func RunUnaryExpr(tree parser.UnaryExpr, env *Env) *Bus {
 	BusCollection := RunTree(tree.RightExpr, env)
 	if IsMultipleBus(BusCollection) {
-		env.ErrorHandle.HandleError(tree.RightExpr.StartLine(), tree.RightExpr.StartPos(), "MULTIPLE BUS IN RunUnaryExpr.\nPlease open issue", errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree.RightExpr, "MULTIPLE BUS IN RunUnaryExpr.\nPlease open issue", errorHandler.LevelFatal)
 	}
 	switch tree.Operator.TokenType {
 	case lexer.SUB:
 		t, err := eclaType.Int(0).Sub(BusCollection[0].GetVal()) // TODO: Fix this
 		if err != nil {
-			env.ErrorHandle.HandleError(tree.RightExpr.StartLine(), tree.RightExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree.RightExpr, err.Error(), errorHandler.LevelFatal)
 		}
 		return NewMainBus(t)
 	case lexer.ADD:
@@ -168,13 +186,35 @@ func RunUnaryExpr(tree parser.UnaryExpr, env *Env) *Bus {
 	case lexer.NOT:
 		t, err := BusCollection[0].GetVal().Not()
 		if err != nil {
-			env.ErrorHandle.HandleError(tree.RightExpr.StartLine(), tree.RightExpr.StartPos(), err.Error(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree.RightExpr, err.Error(), errorHandler.LevelFatal)
 		}
 		return NewMainBus(t)
 	}
 	return NewNoneBus()
 }
 
+// RunInterpolatedStringExpr executes a parser.InterpolatedStringExpr.
+func RunInterpolatedStringExpr(tree parser.InterpolatedStringExpr, env *Env) *Bus {
+	values := make([]eclaType.Type, len(tree.Parts))
+	for i, part := range tree.Parts {
+		BusCollection := RunTree(part, env)
+		if len(BusCollection) != 1 {
+			env.ErrorHandle.HandleErrorAt(part, "MULTIPLE BUS IN RunInterpolatedStringExpr.\nPlease open issue", errorHandler.LevelFatal)
+		}
+		values[i] = BusCollection[0].GetVal()
+	}
+	return NewMainBus(interpolate(values))
+}
+
+// interpolate returns the string joining the strings of the values, given by their GetString.
+func interpolate(values []eclaType.Type) eclaType.String {
+	var str strings.Builder
+	for _, value := range values {
+		str.WriteString(string(value.GetString()))
+	}
+	return eclaType.String(str.String())
+}
+
 // RunFunctionCallExpr executes a parser.FunctionCallExpr.
 func RunFunctionCallExpr(tree parser.FunctionCallExpr, env *Env) []*Bus {
 	var args []eclaType.Type
@@ -190,9 +230,14 @@ func RunFunctionCallExpr(tree parser.FunctionCallExpr, env *Env) []*Bus {
 		}
 
 	}
-	v, ok := env.GetVar(tree.Name)
+	return callFunctionExpr(tree, args, env)
+}
+
+// callFunctionExpr calls the function of a parser.FunctionCallExpr with its already evaluated arguments.
+func callFunctionExpr(tree parser.FunctionCallExpr, args []eclaType.Type, env *Env) []*Bus {
+	v, ok := env.getVar(tree.Name, tree.Binding)
 	if !ok {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Function %s not found", tree.Name), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Function %s not found", tree.Name), errorHandler.LevelFatal)
 	}
 	var fn *eclaType.Function
 	if v.IsFunction() {
@@ -201,19 +246,20 @@ func RunFunctionCallExpr(tree parser.FunctionCallExpr, env *Env) []*Bus {
 	var r []eclaType.Type
 	var err error
 	if fn != nil {
+		env.callSite = tree
 		r, err = RunFunctionCallExprWithArgs(tree.Name, env, fn, args)
 		if err != nil {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 		}
 	} else {
 		switch v.Value.(type) {
 		case *eclaType.FunctionBuiltIn:
 			r, err = v.Value.(*eclaType.FunctionBuiltIn).Call(args)
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
 		default:
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Function %s not found", tree.Name), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Function %s not found", tree.Name), errorHandler.LevelFatal)
 		}
 	}
 
@@ -226,23 +272,57 @@ func RunFunctionCallExpr(tree parser.FunctionCallExpr, env *Env) []*Bus {
 
 // RunFunctionCallExprWithArgs executes a parser.FunctionCallExpr with the given arguments.
 func RunFunctionCallExprWithArgs(Name string, env *Env, fn *eclaType.Function, args []eclaType.Type) ([]eclaType.Type, error) {
-	env.NewScope(SCOPE_FUNCTION)
+	r, _, err := runFunctionCall(Name, env, fn, nil, args)
+	return r, err
+}
+
+// RunMethodCallExprWithArgs executes a method with the given receiver and arguments.
+func RunMethodCallExprWithArgs(Name string, env *Env, fn *eclaType.Function, receiver *eclaType.Struct, args []eclaType.Type) ([]eclaType.Type, error) {
+	r, _, err := runFunctionCall(Name, env, fn, receiver, args)
+	return r, err
+}
+
+// runFunctionCall executes a function in a new scope, binding the receiver when the function is a method.
+// It returns the results and the return types of the prototype matching the arguments.
+func runFunctionCall(Name string, env *Env, fn *eclaType.Function, receiver *eclaType.Struct, args []eclaType.Type) ([]eclaType.Type, []string, error) {
+	if c, ok := fn.GetClosure().(*closure); ok {
+		env.NewClosureScope(c.scope)
+		defer env.enterModule(c.module)()
+	} else {
+		env.NewScope(SCOPE_FUNCTION)
+	}
 	defer env.EndScope()
+	defer env.enterCall(fn, Name)()
 	ok, argsList := fn.TypeAndNumberOfArgsIsCorrect(args, env.TypeDecl)
 	if !ok {
-		return nil, fmt.Errorf("function %s called with incorrect arguments", Name)
+		if err := fn.CheckInterfaces(args, env.TypeDecl); err != nil {
+			return nil, nil, fmt.Errorf("function %s called with incorrect arguments: %s", Name, err)
+		}
+		return nil, nil, fmt.Errorf("function %s called with incorrect arguments", Name)
 	}
-	for i, v := range argsList {
-		env.SetVar(i, v)
+	params, returnTypes := fn.GetParams(), fn.GetReturn()
+	for i, param := range params {
+		env.Vars.SetSlot(i, argsList[param.Name])
 	}
-	env.AddFunctionExecuted(fn)
-	defer env.RemoveFunctionExecuted()
-
-	return RunBodyFunction(fn, env)
+	if receiver != nil {
+		v, err := eclaType.NewVar(fn.Receiver, receiver.GetType(), receiver)
+		if err != nil {
+			return nil, nil, err
+		}
+		env.Vars.SetSlot(len(params), v)
+	}
+	r, err := RunBodyFunction(fn, env)
+	return r, returnTypes, err
 }
 
 // RunBodyFunction executes the code associated with the function.
 func RunBodyFunction(fn *eclaType.Function, env *Env) ([]eclaType.Type, error) {
+	if env.Bytecode {
+		if values, returned := RunBytecode(fn.GetBody(), env); returned {
+			return values, nil
+		}
+		return []eclaType.Type{eclaType.Null{}}, nil
+	}
 	for _, v := range fn.GetBody() {
 		BusCollection := RunTree(v, env)
 		if IsMultipleBus(BusCollection) {
@@ -268,21 +348,21 @@ func RunBodyFunction(fn *eclaType.Function, env *Env) ([]eclaType.Type, error) {
 
 // RunIndexableAccessExpr executes a parser.IndexableAccessExpr.
 func RunIndexableAccessExpr(tree parser.IndexableAccessExpr, env *Env) *Bus {
-	v, ok := env.GetVar(tree.VariableName)
+	v, ok := env.getVar(tree.VariableName, tree.Binding)
 	if !ok {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), fmt.Sprintf("Variable %s not found", tree.VariableName), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, fmt.Sprintf("Variable %s not found", tree.VariableName), errorHandler.LevelFatal)
 	}
 	var result eclaType.Type = v
 	for i := range tree.Indexes {
 		BusCollection := RunTree(tree.Indexes[i], env)
 		if IsMultipleBus(BusCollection) {
-			env.ErrorHandle.HandleError(tree.Indexes[i].StartLine(), tree.Indexes[i].StartPos(), "MULTIPLE BUS IN RunIndexableAccessExpr.\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree.Indexes[i], "MULTIPLE BUS IN RunIndexableAccessExpr.\nPlease open issue", errorHandler.LevelFatal)
 		}
 		elem := BusCollection[0].GetVal()
 		temp, err := result.GetIndex(elem)
 
 		if err != nil {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 		}
 		result = *temp
 
@@ -293,14 +373,14 @@ func RunIndexableAccessExpr(tree parser.IndexableAccessExpr, env *Env) *Bus {
 func RunAnonymousFunctionCallExpr(tree parser.AnonymousFunctionCallExpr, env *Env) []*Bus {
 	fn := RunTree(tree.AnonymousFunction, env)
 	if IsMultipleBus(fn) {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunAnonymousFunctionCallExpr.\nPlease open issue", errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunAnonymousFunctionCallExpr.\nPlease open issue", errorHandler.LevelFatal)
 	}
 	var f *eclaType.Function
 	switch fn[0].GetVal().(type) {
 	case *eclaType.Function:
 		f = fn[0].GetVal().(*eclaType.Function)
 	default:
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "Cannot call a non-function", errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, "Cannot call a non-function", errorHandler.LevelFatal)
 	}
 	var args []eclaType.Type
 	for _, v := range tree.Args {
@@ -314,9 +394,10 @@ func RunAnonymousFunctionCallExpr(tree parser.AnonymousFunctionCallExpr, env *En
 			args = append(args, temp)
 		}
 	}
+	env.callSite = tree
 	r, err := RunFunctionCallExprWithArgs("anonymous function", env, f, args)
 	if err != nil {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 	}
 	var retValues []*Bus
 	for _, v := range r {
@@ -326,11 +407,33 @@ func RunAnonymousFunctionCallExpr(tree parser.AnonymousFunctionCallExpr, env *En
 	return retValues
 }
 
+// RunStructFunctionCall calls the method of the struct with the given name,
+// or the function stored in the field with this name if the struct has no such method.
+func RunStructFunctionCall(tree parser.FunctionCallExpr, s *eclaType.Struct, args []eclaType.Type, env *Env) ([]eclaType.Type, error) {
+	if method, ok := s.Definition.GetMethod(tree.Name); ok {
+		env.callSite = tree
+		return RunMethodCallExprWithArgs(tree.Name, env, method.(*eclaType.Function), s, args)
+	}
+	fn, ok := s.Fields[tree.Name]
+	if !ok {
+		return nil, fmt.Errorf("field %s does not exist", tree.Name)
+	}
+	foo, ok := (*fn).(*eclaType.Function)
+	if !ok {
+		return nil, fmt.Errorf("field %s is not a function", tree.Name)
+	}
+	env.callSite = tree
+	return RunFunctionCallExprWithArgs(tree.Name, env, foo, args)
+}
+
 func RunBlockScopeStmt(tree parser.BlockScopeStmt, env *Env) []*Bus {
 	env.NewScope(SCOPE_MAIN)
 	defer env.EndScope()
 	for _, v := range tree.Body {
-		RunTree(v, env)
+		BusCollection := RunTree(v, env)
+		if len(BusCollection) > 0 && BusCollection[0].IsControlFlow() {
+			return BusCollection
+		}
 	}
 	return []*Bus{NewNoneBus()}
 }
@@ -340,7 +443,7 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 	if Struct == nil {
 		expr1 := RunTree(expr.Expr, env)
 		if IsMultipleBus(expr1) {
-			env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), "MULTIPLE BUS IN RunSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(expr, "MULTIPLE BUS IN RunSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
 		}
 
 		switch expr1[0].GetVal().(type) {
@@ -350,6 +453,11 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 			prev = expr1[0].GetVal()
 		}
 	}
+	// the fields and the methods of a value typed by an interface are the ones of its struct
+	switch prev.(type) {
+	case *eclaType.Interface:
+		prev = prev.(*eclaType.Interface).Value
+	}
 
 	switch prev.(type) {
 	case *eclaType.Lib:
@@ -371,14 +479,19 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 				}
 			}
 			var returnBuses []*Bus
+			var result []eclaType.Type
+			var err error
 			switch lib.(type) {
 			case *envLib:
 				env.SetScope(lib.(*envLib).Var)
-				env.Libs = lib.(*envLib).Libs
+				defer env.enterModule(lib.(*envLib).module)()
+				env.callSite = expr.Sel
+				result, err = lib.(*envLib).callFrom(env, expr.Sel.(parser.FunctionCallExpr).Name, args)
+			default:
+				result, err = lib.Call(expr.Sel.(parser.FunctionCallExpr).Name, args)
 			}
-			result, err := lib.Call(expr.Sel.(parser.FunctionCallExpr).Name, args)
 			if err != nil {
-				env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(expr, err.Error(), errorHandler.LevelFatal)
 			}
 			for _, elem := range result {
 				returnBuses = append(returnBuses, NewMainBus(elem))
@@ -393,7 +506,7 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 			if sel.Type == "VAR" { //TODO don't hard code "VAR"
 				v, ok := lib.(*envLib).GetVar(sel.Value)
 				if !ok {
-					env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), "variable "+sel.Value+" does not exist", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(expr, "variable "+sel.Value+" does not exist", errorHandler.LevelFatal)
 				}
 				return []*Bus{NewMainBus(v)}
 			}
@@ -402,11 +515,11 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 			switch lib.(type) {
 			case *envLib:
 				env.SetScope(lib.(*envLib).Var)
-				env.Libs = lib.(*envLib).Libs
+				defer env.enterModule(lib.(*envLib).module)()
 			}
 			expr := RunTree(sel.Expr, env)
 			if IsMultipleBus(expr) {
-				env.ErrorHandle.HandleError(sel.StartLine(), sel.StartPos(), "MULTIPLE BUS IN RunSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(sel, "MULTIPLE BUS IN RunSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
 			}
 			var returnBuses []*Bus
 			switch expr[0].GetVal().(type) {
@@ -416,7 +529,7 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 				case *eclaType.Struct:
 					returnBuses = RunSelectorExpr(sel, env, val.(*eclaType.Struct))
 				default:
-					env.ErrorHandle.HandleError(sel.StartLine(), sel.StartPos(), "cannot use "+prev.String()+" here", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(sel, "cannot use "+prev.String()+" here", errorHandler.LevelFatal)
 				}
 			}
 			switch lib.(type) {
@@ -425,7 +538,7 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 			}
 			return returnBuses
 		default:
-			env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), "cannot use "+prev.String()+" here", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(expr, "cannot use "+prev.String()+" here", errorHandler.LevelFatal)
 		}
 	case *eclaType.Struct:
 		switch expr.Sel.(type) {
@@ -435,7 +548,7 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 				s := prev.(*eclaType.Struct)
 				result, ok := s.Fields[sel.Value]
 				if !ok {
-					env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), "field "+sel.Value+" does not exist", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(expr, "field "+sel.Value+" does not exist", errorHandler.LevelFatal)
 				}
 				return []*Bus{NewMainBus(*result)}
 			}
@@ -454,18 +567,9 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 				}
 			}
 
-			fn, ok := prev.(*eclaType.Struct).Fields[tree.Name]
-			if !ok {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "field "+tree.Name+" does not exist", errorHandler.LevelFatal)
-			}
-			var foo *eclaType.Function
-			switch (*fn).(type) {
-			case *eclaType.Function:
-				foo = (*fn).(*eclaType.Function)
-			}
-			r, err := RunFunctionCallExprWithArgs(tree.Name, env, foo, args)
+			r, err := RunStructFunctionCall(tree, prev.(*eclaType.Struct), args, env)
 			if err != nil {
-				env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 			}
 			var retValues []*Bus
 			for _, v := range r {
@@ -482,7 +586,7 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 					s := prev.(*eclaType.Struct)
 					result, ok := s.Fields[sel.Value]
 					if !ok {
-						env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), "field "+sel.Value+" does not exist", errorHandler.LevelFatal)
+						env.ErrorHandle.HandleErrorAt(expr, "field "+sel.Value+" does not exist", errorHandler.LevelFatal)
 					}
 					prev = *result
 				}
@@ -501,18 +605,9 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 					}
 				}
 
-				fn, ok := prev.(*eclaType.Struct).Fields[tree.Name]
-				if !ok {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "field "+tree.Name+" does not exist", errorHandler.LevelFatal)
-				}
-				var foo *eclaType.Function
-				switch (*fn).(type) {
-				case *eclaType.Function:
-					foo = (*fn).(*eclaType.Function)
-				}
-				r, err := RunFunctionCallExprWithArgs(tree.Name, env, foo, args)
+				r, err := RunStructFunctionCall(tree, prev.(*eclaType.Struct), args, env)
 				if err != nil {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 				}
 				var retValues []*Bus
 				for _, v := range r {
@@ -521,19 +616,19 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 				if len(retValues) == 1 {
 					prev = retValues[0].GetVal()
 				} else {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN RunSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN RunSelectorExpr.\nPlease open issue", errorHandler.LevelFatal)
 				}
 			case parser.IndexableAccessExpr:
 				tree := sel.Expr.(parser.IndexableAccessExpr)
 				s := prev.(*eclaType.Struct)
 				result, ok := s.Fields[tree.VariableName]
 				if !ok {
-					env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), "field "+tree.VariableName+" does not exist", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(expr, "field "+tree.VariableName+" does not exist", errorHandler.LevelFatal)
 				}
 				for i := range tree.Indexes {
 					BusCollection := RunTree(tree.Indexes[i], env)
 					if IsMultipleBus(BusCollection) {
-						env.ErrorHandle.HandleError(tree.Indexes[i].StartLine(), tree.Indexes[i].StartPos(), "MULTIPLE BUS IN RunIndexableAccessExpr\nPlease open issue", errorHandler.LevelFatal)
+						env.ErrorHandle.HandleErrorAt(tree.Indexes[i], "MULTIPLE BUS IN RunIndexableAccessExpr\nPlease open issue", errorHandler.LevelFatal)
 					}
 					elem := BusCollection[0].GetVal()
 					temp, err := (*result).GetIndex(elem)
@@ -541,12 +636,12 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 					result = temp
 
 					if err != nil {
-						env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+						env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 					}
 				}
 				prev = *result
 			default:
-				fmt.Printf("%T\n", expr.Sel)
+				fmt.Fprintf(env.Stderr(), "%T\n", expr.Sel)
 			}
 			return RunSelectorExpr(sel, env, prev)
 		case parser.IndexableAccessExpr:
@@ -554,12 +649,12 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 			s := prev.(*eclaType.Struct)
 			result, ok := s.Fields[tree.VariableName]
 			if !ok {
-				env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), "field "+tree.VariableName+" does not exist", errorHandler.LevelFatal)
+				env.ErrorHandle.HandleErrorAt(expr, "field "+tree.VariableName+" does not exist", errorHandler.LevelFatal)
 			}
 			for i := range tree.Indexes {
 				BusCollection := RunTree(tree.Indexes[i], env)
 				if IsMultipleBus(BusCollection) {
-					env.ErrorHandle.HandleError(tree.Indexes[i].StartLine(), tree.Indexes[i].StartPos(), "MULTIPLE BUS IN RunIndexableAccessExpr\nPlease open issue", errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree.Indexes[i], "MULTIPLE BUS IN RunIndexableAccessExpr\nPlease open issue", errorHandler.LevelFatal)
 				}
 				elem := BusCollection[0].GetVal()
 				temp, err := (*result).GetIndex(elem)
@@ -567,15 +662,15 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 				result = temp
 
 				if err != nil {
-					env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+					env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 				}
 			}
 			return []*Bus{NewMainBus(*result)}
 		default:
-			env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), "struct cannot have field of type "+prev.GetType(), errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(expr, "struct cannot have field of type "+prev.GetType(), errorHandler.LevelFatal)
 		}
 	default:
-		env.ErrorHandle.HandleError(expr.StartLine(), expr.StartPos(), "type "+prev.GetType()+" has no fields", errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(expr, "type "+prev.GetType()+" has no fields", errorHandler.LevelFatal)
 	}
 	return []*Bus{NewNoneBus()}
 }
@@ -583,14 +678,14 @@ func RunSelectorExpr(expr parser.SelectorExpr, env *Env, Struct eclaType.Type) [
 func RunStructInstantiationExpr(tree parser.StructInstantiationExpr, env *Env) []*Bus {
 	decl, ok := env.GetTypeDecl(tree.Name)
 	if !ok {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "unknown type: "+tree.Name, errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, "unknown type: "+tree.Name, errorHandler.LevelFatal)
 	}
 	s := eclaType.NewStruct(decl.(*eclaDecl.StructDecl))
 	s.SetType(tree.Name)
 	for i, arg := range tree.Args {
 		val := RunTree(arg, env)
 		if IsMultipleBus(val) {
-			env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), "MULTIPLE BUS IN StructInstantiationExpr.\nPlease open issue", errorHandler.LevelFatal)
+			env.ErrorHandle.HandleErrorAt(tree, "MULTIPLE BUS IN StructInstantiationExpr.\nPlease open issue", errorHandler.LevelFatal)
 		}
 		temp := val[0].GetVal()
 		switch temp.(type) {
@@ -601,7 +696,7 @@ func RunStructInstantiationExpr(tree parser.StructInstantiationExpr, env *Env) [
 	}
 	err := s.Verify()
 	if err != nil {
-		env.ErrorHandle.HandleError(tree.StartLine(), tree.StartPos(), err.Error(), errorHandler.LevelFatal)
+		env.ErrorHandle.HandleErrorAt(tree, err.Error(), errorHandler.LevelFatal)
 	}
 	return []*Bus{NewMainBus(s)}
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/Eclalang/Ecla/interpreter/eclaType"
 	"github.com/Eclalang/Ecla/lexer"
 	"github.com/Eclalang/Ecla/parser"
+	"strings"
 	"testing"
 )
 
@@ -493,6 +494,199 @@ func Test_RunAnonymousFunctionExpr(t *testing.T) {
 	if bus == nil {
 		t.Error("Expected bus to be non-nil")
 	}
+	if bus[0].GetVal().(*eclaType.Function).GetClosure().(*closure).scope != env.Vars {
+		t.Error("Expected the anonymous function to capture the current scope")
+	}
+}
+
+func Test_RunAnonymousFunctionClosure(t *testing.T) {
+	env := NewEnv()
+	env.SetCode(`
+	function makeCounter() (function()(int)) {
+		var count int = 0;
+		return function() (int) {
+			count++;
+			return count;
+		};
+	}
+	c1 := makeCounter();
+	c2 := makeCounter();
+	c1();
+	var first int = c1();
+	var second int = c2();
+	function adder(n : int) (function(int)(int)) {
+		return function(x : int) (int) {
+			return x + n;
+		};
+	}
+	function apply(f : function(int)(int), v : int) (int) {
+		var n int = 100;
+		return f(v);
+	}
+	var added int = apply(adder(5), 1);
+	var total int = 0;
+	acc := function(x : int) {
+		total += x;
+	};
+	acc(3);
+	acc(4);
+	`)
+	env.Execute()
+
+	checkVars(t, env, map[string]string{"first": "2", "second": "1", "added": "6", "total": "7"})
+}
+
+func Test_RunMethodCall(t *testing.T) {
+	env := NewEnv()
+	env.SetCode(`
+	import "../DEMO/Test/structMethodutils.ecla";
+	struct Point {
+		x : int;
+		y : int;
+		function norm2() (int) {
+			return self.x * self.x + self.y * self.y;
+		}
+	}
+	function (p : Point) move(dx : int, dy : int) {
+		p.x += dx;
+		p.y += dy;
+	}
+	function (p : Point) twice() (int) {
+		return p.norm2() * 2;
+	}
+	var p Point = Point{3, 4};
+	var norm int = p.norm2();
+	p.move(1, 1);
+	var moved int = p.x + p.y;
+	var twice int = p.twice();
+	v := structMethodutils.newVec(2, 3);
+	v.scale(2);
+	var sum int = v.sum();
+	var described string = v.describe();
+	`)
+	env.Execute()
+
+	checkVars(t, env, map[string]string{"norm": "25", "moved": "9", "twice": "82", "sum": "10", "described": "Vec(4, 6)"})
+}
+
+func Test_RunMethodCallErrors(t *testing.T) {
+	codes := []string{
+		// method on an unknown struct
+		`function (p : Point) move() {}`,
+		// method with the name of a field
+		`struct Point {x : int;}
+		function (p : Point) x() {}`,
+		// method declared twice
+		`struct Point {x : int;}
+		function (p : Point) move() {}
+		function (p : Point) move() {}`,
+		// call of an undeclared method
+		`struct Point {x : int;}
+		var p Point = Point{1};
+		p.move();`,
+	}
+	for _, code := range codes {
+		exited := false
+		env := NewEnv()
+		env.ErrorHandle.HookExit(func(int) {
+			exited = true
+		})
+		env.SetCode(code)
+		env.Execute()
+		if !exited {
+			t.Errorf("Expected an error for %s", code)
+		}
+		env.ErrorHandle.RestoreExit()
+	}
+}
+
+func Test_RunInterface(t *testing.T) {
+	env := NewEnv()
+	env.SetCode(`
+	interface Shape {
+		name : string;
+		function area() (int);
+	}
+	struct Rect {
+		name : string;
+		w : int;
+		h : int;
+		function area() (int) {
+			return self.w * self.h;
+		}
+	}
+	struct Square {
+		name : string;
+		side : int;
+	}
+	function (s : Square) area() (int) {
+		return s.side * s.side;
+	}
+	function describe(s : Shape) (string) {
+		return s.name + " " + s.area();
+	}
+	function biggest(a : Shape, b : Shape) (Shape) {
+		if (a.area() > b.area()) {
+			return a;
+		}
+		return b;
+	}
+	var r Rect = Rect{"rect", 2, 3};
+	var sq Square = Square{"square", 4};
+	var rect string = describe(r);
+	var square string = describe(sq);
+	var s Shape = r;
+	var first int = s.area();
+	s = sq;
+	var second int = s.area();
+	var big Shape = biggest(r, sq);
+	var bigName string = big.name;
+	`)
+	env.Execute()
+
+	checkVars(t, env, map[string]string{"rect": "rect 6", "square": "square 16", "first": "6", "second": "16", "bigName": "square"})
+	if v, _ := env.GetVar("s"); v.GetType() != "Shape" {
+		t.Errorf("Expected s to be typed by Shape, got %s", v.GetType())
+	}
+}
+
+func Test_RunInterfaceErrors(t *testing.T) {
+	decls := `
+	interface Shape {
+		function area() (int);
+	}
+	struct Point {
+		x : int;
+	}
+	function area(s : Shape) (int) {
+		return s.area();
+	}
+	var p Point = Point{1};
+	`
+	codes := []string{
+		// variable declaration
+		`var s Shape = p;`,
+		// assignment
+		`var s Shape;
+		s = p;`,
+		// parameter
+		`area(p);`,
+	}
+	for _, code := range codes {
+		exited := false
+		env := NewEnv()
+		env.ErrorHandle.HookExit(func(int) {
+			exited = true
+		})
+		env.SetCode(decls + code)
+		env.Execute()
+		if !exited {
+			t.Errorf("Expected an error for %s", code)
+		} else if first := env.ErrorHandle.Errors[0]; !strings.Contains(first.Msg, "struct Point does not implement interface Shape: missing method area") {
+			t.Errorf("Expected the missing method error for %s, got %s", code, first.Msg)
+		}
+		env.ErrorHandle.RestoreExit()
+	}
 }
 
 func Test_RunTreeLoad(t *testing.T) {
@@ -1115,6 +1309,8 @@ func Test_RunFunctionCallExprWithArgs(t *testing.T) {
 						parser.Literal{
 							Type:  "VAR",
 							Value: "a",
+							// the parameters of a function are found in their slot
+							Binding: &parser.Binding{Slot: 0},
 						},
 					},
 				},
@@ -0,0 +1,125 @@
+package interpreter
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/Eclalang/Ecla/errorHandler"
+)
+
+// Options configures an Interpreter.
+type Options struct {
+	// Bytecode executes the code with the vm instead of the tree walker.
+	Bytecode bool
+	// Stdin, Stdout and Stderr are the input, the output and the diagnostics of the code,
+	// the ones of the process are used when they are nil.
+	// Every library reading or writing the terminal, console and debugKingdom, uses them.
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	// Limits bounds every run of the code.
+	Limits Limits
+	// ImportPolicy restricts the imports of the code.
+	ImportPolicy ImportPolicy
+	// FS is the file system from which the files and the modules are read, the files of the os are read when it is nil.
+	FS fs.FS
+}
+
+// Interpreter executes Ecla code from a Go program.
+// The fatal errors stop the execution of the code and are returned instead of exiting the program.
+// The variables, functions, types and imports declared by a run are kept for the next ones.
+type Interpreter struct {
+	env *Env
+}
+
+// Error is the error returned when the execution of the code is stopped by fatal errors.
+type Error struct {
+	// Errors is the fatal errors raised, every syntax error of the code or the runtime error with its trace
+	Errors []errorHandler.Error
+	// Cause is the reason why the execution was aborted when a limit was exceeded or the context was done,
+	// one of ErrStepLimit, ErrCallDepthLimit, ErrMemoryLimit or the error of the context
+	Cause error
+}
+
+// Error returns the string representation of the errors, one per line.
+func (e *Error) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.String()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors, so errors.As finds the errorHandler.Error raised.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	return errs
+}
+
+// NewInterpreter returns a new Interpreter.
+func NewInterpreter(options Options) *Interpreter {
+	env := NewEnv()
+	env.Bytecode = options.Bytecode
+	env.SetLimits(options.Limits)
+	env.SetImportPolicy(options.ImportPolicy)
+	env.SetFS(options.FS)
+	if options.Stdin != nil {
+		env.SetStdin(options.Stdin)
+	}
+	if options.Stdout != nil {
+		env.SetStdout(options.Stdout)
+	}
+	if options.Stderr != nil {
+		env.SetStderr(options.Stderr)
+	}
+	env.ErrorHandle.Unwind()
+	return &Interpreter{env: env}
+}
+
+// Env returns the environment in which the code is executed.
+func (i *Interpreter) Env() *Env {
+	return i.env
+}
+
+// RunString executes the code.
+func (i *Interpreter) RunString(code string) error {
+	return i.RunStringContext(context.Background(), code)
+}
+
+// RunStringContext executes the code, the execution is aborted when the context is done.
+func (i *Interpreter) RunStringContext(ctx context.Context, code string) error {
+	i.env.SetFile("")
+	i.env.SetCode(code)
+	i.env.SetContext(ctx)
+	return i.run()
+}
+
+// RunFile executes the code of the file, its imports are relative to its directory.
+func (i *Interpreter) RunFile(file string) error {
+	return i.RunFileContext(context.Background(), file)
+}
+
+// RunFileContext executes the code of the file, the execution is aborted when the context is done.
+func (i *Interpreter) RunFileContext(ctx context.Context, file string) error {
+	code, err := i.env.readFile(file)
+	if err != nil {
+		return err
+	}
+	i.env.SetFile(file)
+	i.env.SetCode(code)
+	i.env.SetContext(ctx)
+	return i.run()
+}
+
+// run executes the code of the environment, the state of the environment is restored when a fatal error is raised.
+func (i *Interpreter) run() error {
+	return i.env.protect(i.env.execute)
+}
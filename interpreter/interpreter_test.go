@@ -0,0 +1,557 @@
+package interpreter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Eclalang/Ecla/errorHandler"
+	"github.com/Eclalang/Ecla/interpreter/eclaType"
+)
+
+func TestInterpreter_RunString(t *testing.T) {
+	for _, bytecode := range []bool{false, true} {
+		i := NewInterpreter(Options{Bytecode: bytecode})
+		if err := i.RunString(`var a int = 1;
+function double(n : int) (int) {
+	return n * 2;
+}`); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		// the declarations are kept from a run to the next
+		if err := i.RunString(`var b int = double(a);`); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		if v, ok := i.Env().GetVar("b"); !ok || v.String() != "b = 2" {
+			t.Errorf("Expected b = 2, got %v", v)
+		}
+	}
+}
+
+func TestInterpreter_RunStringError(t *testing.T) {
+	for _, bytecode := range []bool{false, true} {
+		i := NewInterpreter(Options{Bytecode: bytecode})
+		err := i.RunString(`function f(n : int) (int) {
+	if (n > 0) {
+		var x int = 1 - "a";
+	}
+	return n;
+}
+var a int = f(0);
+var b int = f(1);
+var c int = 3;`)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Fatalf("Expected an *Error, got %v", err)
+		}
+		if len(e.Errors) != 1 || e.Errors[0].Line != 3 {
+			t.Fatalf("Expected the error of line 3, got %v", e.Errors)
+		}
+		if len(e.Errors[0].Trace) != 2 || e.Errors[0].Trace[0].Function != "f" || e.Errors[0].Trace[1].Line != 8 {
+			t.Errorf("Expected the error to be raised in f called at line 8, got %v", e.Errors[0].Trace)
+		}
+		var raised errorHandler.Error
+		if !errors.As(err, &raised) || raised.Line != 3 {
+			t.Errorf("Expected errors.As to find the errorHandler.Error, got %v", raised)
+		}
+		if _, ok := i.Env().GetVar("a"); !ok {
+			t.Error("Expected the code before the error to be executed")
+		}
+		if _, ok := i.Env().GetVar("c"); ok {
+			t.Error("Expected the code after the error not to be executed")
+		}
+		if i.Env().Vars.GetDeepestScope() != i.Env().Vars {
+			t.Error("Expected the scopes of the failed run to be ended")
+		}
+		// the interpreter can still be used
+		if err := i.RunString(`var d int = f(0);`); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	}
+}
+
+func TestInterpreter_ErrorSpan(t *testing.T) {
+	code := `var a int = 2;
+var x int = 1 + (a - "b");`
+	for _, bytecode := range []bool{false, true} {
+		err := NewInterpreter(Options{Bytecode: bytecode}).RunString(code)
+		var e *Error
+		if !errors.As(err, &e) || len(e.Errors) != 1 {
+			t.Fatalf("Expected an *Error, got %v", err)
+		}
+		// the error is raised on the whole subtraction, from a to the quote closing "b"
+		raised := e.Errors[0]
+		span := [4]int{raised.Line, raised.Col, raised.EndLine, raised.EndCol}
+		if span != [4]int{2, 18, 2, 25} {
+			t.Errorf("Expected the error to span 2:18 to 2:25, got %v", span)
+		}
+	}
+	// the syntax errors span the token they are raised on
+	err := NewInterpreter(Options{}).RunString(`var s string = "a" "b";`)
+	var e *Error
+	if !errors.As(err, &e) || len(e.Errors) == 0 {
+		t.Fatalf("Expected an *Error, got %v", err)
+	}
+	raised := e.Errors[0]
+	if span := [4]int{raised.Line, raised.Col, raised.EndLine, raised.EndCol}; span != [4]int{1, 20, 1, 21} {
+		t.Errorf("Expected the syntax error to span 1:20 to 1:21, got %v", span)
+	}
+}
+
+func TestInterpreter_RunStringSyntaxError(t *testing.T) {
+	i := NewInterpreter(Options{})
+	// the errors raised by a previous run are traced, not the syntax errors
+	if err := i.RunString(`var l []int = [1];
+var x int = l[3];`); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	err := i.RunString(`var a int = ;
+var b int = 1;
+var c int = +;`)
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("Expected an *Error, got %v", err)
+	}
+	if len(e.Errors) != 2 || e.Errors[0].Line != 1 || e.Errors[1].Line != 3 {
+		t.Errorf("Expected the errors of lines 1 and 3, got %v", e.Errors)
+	}
+	for _, raised := range e.Errors {
+		if raised.Trace != nil {
+			t.Errorf("Expected the syntax error not to be traced, got %v", raised.Trace)
+		}
+	}
+	if _, ok := i.Env().GetVar("b"); ok {
+		t.Error("Expected the code not to be executed")
+	}
+}
+
+func TestInterpreter_Escapes(t *testing.T) {
+	for _, bytecode := range []bool{false, true} {
+		i := NewInterpreter(Options{Bytecode: bytecode})
+		if err := i.RunString(`var s string = "a\tb\n\u{e9}\\\"\x41\033";
+var c char = '\u{1F600}';
+var q char = '\'';
+var n int = len("été");
+var t string = "été";
+var e char = t[2];`); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		expected := map[string]string{
+			"s": "s = a\tb\né\\\"A\x1b",
+			"c": "c = 😀",
+			"q": "q = '",
+			"n": "n = 3",
+			"e": "e = é",
+		}
+		for name, value := range expected {
+			if v, ok := i.Env().GetVar(name); !ok || v.String() != value {
+				t.Errorf("Expected %q, got %q", value, v)
+			}
+		}
+
+		err := i.RunString(`var a string = "ab\q";`)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Fatalf("Expected an *Error, got %v", err)
+		}
+		if len(e.Errors) != 1 || e.Errors[0].Line != 1 || e.Errors[0].Col != 19 || e.Errors[0].Msg != "unknown escape sequence \\q" {
+			t.Errorf("Expected the invalid escape sequence at line 1, col 19, got %v", e.Errors)
+		}
+	}
+}
+
+func TestInterpreter_Strings(t *testing.T) {
+	for _, bytecode := range []bool{false, true} {
+		i := NewInterpreter(Options{Bytecode: bytecode})
+		if err := i.RunString(`var name string = "Ecla";
+var age int = 3;
+var l []int = [1, 2];
+function twice(x : int) (int) {
+	return x * 2;
+}
+var greeting string = "Hello ${name}, you are ${age + 1}";
+var values string = "${twice(age)} ${l} ${'c'} ${true} \${age} ${"${name}!"}";
+var raw string = ` + "`C:\\new\\${name}\n\"second\" line`" + `;
+var empty string = ` + "``;"); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		expected := map[string]string{
+			"greeting": "greeting = Hello Ecla, you are 4",
+			"values":   "values = 6 [1, 2] c true ${age} Ecla!",
+			"raw":      "raw = C:\\new\\${name}\n\"second\" line",
+			"empty":    "empty = ",
+		}
+		for name, value := range expected {
+			if v, ok := i.Env().GetVar(name); !ok || v.String() != value {
+				t.Errorf("Expected %q, got %q", value, v)
+			}
+		}
+	}
+}
+
+func TestInterpreter_Numbers(t *testing.T) {
+	for _, bytecode := range []bool{false, true} {
+		i := NewInterpreter(Options{Bytecode: bytecode})
+		if err := i.RunString(`var h int = 0x1F;
+var o int = 0o17 + 0b101;
+var d int = 1_000_000;
+var z int = 010;
+var f float = 1.5e-3;
+var g float = 2E3;`); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		expected := map[string]string{
+			"h": "h = 31",
+			"o": "o = 20",
+			"d": "d = 1000000",
+			"z": "z = 10",
+			"f": "f = 0.0015",
+			"g": "g = 2000",
+		}
+		for name, value := range expected {
+			if v, ok := i.Env().GetVar(name); !ok || v.String() != value {
+				t.Errorf("Expected %q, got %q", value, v)
+			}
+		}
+
+		err := i.RunString(`var a int = 99999999999999999999;`)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Fatalf("Expected an *Error, got %v", err)
+		}
+		if len(e.Errors) != 1 || e.Errors[0].Line != 1 || e.Errors[0].Col != 13 || e.Errors[0].Msg != "int literal 99999999999999999999 overflows int" {
+			t.Errorf("Expected the overflow at line 1, col 13, got %v", e.Errors)
+		}
+	}
+}
+
+func TestInterpreter_RunFile(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "mod.ecla"), []byte(`var value int = 42;`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	main := filepath.Join(dir, "main.ecla")
+	err = os.WriteFile(main, []byte(`import "mod.ecla";
+var a int = mod.value;`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := NewInterpreter(Options{})
+	if err := i.RunFile(main); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+	if v, ok := i.Env().GetVar("a"); !ok || v.String() != "a = 42" {
+		t.Errorf("Expected a = 42, got %v", v)
+	}
+	if err := i.RunFile(filepath.Join(dir, "missing.ecla")); err == nil {
+		t.Error("Expected an error for a missing file, got nil")
+	}
+	err = os.WriteFile(main, []byte(`import "missing.ecla";`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e *Error
+	if err := i.RunFile(main); !errors.As(err, &e) {
+		t.Errorf("Expected an *Error for a missing module, got %v", err)
+	}
+}
+
+func TestInterpreter_RunFileModules(t *testing.T) {
+	dir := t.TempDir()
+	module := filepath.Join(dir, "counter.ecla")
+	err := os.WriteFile(module, []byte(`var count int = 0;
+function inc() (int) {
+	count++;
+	return count;
+}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	main := filepath.Join(dir, "main.ecla")
+	err = os.WriteFile(main, []byte(`import "counter.ecla";
+n = counter.inc();`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := NewInterpreter(Options{})
+	if err := i.RunString(`var n int = 0;`); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+	// each run loads the modules again
+	for run := 0; run < 3; run++ {
+		if err := i.RunFile(main); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		if v, ok := i.Env().GetVar("n"); !ok || v.String() != "n = 1" {
+			t.Errorf("run %d: expected n = 1, got %v", run, v)
+		}
+	}
+	err = os.WriteFile(module, []byte(`var count int = 10;
+function inc() (int) {
+	count++;
+	return count;
+}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := i.RunFile(main); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+	if v, ok := i.Env().GetVar("n"); !ok || v.String() != "n = 11" {
+		t.Errorf("Expected the module edited to be loaded, got %v", v)
+	}
+}
+
+func TestInterpreter_Streams(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "mod.ecla"), []byte(`import "console";
+function greet(name : string) {
+	console.println("hello", name);
+}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	main := filepath.Join(dir, "main.ecla")
+	err = os.WriteFile(main, []byte(`import "console";
+import "mod.ecla";
+var name string = console.input();
+var n int = console.inputInt();
+console.printf("%d:", n + 1);
+mod.greet(name);
+console.print(console.input());`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, bytecode := range []bool{false, true} {
+		var stdout, stderr bytes.Buffer
+		i := NewInterpreter(Options{
+			Bytecode: bytecode,
+			Stdin:    strings.NewReader("ecla\n41\r\nend"),
+			Stdout:   &stdout,
+			Stderr:   &stderr,
+		})
+		if err := i.RunFile(main); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		if stdout.String() != "42:hello ecla\nend" {
+			t.Errorf("Expected the output of the code and of its module, got %q", stdout.String())
+		}
+	}
+
+	// the libraries reading or writing the terminal never use the ones of the process
+	var out bytes.Buffer
+	i := NewInterpreter(Options{Stdout: &out, Stderr: io.Discard})
+	if err := i.RunString(`import "debugKingdom";
+debugKingdom.clear();`); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+	if out.String() != "\033[H\033[2J" {
+		t.Errorf("Expected the screen to be cleared on the output, got %q", out.String())
+	}
+	if err := i.RunString(`import "console";
+console.unknown();`); err == nil || !strings.Contains(err.Error(), "Method unknown not found in package console") {
+		t.Errorf("Expected the unknown method error, got %v", err)
+	}
+
+	// the Envs reading the input of the process share its buffer
+	if NewEnv().Stdin() != NewEnv().Stdin() || NewTemporaryEnv(nil).Stdin() != NewEnv().Stdin() {
+		t.Error("Expected the Envs to share the input of the process")
+	}
+
+	// the errors exiting the program are printed to the diagnostics
+	var stdout, stderr bytes.Buffer
+	env := NewEnv()
+	env.SetStdout(&stdout)
+	env.SetStderr(&stderr)
+	env.SetCode(`var a int = 1 / 0;`)
+	var code int
+	env.ErrorHandle.HookExit(func(c int) {
+		code = c
+	})
+	defer env.ErrorHandle.RestoreExit()
+	env.Execute()
+	if code != 1 || !strings.Contains(stderr.String(), "cannot divide by zero") || stdout.Len() != 0 {
+		t.Errorf("Expected the error to be printed to the diagnostics, got %q and %q", stderr.String(), stdout.String())
+	}
+}
+
+// runScript executes the script with a new interpreter and returns its output and its error.
+func runScript(file string, bytecode bool) string {
+	var stdout bytes.Buffer
+	i := NewInterpreter(Options{
+		Bytecode: bytecode,
+		Stdin:    strings.NewReader(""),
+		Stdout:   &stdout,
+		Stderr:   io.Discard,
+	})
+	if err := i.RunFile(file); err != nil {
+		stdout.WriteString(err.Error())
+	}
+	return stdout.String()
+}
+
+// skippedScripts are the DEMO scripts whose output cannot be compared from a run to another,
+// because they never end or because they print random values.
+var skippedScripts = map[string]bool{
+	"forIn.ecla": true,
+}
+
+// demoScripts returns the DEMO scripts whose output is compared from a run to another.
+func demoScripts(t *testing.T) []string {
+	files, err := filepath.Glob("../DEMO/Test/*.ecla")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files = append(files, "../DEMO/AllTests.ecla")
+	var scripts []string
+	for _, file := range files {
+		if !skippedScripts[filepath.Base(file)] {
+			scripts = append(scripts, file)
+		}
+	}
+	return scripts
+}
+
+func TestInterpreter_Bytecode(t *testing.T) {
+	// the bytecode vm executes the scripts like the tree-walking interpreter
+	for _, file := range demoScripts(t) {
+		if expected, out := runScript(file, false), runScript(file, true); out != expected {
+			t.Errorf("%s: expected the output of the tree-walking interpreter %q, got %q", file, expected, out)
+		}
+	}
+}
+
+func TestInterpreter_Concurrent(t *testing.T) {
+	scripts := demoScripts(t)
+	for _, bytecode := range []bool{false, true} {
+		expected := make([]string, len(scripts))
+		for i, file := range scripts {
+			expected[i] = runScript(file, bytecode)
+		}
+		var wg sync.WaitGroup
+		for n := 0; n < 4; n++ {
+			for i, file := range scripts {
+				wg.Add(1)
+				go func(i int, file string) {
+					defer wg.Done()
+					if out := runScript(file, bytecode); out != expected[i] {
+						t.Errorf("%s: expected the output of the script run alone %q, got %q", file, expected[i], out)
+					}
+				}(i, file)
+			}
+		}
+		wg.Wait()
+	}
+}
+
+func TestInterpreter_ErrorMethod(t *testing.T) {
+	code := `function (err : error) describe() (string) {
+	return err.message;
+}`
+	run := func() error {
+		return NewInterpreter(Options{Stdout: io.Discard, Stderr: io.Discard}).RunString(code)
+	}
+	// the built-in error struct is shared by every Env, a method on it would leak from one to another
+	for n := 0; n < 2; n++ {
+		if err := run(); err == nil || !strings.Contains(err.Error(), "Cannot declare a method on error") {
+			t.Errorf("Expected the invalid receiver type error, got %v", err)
+		}
+	}
+	var wg sync.WaitGroup
+	for n := 0; n < 4; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := run(); err == nil || !strings.Contains(err.Error(), "Cannot declare a method on error") {
+				t.Errorf("Expected the invalid receiver type error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(eclaType.ErrorDecl.Methods) != 0 {
+		t.Errorf("Expected the built-in error struct to have no method, got %v", eclaType.ErrorDecl.Methods)
+	}
+}
+
+func TestInterpreter_FS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"scripts/main.ecla": {Data: []byte(`import "lib/mod.ecla";
+import "/scripts/util.ecla";
+var a int = mod.value + util.value;`)},
+		"scripts/lib/mod.ecla": {Data: []byte(`import "../util.ecla";
+var value int = util.value * 10;`)},
+		"scripts/util.ecla":    {Data: []byte(`var value int = 4;`)},
+		"scripts/missing.ecla": {Data: []byte(`import "nothing.ecla";`)},
+		"scripts/escape.ecla":  {Data: []byte(`import "../other/mod.ecla";`)},
+		"other/mod.ecla":       {Data: []byte(`var value int = 1;`)},
+	}
+	for _, bytecode := range []bool{false, true} {
+		i := NewInterpreter(Options{Bytecode: bytecode, FS: fsys})
+		if err := i.RunFile("scripts/main.ecla"); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		if v, ok := i.Env().GetVar("a"); !ok || v.String() != "a = 44" {
+			t.Errorf("Expected a = 44, got %v", v)
+		}
+	}
+
+	i := NewInterpreter(Options{FS: fsys})
+	if err := i.RunFile("scripts/nothing.ecla"); err == nil {
+		t.Error("Expected an error for a file missing from the file system, got nil")
+	}
+	if err := i.RunFile("scripts/missing.ecla"); err == nil || !strings.Contains(err.Error(), "module 'scripts/nothing.ecla' not found") {
+		t.Errorf("Expected the module to be missing from the file system, got %v", err)
+	}
+	// the file system is the only source of the files
+	if err := i.RunString(`import "fs_test.go";`); err == nil {
+		t.Error("Expected the files of the os not to be imported, got nil")
+	}
+
+	// the roots of the import policy are directories of the file system
+	i = NewInterpreter(Options{FS: fsys, ImportPolicy: ImportPolicy{Roots: []string{"scripts"}}})
+	if err := i.RunFile("scripts/main.ecla"); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+	if err := i.RunFile("scripts/escape.ecla"); err == nil || !strings.Contains(err.Error(), "outside of the allowed directories") {
+		t.Errorf("Expected the import outside of the roots to be rejected, got %v", err)
+	}
+}
+
+func BenchmarkInterpreter_Call(b *testing.B) {
+	code := `function work(n : int) (int) {
+	var acc int = 0;
+	for (var i int = 0, i < n, i++) {
+		var x int = i * 2;
+		if (x > 10) {
+			acc = acc + x - i;
+		}
+	}
+	return acc;
+}`
+	for _, bytecode := range []bool{false, true} {
+		name := "tree"
+		if bytecode {
+			name = "vm"
+		}
+		b.Run(name, func(b *testing.B) {
+			i := NewInterpreter(Options{Bytecode: bytecode})
+			if err := i.RunString(code); err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if _, err := i.Env().Call("work", 1000); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
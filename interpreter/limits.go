@@ -0,0 +1,152 @@
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Eclalang/Ecla/errorHandler"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// Limits bounds the execution of the code, a zero field sets no limit.
+type Limits struct {
+	// MaxSteps is the maximum number of nodes or instructions executed by a run
+	MaxSteps int
+	// Timeout is the maximum duration of a run
+	Timeout time.Duration
+	// MaxCallDepth is the maximum number of nested function calls
+	MaxCallDepth int
+	// MaxMemory is the maximum size of the variables, as computed by the GetSize method of their values
+	MaxMemory int
+}
+
+var (
+	// ErrStepLimit is raised when a run executes more than Limits.MaxSteps steps.
+	ErrStepLimit = errors.New("maximum number of executed steps exceeded")
+	// ErrCallDepthLimit is raised when the calls are nested deeper than Limits.MaxCallDepth.
+	ErrCallDepthLimit = errors.New("maximum call depth exceeded")
+	// ErrMemoryLimit is raised when the variables are larger than Limits.MaxMemory.
+	ErrMemoryLimit = errors.New("maximum memory exceeded")
+)
+
+// checkInterval is the number of steps between two checks of the context and of the memory.
+const checkInterval = 64
+
+// limiter holds the limits of the execution and what they bound, it is shared by the Env of the modules.
+type limiter struct {
+	limits Limits
+	// ctx is the context of the runs, the run is aborted when it is done
+	ctx context.Context
+	// active is true when the run being executed is bounded
+	active bool
+	done   <-chan struct{}
+	runCtx context.Context
+	steps  int
+	depth  int
+}
+
+// abort is the panic unwinding the execution when a limit is exceeded, it is not caught by the try blocks.
+type abort struct {
+	err   errorHandler.Error
+	cause error
+}
+
+// SetLimits sets the limits of the next runs.
+func (env *Env) SetLimits(limits Limits) {
+	env.limiter.limits = limits
+}
+
+// SetContext sets the context of the next runs, a run is aborted when its context is done.
+func (env *Env) SetContext(ctx context.Context) {
+	env.limiter.ctx = ctx
+}
+
+// startLimits starts bounding a run, the returned function stops it.
+func (env *Env) startLimits() func() {
+	l := env.limiter
+	if l.active {
+		// the run is nested in another one, it is bounded with it
+		return func() {}
+	}
+	l.steps, l.depth = 0, 0
+	ctx := l.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cancel := func() {}
+	if l.limits.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, l.limits.Timeout)
+	}
+	l.runCtx, l.done = ctx, ctx.Done()
+	l.active = l.done != nil || l.limits.MaxSteps > 0 || l.limits.MaxCallDepth > 0 || l.limits.MaxMemory > 0
+	return func() {
+		cancel()
+		l.active, l.runCtx, l.done = false, nil, nil
+	}
+}
+
+// step counts a step of the execution of the node, the run is aborted when a limit is exceeded.
+func (env *Env) step(node parser.Node) {
+	l := env.limiter
+	if !l.active {
+		return
+	}
+	l.steps++
+	if l.limits.MaxSteps > 0 && l.steps > l.limits.MaxSteps {
+		env.abort(node, ErrStepLimit)
+	}
+	if l.steps%checkInterval != 0 {
+		return
+	}
+	if l.done != nil {
+		select {
+		case <-l.done:
+			env.abort(node, l.runCtx.Err())
+		default:
+		}
+	}
+	if l.limits.MaxMemory > 0 && env.memory() > l.limits.MaxMemory {
+		env.abort(node, ErrMemoryLimit)
+	}
+}
+
+// enterDepth counts a nested call, the run is aborted before the call when the calls are nested too deep.
+func (env *Env) enterDepth() {
+	l := env.limiter
+	if l.active && l.limits.MaxCallDepth > 0 && l.depth >= l.limits.MaxCallDepth {
+		env.abort(env.callSite, ErrCallDepthLimit)
+	}
+	l.depth++
+}
+
+// exitDepth counts the end of a nested call.
+func (env *Env) exitDepth() {
+	env.limiter.depth--
+}
+
+// memory returns the size of the variables of env and of its modules.
+func (env *Env) memory() int {
+	size := env.Vars.size()
+	for _, lib := range env.Libs {
+		if l, ok := lib.(*envLib); ok {
+			size += l.Var.size()
+		}
+	}
+	return size
+}
+
+// abort stops the execution of the code because of cause.
+// The error unwinds the execution when the fatal errors do, it exits the program otherwise.
+func (env *Env) abort(node parser.Node, cause error) {
+	err := errorHandler.Error{Msg: cause.Error(), Level: errorHandler.LevelFatal}
+	if node != nil {
+		err = errorHandler.NewError(node, cause.Error(), errorHandler.LevelFatal)
+	}
+	err.Trace = env.trace(err.Line, err.Col)
+	if env.ErrorHandle.Unwinding() {
+		env.ErrorHandle.Collect(err)
+		panic(abort{err: err, cause: cause})
+	}
+	env.ErrorHandle.Exit(err)
+}
@@ -0,0 +1,102 @@
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		limits Limits
+		code   string
+		cause  error
+	}{
+		{Limits{MaxSteps: 1000}, `while (true) {}`, ErrStepLimit},
+		// the try blocks do not catch the limits
+		{Limits{MaxSteps: 1000}, `var caught bool = false;
+try {
+	while (true) {}
+} catch (e) {
+	caught = true;
+}`, ErrStepLimit},
+		{Limits{Timeout: 20 * time.Millisecond}, `while (true) {}`, context.DeadlineExceeded},
+		{Limits{MaxCallDepth: 50}, `function f(n : int) (int) {
+	return f(n + 1);
+}
+var a int = f(0);`, ErrCallDepthLimit},
+		{Limits{MaxMemory: 10000}, `var l []int = [1];
+while (true) {
+	l = append(l, 1);
+}`, ErrMemoryLimit},
+	}
+	for _, bytecode := range []bool{false, true} {
+		for _, test := range tests {
+			i := NewInterpreter(Options{Bytecode: bytecode, Limits: test.limits})
+			err := i.RunString(test.code)
+			if !errors.Is(err, test.cause) {
+				t.Errorf("%v: expected %v, got %v", test.limits, test.cause, err)
+				continue
+			}
+			var e *Error
+			if !errors.As(err, &e) || len(e.Errors) != 1 || e.Errors[0].Msg != test.cause.Error() {
+				t.Errorf("%v: expected the error of the limit, got %v", test.limits, err)
+			}
+			if v, ok := i.Env().GetVar("caught"); ok && v.String() != "caught = false" {
+				t.Errorf("%v: expected the limit not to be caught, got %v", test.limits, v)
+			}
+			// the limits bound each run
+			if err := i.RunString(`var b int = 1;`); err != nil {
+				t.Errorf("%v: expected nil, got %v", test.limits, err)
+			}
+		}
+	}
+}
+
+func TestLimits_WithinLimits(t *testing.T) {
+	i := NewInterpreter(Options{Limits: Limits{MaxSteps: 100000, Timeout: time.Minute, MaxCallDepth: 20, MaxMemory: 100000}})
+	err := i.RunString(`function fact(n : int) (int) {
+	if (n <= 1) {
+		return 1;
+	}
+	return n * fact(n - 1);
+}
+var a int = fact(10);`)
+	if err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+	if _, err := i.Env().Call("fact", 15); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+	_, err = i.Env().Call("fact", 30)
+	if !errors.Is(err, ErrCallDepthLimit) {
+		t.Fatalf("Expected %v, got %v", ErrCallDepthLimit, err)
+	}
+	// the call aborted is not traced, the calls of the aborted run are not traced by the next one
+	var e *Error
+	if !errors.As(err, &e) || len(e.Errors[0].Trace) != 21 {
+		t.Errorf("Expected 20 calls of fact and main to be traced, got %v", err)
+	}
+	err = i.RunString(`var l []int = [1];
+var b int = l[3];`)
+	if !errors.As(err, &e) || len(e.Errors) != 1 || len(e.Errors[0].Trace) != 1 || e.Errors[0].Trace[0].Function != "main" {
+		t.Errorf("Expected the error to be raised in main, got %v", err)
+	}
+}
+
+func TestLimits_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	i := NewInterpreter(Options{})
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	if err := i.RunStringContext(ctx, `while (true) {}`); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+	// the context is only used by the run it is given to
+	if err := i.RunString(`var a int = 1;`); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+}
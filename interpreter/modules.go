@@ -0,0 +1,89 @@
+package interpreter
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// modules is the registry of the modules imported by a run of an Env and by its modules,
+// each module is loaded once per run and its state is shared by all the code importing it.
+type modules struct {
+	// loaded is the Env of the modules loaded, by the key of their file
+	loaded map[string]*Env
+	// loading is the chain of the modules being loaded, the first one is the code importing the others
+	loading []moduleFile
+}
+
+// moduleFile is the file of a module and the key identifying it.
+type moduleFile struct {
+	key  string
+	file string
+}
+
+// newModules returns an empty registry of modules.
+func newModules() *modules {
+	return &modules{loaded: make(map[string]*Env)}
+}
+
+// moduleKey returns the key identifying the module of the file, its absolute path.
+func (env *Env) moduleKey(file string) string {
+	if file == "" {
+		return ""
+	}
+	if env.fsys != nil {
+		return path.Clean(file)
+	}
+	if abs, err := filepath.Abs(file); err == nil {
+		return abs
+	}
+	return filepath.Clean(file)
+}
+
+// loadModule returns the Env of the module of the file, it is loaded the first time it is imported.
+// An error is returned if the module is already being loaded, the imports form a cycle.
+func (env *Env) loadModule(file string, load func(*Env)) (*Env, error) {
+	m := env.modules
+	key := env.moduleKey(file)
+	if module, ok := m.loaded[key]; ok {
+		return module, nil
+	}
+	if len(m.loading) == 0 {
+		m.loading = append(m.loading, moduleFile{key: env.moduleKey(env.File), file: env.File})
+		// the code importing the modules is the first of the chain until its imports are loaded
+		defer func() {
+			m.loading = m.loading[:0]
+		}()
+	}
+	for i, loading := range m.loading {
+		if loading.key == key {
+			chain := make([]string, 0, len(m.loading)-i+1)
+			for _, f := range m.loading[i:] {
+				chain = append(chain, f.file)
+			}
+			return nil, fmt.Errorf("import cycle: %s", strings.Join(append(chain, file), " -> "))
+		}
+	}
+	m.loading = append(m.loading, moduleFile{key: key, file: file})
+	defer func() {
+		m.loading = m.loading[:len(m.loading)-1]
+	}()
+
+	module := NewTemporaryEnv(env.ErrorHandle)
+	module.SetFile(file)
+	module.streams = env.streams
+	module.limiter = env.limiter
+	module.policy = env.policy
+	module.fsys = env.fsys
+	module.modules = m
+	module.builtIns = env.builtIns
+	for name, builtIn := range env.builtIns {
+		if err := declareBuiltIn(module, name, builtIn); err != nil {
+			return nil, err
+		}
+	}
+	load(module)
+	m.loaded[key] = module
+	return module, nil
+}
@@ -0,0 +1,136 @@
+package interpreter
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Eclalang/Ecla/errorHandler"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// stdLibs is the names of the standard libraries, those of the LibraryController.
+var stdLibs = map[string]bool{
+	"console":      true,
+	"debugKingdom": true,
+	"encoding":     true,
+	"json":         true,
+	"os":           true,
+	"hash":         true,
+	"regex":        true,
+	"strings":      true,
+	"time":         true,
+	"math":         true,
+	"cast":         true,
+}
+
+// ImportPolicy restricts the modules the code may import, the zero ImportPolicy allows every import.
+// The policy of an Env applies to the modules it imports.
+type ImportPolicy struct {
+	// Libs is the standard libraries the code may import, every library when it is nil
+	Libs []string
+	// DeniedLibs is the standard libraries the code may not import
+	DeniedLibs []string
+	// Roots is the directories the imported files must be in, every file when it is nil
+	// and no file when it is empty but not nil
+	Roots []string
+}
+
+// SetImportPolicy sets the policy of the imports of the code.
+func (env *Env) SetImportPolicy(policy ImportPolicy) {
+	*env.policy = policy
+}
+
+// checkImport returns an error if the policy forbids the import of the module found at the path.
+func (env *Env) checkImport(path string) error {
+	policy := env.policy
+	if stdLibs[path] {
+		if policy.Libs != nil && !contains(path, policy.Libs) {
+			return fmt.Errorf("the import of the library '%s' is not allowed", path)
+		}
+		if contains(path, policy.DeniedLibs) {
+			return fmt.Errorf("the import of the library '%s' is denied", path)
+		}
+		return nil
+	}
+	if policy.Roots == nil {
+		return nil
+	}
+	file := env.modulePath(path)
+	for _, root := range policy.Roots {
+		if env.isInDir(file, root) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the import of '%s' is not allowed, it is outside of the allowed directories", file)
+}
+
+// checkImports raises an error for each import of the parsed code forbidden by the policy, before executing it.
+func (env *Env) checkImports() {
+	var errs []errorHandler.Error
+	for _, node := range env.SyntaxTree.ParseTree.Operations {
+		if stmt, ok := node.(parser.ImportStmt); ok {
+			if err := env.checkImport(stmt.ModulePath); err != nil {
+				e := errorHandler.NewError(stmt, err.Error(), errorHandler.LevelFatal)
+				env.ErrorHandle.Collect(e)
+				errs = append(errs, e)
+			}
+		}
+	}
+	env.ErrorHandle.HandleErrors(errs)
+}
+
+// modulePath returns the path of the file of a module, relative to the directory of the file of env.
+// The absolute paths of a file system are relative to its root.
+func (env *Env) modulePath(p string) string {
+	if env.fsys != nil {
+		if path.IsAbs(p) {
+			return path.Clean(strings.TrimLeft(p, "/"))
+		}
+		return path.Join(path.Dir(env.File), p)
+	}
+	if !filepath.IsAbs(p) {
+		return filepath.Join(filepath.Dir(env.File), p)
+	}
+	return p
+}
+
+// isInDir returns true if the file is in the directory or in one of its subdirectories.
+func (env *Env) isInDir(file string, dir string) bool {
+	if env.fsys != nil {
+		dir = path.Clean(dir)
+		return dir == "." || file == dir || strings.HasPrefix(file, dir+"/")
+	}
+	file, dir = resolvePath(file), resolvePath(dir)
+	rel, err := filepath.Rel(dir, file)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// resolvePath returns the absolute path without symbolic links, as far as it can be resolved.
+func resolvePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	// the file does not exist, the symbolic links of its directory are resolved
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Join(dir, filepath.Base(path))
+	}
+	return path
+}
+
+// contains returns true if the name is in the list.
+func contains(name string, list []string) bool {
+	for _, s := range list {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
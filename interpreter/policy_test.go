@@ -0,0 +1,115 @@
+package interpreter
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	libs "github.com/Eclalang/LibraryController"
+)
+
+func TestStdLibs(t *testing.T) {
+	for name := range stdLibs {
+		if libs.Import(name) == nil {
+			t.Errorf("Expected %s to be a standard library", name)
+		}
+	}
+	if libs.Import("module.ecla") != nil || stdLibs["module.ecla"] {
+		t.Error("Expected module.ecla not to be a standard library")
+	}
+}
+
+func TestImportPolicy_Libs(t *testing.T) {
+	tests := []struct {
+		policy ImportPolicy
+		code   string
+		denied string
+	}{
+		{ImportPolicy{}, `import "console";
+import "os";`, ""},
+		{ImportPolicy{Libs: []string{"console", "math"}}, `import "console";
+import "math";`, ""},
+		{ImportPolicy{Libs: []string{"console"}}, `import "console";
+import "os";`, "os"},
+		{ImportPolicy{DeniedLibs: []string{"os"}}, `import "console";
+import "os";`, "os"},
+		{ImportPolicy{Libs: []string{}}, `import "console";`, "console"},
+	}
+	for _, test := range tests {
+		i := NewInterpreter(Options{ImportPolicy: test.policy})
+		err := i.RunString(test.code + `
+var a int = 1;`)
+		if test.denied == "" {
+			if err != nil {
+				t.Errorf("%v: expected nil, got %v", test.policy, err)
+			}
+			continue
+		}
+		var e *Error
+		if !errors.As(err, &e) || len(e.Errors) != 1 || !strings.Contains(e.Errors[0].Msg, "'"+test.denied+"'") {
+			t.Errorf("%v: expected the import of %s to be rejected, got %v", test.policy, test.denied, err)
+			continue
+		}
+		// the imports are checked before executing the code
+		if _, ok := i.Env().GetVar("a"); ok {
+			t.Errorf("%v: expected the code not to be executed", test.policy)
+		}
+	}
+}
+
+func TestImportPolicy_Roots(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(root, "sub", "mod.ecla"): `var value int = 1;`,
+		filepath.Join(root, "libs.ecla"):       `import "os";`,
+		filepath.Join(dir, "outside.ecla"):     `var value int = 2;`,
+	}
+	for file, code := range files {
+		if err := os.WriteFile(file, []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := true
+	if err := os.Symlink(filepath.Join(dir, "outside.ecla"), filepath.Join(root, "link.ecla")); err != nil {
+		links = false
+	}
+
+	main := filepath.Join(root, "main.ecla")
+	run := func(policy ImportPolicy, code string) error {
+		if err := os.WriteFile(main, []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return NewInterpreter(Options{ImportPolicy: policy}).RunFile(main)
+	}
+	policy := ImportPolicy{Roots: []string{root}, DeniedLibs: []string{"os"}}
+	if err := run(policy, `import "sub/mod.ecla";`); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+	if err := run(policy, `import "../outside.ecla";`); err == nil || !strings.Contains(err.Error(), "outside of the allowed directories") {
+		t.Errorf("Expected the import of a file outside of the roots to be rejected, got %v", err)
+	}
+	if err := run(policy, `import "`+filepath.Join(dir, "outside.ecla")+`";`); err == nil {
+		t.Error("Expected the import of an absolute path outside of the roots to be rejected, got nil")
+	}
+	if links {
+		if err := run(policy, `import "link.ecla";`); err == nil {
+			t.Error("Expected the import of a link to a file outside of the roots to be rejected, got nil")
+		}
+	}
+	// the policy applies to the imports of the modules
+	if err := run(policy, `import "libs.ecla";`); err == nil || !strings.Contains(err.Error(), "'os'") {
+		t.Errorf("Expected the import of os by the module to be rejected, got %v", err)
+	}
+	if err := run(ImportPolicy{Roots: []string{}}, `import "sub/mod.ecla";`); err == nil {
+		t.Error("Expected every file to be rejected, got nil")
+	}
+	if err := run(ImportPolicy{}, `import "../outside.ecla";`); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+}
@@ -2,6 +2,15 @@ package interpreter
 
 // Run executes the environment.
 func Run(env *Env) {
+	defer env.enterMain()()
+	if env.Bytecode {
+		// like the tree walker, what a statement returns does not stop the execution of the next ones
+		operations := env.SyntaxTree.ParseTree.Operations
+		for i := range operations {
+			RunBytecode(operations[i:i+1], env)
+		}
+		return
+	}
 	for _, v := range env.SyntaxTree.ParseTree.Operations {
 		RunTree(v, env)
 	}
@@ -9,6 +18,7 @@ func Run(env *Env) {
 
 // Load executes import statements with their environment.
 func Load(env *Env) {
+	defer env.enterMain()()
 	for _, v := range env.SyntaxTree.ParseTree.Operations {
 		RunTreeLoad(v, env)
 	}
@@ -17,92 +17,201 @@ const (
 	SCOPE_FINALLY
 )
 
+// minSlots is the number of slots allocated for the first variable declared in a scope.
+const minSlots = 8
+
 // Scope is a scope.
+// The variables declared in a block or a function are stored in the slots the resolver gave them,
+// the ones set by name, like the functions, the imports or the variables of the main scope, are stored in Var.
 type Scope struct {
+	// Var is nil until a variable is set by name in the scope
 	Var      map[string]*eclaType.Var
+	slots    []*eclaType.Var
 	next     *Scope
 	previous *Scope
 	Type     ScopeType
 	InFunc   bool
+	// frames is the stack of the scopes, from the main scope to the deepest one, it is only kept by the main scope
+	frames []scopeFrame
+}
+
+// scopeFrame is a scope of the stack of a main scope.
+type scopeFrame struct {
+	scope *Scope
+	// base is the index of the function scope, or of the main scope, the scope belongs to
+	base int
 }
 
 // NewScopeMain returns a new main scope.
 func NewScopeMain() *Scope {
-	return &Scope{
+	s := &Scope{
 		Var:      make(map[string]*eclaType.Var),
 		next:     nil,
 		previous: nil,
 		Type:     SCOPE_MAIN,
 		InFunc:   false,
 	}
+	s.frames = []scopeFrame{{scope: s}}
+	return s
+}
+
+// deepest returns the deepest scope.
+func (s *Scope) deepest() *Scope {
+	if s.frames == nil {
+		return s
+	}
+	return s.frames[len(s.frames)-1].scope
+}
+
+// push adds the scope after the deepest one, base tells if the scope is the base of the scopes deeper than it.
+func (s *Scope) push(scope *Scope, base bool) {
+	top := len(s.frames) - 1
+	f := scopeFrame{scope: scope, base: s.frames[top].base}
+	if base {
+		f.base = top + 1
+	}
+	s.frames[top].scope.next = scope
+	s.frames = append(s.frames, f)
 }
 
 // Set sets the value of the variable with the given name.
 func (s *Scope) Set(name string, value *eclaType.Var) {
-	cursor := s
-	for cursor.next != nil {
-		cursor = cursor.next
+	cursor := s.deepest()
+	if cursor.Var == nil {
+		cursor.Var = make(map[string]*eclaType.Var)
 	}
 	cursor.Var[name] = value
 }
 
+// SetSlot sets the variable in the given slot of the current scope.
+func (s *Scope) SetSlot(slot int, value *eclaType.Var) {
+	cursor := s.deepest()
+	if slot >= cap(cursor.slots) {
+		// the slots are declared in order, they are grown once for the next ones too
+		slots := make([]*eclaType.Var, slot+1, max(slot+1, 2*cap(cursor.slots), minSlots))
+		copy(slots, cursor.slots)
+		cursor.slots = slots
+	} else if slot >= len(cursor.slots) {
+		cursor.slots = cursor.slots[:slot+1]
+	}
+	cursor.slots[slot] = value
+}
+
+// size returns the size of the variables of the scope and of the scopes deeper than it.
+func (s *Scope) size() int {
+	size := 0
+	for cursor := s; cursor != nil; cursor = cursor.next {
+		for _, v := range cursor.Var {
+			size += v.GetSize()
+		}
+		for _, v := range cursor.slots {
+			if v != nil {
+				size += v.GetSize()
+			}
+		}
+	}
+	return size
+}
+
 // Get returns the value of the variable with the given name.
 func (s *Scope) Get(name string) (*eclaType.Var, bool) {
-	cursor := s
-	for cursor.next != nil {
-		cursor = cursor.next
+	for cursor := s.deepest(); cursor != nil; cursor = cursor.previous {
+		if v, ok := cursor.Var[name]; ok {
+			return v, true
+		}
 	}
-	var ok = false
-	var v *eclaType.Var
-	for cursor != nil && !ok {
-		v, ok = cursor.Var[name]
-		cursor = cursor.previous
+	return nil, false
+}
+
+// GetSlot returns the variable in the given slot of the scope depth scopes above the current one.
+// The scopes of the current function are indexed in the stack, the ones above it are those the function captured.
+func (s *Scope) GetSlot(depth int, slot int) (*eclaType.Var, bool) {
+	var cursor *Scope
+	top := len(s.frames) - 1
+	if base := s.frames[top].base; top-depth >= base {
+		cursor = s.frames[top-depth].scope
+	} else {
+		cursor = s.frames[base].scope.previous
+		for i := top - base + 1; i < depth && cursor != nil; i++ {
+			cursor = cursor.previous
+		}
 	}
-	return v, ok
+	if cursor == nil || slot >= len(cursor.slots) || cursor.slots[slot] == nil {
+		return nil, false
+	}
+	return cursor.slots[slot], true
 }
 
-// CheckIfVarExistsInCurrentScope returns true if the variable exists in the current scope.
+// CheckIfVarExistsInCurrentScope returns true if a variable with the given name was set in the current scope.
 func (s *Scope) CheckIfVarExistsInCurrentScope(name string) bool {
-	cursor := s
-	for cursor.next != nil {
-		cursor = cursor.next
-	}
-	_, ok := cursor.Var[name]
+	_, ok := s.deepest().Var[name]
 	return ok
 }
 
+// CheckIfSlotIsSetInCurrentScope returns true if a variable was set in the given slot of the current scope.
+func (s *Scope) CheckIfSlotIsSetInCurrentScope(slot int) bool {
+	cursor := s.deepest()
+	return slot < len(cursor.slots) && cursor.slots[slot] != nil
+}
+
 // GoDeep creates a new scope was deeper than the current one.
 func (s *Scope) GoDeep(Type ScopeType) {
-	cursor := s
-	for cursor.next != nil {
-		cursor = cursor.next
-	}
+	cursor := s.deepest()
 	InFunc := cursor.InFunc
 	if Type == SCOPE_FUNCTION {
 		InFunc = true
 	}
-	cursor.next = &Scope{
-		Var:      make(map[string]*eclaType.Var),
+	s.push(&Scope{
 		next:     nil,
 		previous: cursor,
 		Type:     Type,
 		InFunc:   InFunc,
-	}
+	}, Type == SCOPE_FUNCTION)
+}
+
+// GoDeepWithClosure creates a new function scope deeper than the current one,
+// the variables not found in it are looked up in the given closure instead of the current scopes.
+func (s *Scope) GoDeepWithClosure(closure *Scope) {
+	s.push(&Scope{
+		next:     nil,
+		previous: closure,
+		Type:     SCOPE_FUNCTION,
+		InFunc:   true,
+	}, true)
 }
 
 // GoUp goes up in the scope and deletes the current one.
 func (s *Scope) GoUp() {
-	cursor := s
-	if cursor.next != nil {
-		for cursor.next.next != nil {
-			cursor = cursor.next
-		}
+	top := len(s.frames) - 1
+	if top <= 0 {
+		return
+	}
+	s.frames[top] = scopeFrame{}
+	s.frames = s.frames[:top]
+	s.frames[top-1].scope.next = nil
+}
+
+// GoUpTo deletes every scope deeper than the given one.
+func (s *Scope) GoUpTo(scope *Scope) {
+	for len(s.frames) > 1 && s.deepest() != scope {
+		s.GoUp()
 	}
-	cursor.next = nil
 }
 
-// SetNextScope sets the next scope.
+// GetDeepestScope returns the deepest scope.
+func (s *Scope) GetDeepestScope() *Scope {
+	return s.deepest()
+}
+
+// SetNextScope sets the next scope, it replaces the scopes deeper than the main scope when called on it.
 func (s *Scope) SetNextScope(next *Scope) {
+	if s.frames != nil {
+		s.GoUpTo(s)
+		if next != nil {
+			s.push(next, true)
+		}
+		return
+	}
 	s.next = next
 }
 
@@ -130,9 +239,5 @@ func (s *Scope) InFunction() bool {
 
 // GoDeepWithSpecificScope goes deep with a specific scope.
 func (s *Scope) GoDeepWithSpecificScope(Scope *Scope) {
-	cursor := s
-	for cursor.next != nil {
-		cursor = cursor.next
-	}
-	cursor.next = Scope
+	s.push(Scope, true)
 }
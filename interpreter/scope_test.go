@@ -200,3 +200,167 @@ func TestScope_GoDeepWithSpecificScope(t *testing.T) {
 		t.Error("Expected not nil, got nil")
 	}
 }
+
+func TestScope_GoDeepWithClosure(t *testing.T) {
+	scope := NewScopeMain()
+	closure := NewScopeMain()
+	v, _ := eclaType.NewVar("captured", "int", eclaType.Int(1))
+	closure.Set("captured", v)
+	v, _ = eclaType.NewVar("hidden", "int", eclaType.Int(2))
+	scope.Set("hidden", v)
+
+	scope.GoDeepWithClosure(closure)
+
+	if scope.next == nil || scope.next.Type != SCOPE_FUNCTION || !scope.next.InFunc {
+		t.Error("Expected a new function scope")
+	}
+	if _, ok := scope.Get("captured"); !ok {
+		t.Error("Expected the variables of the closure to be visible")
+	}
+	if _, ok := scope.Get("hidden"); ok {
+		t.Error("Expected the variables of the enclosing scopes to be hidden")
+	}
+
+	scope.GoUp()
+	if scope.next != nil {
+		t.Error("Expected the closure scope to be removed")
+	}
+}
+
+func TestScope_SetSlot(t *testing.T) {
+	scope := NewScopeMain()
+	scope.GoDeep(SCOPE_FUNCTION)
+	v1, _ := eclaType.NewVar("a", "int", eclaType.Int(1))
+	scope.SetSlot(0, v1)
+	scope.GoDeep(SCOPE_LOOP)
+	v2, _ := eclaType.NewVar("b", "int", eclaType.Int(2))
+	scope.SetSlot(1, v2)
+
+	if v, ok := scope.GetSlot(1, 0); !ok || v != v1 {
+		t.Error("Expected a to be found in the slot 0 of the function scope")
+	}
+	if v, ok := scope.GetSlot(0, 1); !ok || v != v2 {
+		t.Error("Expected b to be found in the slot 1 of the current scope")
+	}
+	if !scope.CheckIfSlotIsSetInCurrentScope(1) || scope.CheckIfSlotIsSetInCurrentScope(0) {
+		t.Error("Expected only the slot 1 to be set in the current scope")
+	}
+	// the variables in a slot are not found by their name
+	if _, ok := scope.Get("b"); ok {
+		t.Error("Expected b not to be found by its name")
+	}
+	if scope.CheckIfVarExistsInCurrentScope("b") {
+		t.Error("Expected b not to be set by name")
+	}
+	if _, ok := scope.GetSlot(0, 0); ok {
+		t.Error("Expected the slot 0 of the current scope to be empty")
+	}
+	if _, ok := scope.GetSlot(0, 2); ok {
+		t.Error("Expected the slot 2 of the current scope not to exist")
+	}
+
+	scope.GoUp()
+	if _, ok := scope.GetSlot(0, 1); ok {
+		t.Error("Expected b to be removed with its scope")
+	}
+}
+
+func TestScope_GetSlotClosure(t *testing.T) {
+	scope := NewScopeMain()
+	scope.GoDeep(SCOPE_FUNCTION)
+	captured, _ := eclaType.NewVar("captured", "int", eclaType.Int(1))
+	scope.SetSlot(0, captured)
+	scope.GoDeep(SCOPE_CONDITION)
+	closure := scope.GetDeepestScope()
+	scope.GoUp()
+	scope.GoDeep(SCOPE_LOOP)
+	hidden, _ := eclaType.NewVar("hidden", "int", eclaType.Int(2))
+	scope.SetSlot(0, hidden)
+
+	scope.GoDeepWithClosure(closure)
+	scope.GoDeep(SCOPE_LOOP)
+	// the loop, the function scope, the captured condition and the function scope declaring captured
+	if v, ok := scope.GetSlot(3, 0); !ok || v != captured {
+		t.Error("Expected captured to be found in the scopes of the closure")
+	}
+	scope.GoUp()
+	scope.GoUp()
+	if v, ok := scope.GetSlot(0, 0); !ok || v != hidden {
+		t.Error("Expected hidden to be found once the closure scope is removed")
+	}
+}
+
+func TestScope_GetDeepestScope(t *testing.T) {
+	scope := NewScopeMain()
+	if scope.GetDeepestScope() != scope {
+		t.Error("Expected the main scope to be the deepest")
+	}
+	scope.GoDeep(SCOPE_LOOP)
+	loop := scope.GetDeepestScope()
+	scope.GoDeep(SCOPE_CONDITION)
+	scope.GoDeep(SCOPE_CONDITION)
+	scope.GoUpTo(loop)
+	if scope.GetDeepestScope() != loop {
+		t.Error("Expected the loop scope to be the deepest")
+	}
+
+	lib := NewScopeMain()
+	scope.GoDeepWithSpecificScope(lib)
+	scope.GoDeep(SCOPE_FUNCTION)
+	if scope.GetDeepestScope().previous != lib {
+		t.Error("Expected the function scope to be deeper than the library scope")
+	}
+	scope.GoUp()
+	scope.GoUp()
+	if scope.GetDeepestScope() != loop || loop.next != nil {
+		t.Error("Expected the library scope to be removed")
+	}
+
+	scope.SetNextScope(nil)
+	if scope.GetDeepestScope() != scope {
+		t.Error("Expected the main scope to be the deepest")
+	}
+}
+
+// benchmarkScope declares the variables of a function, then looks them up from the blocks nested in it,
+// by their name or by their slot.
+func benchmarkScope(b *testing.B, bySlot bool) {
+	names := []string{"a", "b", "c", "d", "e", "f"}
+	vars := make([]*eclaType.Var, len(names))
+	for i, name := range names {
+		vars[i], _ = eclaType.NewVar(name, "int", eclaType.Int(i))
+	}
+	scope := NewScopeMain()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		scope.GoDeep(SCOPE_FUNCTION)
+		for i, v := range vars {
+			if bySlot {
+				scope.SetSlot(i, v)
+			} else {
+				scope.Set(v.Name, v)
+			}
+		}
+		scope.GoDeep(SCOPE_LOOP)
+		scope.GoDeep(SCOPE_CONDITION)
+		for i, v := range vars {
+			if bySlot {
+				scope.GetSlot(2, i)
+			} else {
+				scope.Get(v.Name)
+			}
+		}
+		scope.GoUp()
+		scope.GoUp()
+		scope.GoUp()
+	}
+}
+
+func BenchmarkScope_Get(b *testing.B) {
+	benchmarkScope(b, false)
+}
+
+func BenchmarkScope_GetSlot(b *testing.B) {
+	benchmarkScope(b, true)
+}
@@ -0,0 +1,177 @@
+package interpreter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Eclalang/Ecla/interpreter/eclaType"
+	libs "github.com/Eclalang/LibraryController"
+	"github.com/Eclalang/LibraryController/utils"
+	"github.com/Eclalang/console"
+)
+
+// streams holds the input, the output and the diagnostics of the code, they are shared by the Env of its modules.
+type streams struct {
+	stdin  *inputReader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// inputReader is a buffered input which can be read by several Envs at once.
+type inputReader struct {
+	mu sync.Mutex
+	r  *bufio.Reader
+}
+
+// processStdin is the input of the process, every Env reading it shares its buffer so that none of them
+// steals the input buffered by another.
+var processStdin = &inputReader{r: bufio.NewReader(os.Stdin)}
+
+func (in *inputReader) Read(p []byte) (int, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.r.Read(p)
+}
+
+// readLine reads a line of the input without its end of line.
+func (in *inputReader) readLine() string {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	line, _ := in.r.ReadString('\n')
+	return strings.TrimRight(line, "\r\n")
+}
+
+// newStreams returns the streams of the process.
+func newStreams() *streams {
+	return &streams{
+		stdin:  processStdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+}
+
+// SetStdin sets the reader from which the code reads its input.
+func (env *Env) SetStdin(r io.Reader) {
+	env.streams.stdin = &inputReader{r: bufio.NewReader(r)}
+}
+
+// SetStdout sets the writer to which the code prints its output.
+func (env *Env) SetStdout(w io.Writer) {
+	env.streams.stdout = w
+}
+
+// SetStderr sets the writer to which the errors and the warnings are printed.
+func (env *Env) SetStderr(w io.Writer) {
+	env.streams.stderr = w
+	env.ErrorHandle.SetOutput(w)
+}
+
+// Stdin returns the reader from which the code reads its input.
+func (env *Env) Stdin() io.Reader {
+	return env.streams.stdin
+}
+
+// Stdout returns the writer to which the code prints its output.
+func (env *Env) Stdout() io.Writer {
+	return env.streams.stdout
+}
+
+// Stderr returns the writer to which the errors and the warnings are printed.
+func (env *Env) Stderr() io.Writer {
+	return env.streams.stderr
+}
+
+// readLine reads a line of the input without its end of line.
+func (env *Env) readLine() string {
+	return env.streams.stdin.readLine()
+}
+
+// importLib returns the library with the given name. The console and debugKingdom libraries, the only ones of
+// LibraryController reading or writing the terminal, are run on the streams of env instead of the ones of the process.
+func (env *Env) importLib(name string) libs.Lib {
+	switch name {
+	case "console":
+		return &consoleLib{env: env}
+	case "debugKingdom":
+		return &debugKingdomLib{env: env}
+	}
+	return libs.Import(name)
+}
+
+// clearScreen returns the sequence clearing the console.
+func clearScreen() string {
+	if runtime.GOOS == "windows" {
+		return "\033[H\033[2J"
+	}
+	return "\033[2J"
+}
+
+// consoleLib is the console library printing to the output and reading the input of an Env.
+type consoleLib struct {
+	env *Env
+}
+
+func (c *consoleLib) Call(name string, args []eclaType.Type) ([]eclaType.Type, error) {
+	newArgs := make([]any, len(args))
+	for k, arg := range args {
+		newArgs[k] = utils.EclaTypeToGo(arg)
+	}
+	out := c.env.Stdout()
+	switch name {
+	case "clear":
+		fmt.Fprint(out, clearScreen())
+	case "input":
+		return []eclaType.Type{utils.GoToEclaType(c.env.readLine())}, nil
+	case "inputFloat":
+		input, err := strconv.ParseFloat(c.env.readLine(), 64)
+		return []eclaType.Type{utils.GoToEclaType(input)}, err
+	case "inputInt":
+		input, err := strconv.Atoi(c.env.readLine())
+		return []eclaType.Type{utils.GoToEclaType(input)}, err
+	case "print":
+		fmt.Fprint(out, newArgs...)
+	case "printf":
+		if len(newArgs) == 0 {
+			return nil, errors.New("printf expects a format")
+		}
+		format, ok := newArgs[0].(string)
+		if !ok {
+			return nil, errors.New("the format of printf must be a string")
+		}
+		fmt.Fprintf(out, format, newArgs[1:]...)
+	case "printInColor":
+		if len(newArgs) == 0 {
+			return nil, errors.New("printInColor expects a color")
+		}
+		fmt.Fprint(out, newArgs[0])
+		fmt.Fprint(out, newArgs[1:]...)
+		fmt.Fprint(out, console.ColorOff)
+	case "println":
+		fmt.Fprintln(out, newArgs...)
+	default:
+		return nil, fmt.Errorf("Method %s not found in package console", name)
+	}
+	return []eclaType.Type{eclaType.Null{}}, nil
+}
+
+// debugKingdomLib is the debugKingdom library printing to the output of an Env.
+type debugKingdomLib struct {
+	env *Env
+}
+
+func (d *debugKingdomLib) Call(name string, args []eclaType.Type) ([]eclaType.Type, error) {
+	switch name {
+	case "clear":
+		fmt.Fprint(d.env.Stdout(), "\033[H\033[2J")
+	default:
+		return nil, fmt.Errorf("Method %s not found in package debugKingdom", name)
+	}
+	return []eclaType.Type{eclaType.Null{}}, nil
+}
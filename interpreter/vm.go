@@ -0,0 +1,215 @@
+package interpreter
+
+import (
+	"github.com/Eclalang/Ecla/errorHandler"
+	"github.com/Eclalang/Ecla/interpreter/eclaType"
+	"github.com/Eclalang/Ecla/parser"
+)
+
+// loopState is the state of a loop being executed by the vm.
+type loopState struct {
+	info *loopInfo
+	// scope is the deepest scope when the loop is entered, break and continue end the scopes deeper than it
+	scope *Scope
+	// rangeLoop is the iteration of a loop over a range, nil for the other loops
+	rangeLoop *rangeLoop
+}
+
+// frame is the execution of a chunk.
+type frame struct {
+	chunk *chunk
+	pc    int
+	stack []eclaType.Type
+	marks []int
+	loops []loopState
+}
+
+// getChunk returns the bytecode of the body, it is compiled the first time the body is executed.
+func (env *Env) getChunk(body []parser.Node) *chunk {
+	if env.chunks == nil {
+		env.chunks = make(map[*parser.Node]*chunk)
+	}
+	c, ok := env.chunks[&body[0]]
+	if !ok {
+		c = compile(body)
+		env.chunks[&body[0]] = c
+	}
+	return c
+}
+
+// RunBytecode compiles the body and executes it with the vm.
+// It returns the values returned by the body and true if a return statement was executed.
+func RunBytecode(body []parser.Node, env *Env) ([]eclaType.Type, bool) {
+	if len(body) == 0 {
+		return nil, false
+	}
+	f := &frame{chunk: env.getChunk(body)}
+	scope := env.Vars.GetDeepestScope()
+	values, returned := f.run(env)
+	if returned {
+		env.Vars.GoUpTo(scope)
+	}
+	return values, returned
+}
+
+func (f *frame) push(value eclaType.Type) {
+	f.stack = append(f.stack, value)
+}
+
+func (f *frame) pop() eclaType.Type {
+	value := f.stack[len(f.stack)-1]
+	f.stack = f.stack[:len(f.stack)-1]
+	return value
+}
+
+// popMark returns the values pushed since the last mark and removes them from the stack.
+func (f *frame) popMark() []eclaType.Type {
+	mark := f.marks[len(f.marks)-1]
+	f.marks = f.marks[:len(f.marks)-1]
+	values := append([]eclaType.Type(nil), f.stack[mark:]...)
+	f.stack = f.stack[:mark]
+	return values
+}
+
+// pushBus pushes the values of the bus collection as asked by values.
+func (f *frame) pushBus(BusCollection []*Bus, values int, node parser.Node, env *Env) {
+	switch values {
+	case valuesOne:
+		if len(BusCollection) != 1 {
+			env.ErrorHandle.HandleErrorAt(node, "MULTIPLE BUS IN vm.\nPlease open issue", errorHandler.LevelFatal)
+		}
+		f.push(BusCollection[0].GetVal())
+	case valuesAll:
+		for _, bus := range BusCollection {
+			f.push(bus.GetVal())
+		}
+	}
+}
+
+// jump propagates a break or a continue to the loop it targets.
+// The scopes of the loops left are ended, nothing is done if no loop of the frame is targeted.
+func (f *frame) jump(bus *Bus, env *Env) {
+	for i := len(f.loops) - 1; i >= 0; i-- {
+		loop := f.loops[i]
+		if !bus.Targets(loop.info.label) {
+			continue
+		}
+		env.Vars.GoUpTo(loop.scope)
+		f.loops = f.loops[:i+1]
+		if bus.IsBreak() {
+			f.pc = loop.info.breakPC
+		} else {
+			f.pc = loop.info.continuePC
+		}
+		return
+	}
+}
+
+// run executes the instructions of the frame.
+// It returns the values returned by the body and true if a return statement was executed.
+func (f *frame) run(env *Env) ([]eclaType.Type, bool) {
+	code := f.chunk.code
+	for f.pc < len(code) {
+		env.step(nil)
+		ins := code[f.pc]
+		f.pc++
+		switch ins.op {
+		case opConst:
+			f.push(f.chunk.consts[ins.arg])
+		case opLoad:
+			node := f.chunk.nodes[ins.n]
+			v, ok := env.lookupVar(node.(parser.Literal))
+			if !ok {
+				env.ErrorHandle.HandleErrorAt(node, "variable "+f.chunk.names[ins.arg]+" not found", errorHandler.LevelFatal)
+			}
+			f.push(v)
+		case opBinary:
+			right := f.pop()
+			left := f.pop()
+			t, err := binaryOperations[ins.arg](left, right)
+			if err != nil {
+				node := f.chunk.nodes[ins.n]
+				env.ErrorHandle.HandleErrorAt(node, err.Error(), errorHandler.LevelFatal)
+			}
+			f.push(t)
+		case opNeg:
+			t, err := eclaType.Int(0).Sub(f.pop())
+			if err != nil {
+				node := f.chunk.nodes[ins.arg]
+				env.ErrorHandle.HandleErrorAt(node, err.Error(), errorHandler.LevelFatal)
+			}
+			f.push(t)
+		case opNot:
+			t, err := f.pop().Not()
+			if err != nil {
+				node := f.chunk.nodes[ins.arg]
+				env.ErrorHandle.HandleErrorAt(node, err.Error(), errorHandler.LevelFatal)
+			}
+			f.push(t)
+		case opEval:
+			node := f.chunk.nodes[ins.arg]
+			f.pushBus(RunTree(node, env), ins.n, node, env)
+		case opMark:
+			f.marks = append(f.marks, len(f.stack))
+		case opCall:
+			args := f.popMark()
+			for i, arg := range args {
+				switch arg.(type) {
+				case *eclaType.Var:
+					args[i] = arg.(*eclaType.Var).GetValue().(eclaType.Type)
+				}
+			}
+			node := f.chunk.nodes[ins.arg]
+			f.pushBus(callFunctionExpr(node.(parser.FunctionCallExpr), args, env), ins.n, node, env)
+		case opDeclare:
+			declareVariable(f.chunk.nodes[ins.arg].(parser.VariableDecl), f.pop(), env)
+		case opAssign:
+			assignValues(f.chunk.nodes[ins.arg].(parser.VariableAssignStmt), f.popMark(), env)
+		case opExec:
+			BusCollection := RunTree(f.chunk.nodes[ins.arg], env)
+			if len(BusCollection) == 0 {
+				continue
+			}
+			bus := BusCollection[0]
+			switch {
+			case bus.IsReturn():
+				var values []eclaType.Type
+				for _, b := range BusCollection {
+					values = append(values, b.GetVal().GetValue().(eclaType.Type))
+				}
+				return values, true
+			case bus.IsBreak(), bus.IsContinue():
+				f.jump(bus, env)
+			}
+		case opJump:
+			f.pc = ins.arg
+		case opJumpIfFalse:
+			if f.pop().GetString() != "true" {
+				f.pc = ins.arg
+			}
+		case opEnterScope:
+			env.NewScope(ScopeType(ins.arg))
+		case opExitScope:
+			env.EndScope()
+		case opLoop:
+			f.loops = append(f.loops, loopState{info: &f.chunk.loops[ins.arg], scope: env.Vars.GetDeepestScope()})
+		case opEndLoop:
+			f.loops = f.loops[:len(f.loops)-1]
+		case opBreak:
+			f.jump(NewBreakBus(f.chunk.names[ins.arg]), env)
+		case opContinue:
+			f.jump(NewContinueBus(f.chunk.names[ins.arg]), env)
+		case opRange:
+			f.loops[len(f.loops)-1].rangeLoop = newRangeLoop(f.chunk.nodes[ins.arg].(parser.ForStmt), f.pop(), env)
+		case opNext:
+			if !f.loops[len(f.loops)-1].rangeLoop.next(f.chunk.nodes[ins.n].(parser.ForStmt), env) {
+				f.pc = ins.arg
+			}
+		case opInterpolate:
+			str := interpolate(f.stack[len(f.stack)-ins.arg:])
+			f.stack = f.stack[:len(f.stack)-ins.arg]
+			f.push(str)
+		}
+	}
+	return nil, false
+}
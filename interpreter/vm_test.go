@@ -0,0 +1,146 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/Eclalang/Ecla/errorHandler"
+)
+
+// runBothEngines executes the code with the tree walker and with the vm and checks that the variables have the same values.
+func runBothEngines(t *testing.T, code string, names []string) {
+	t.Helper()
+	walker := NewEnv()
+	walker.SetCode(code)
+	walker.Execute()
+	vm := NewEnv()
+	vm.Bytecode = true
+	vm.SetCode(code)
+	vm.Execute()
+	for _, name := range names {
+		expected, ok := walker.GetVar(name)
+		if !ok {
+			t.Errorf("Expected variable %s with the tree walker, got nil", name)
+			continue
+		}
+		v, ok := vm.GetVar(name)
+		if !ok {
+			t.Errorf("Expected variable %s with the vm, got nil", name)
+			continue
+		}
+		if v.String() != expected.String() {
+			t.Errorf("Expected %s, got %s", expected.String(), v.String())
+		}
+	}
+}
+
+func TestRunBytecode(t *testing.T) {
+	runBothEngines(t, `
+	function fib(n : int) (int) {
+		if (n < 2) {
+			return n;
+		}
+		return fib(n - 1) + fib(n - 2);
+	}
+	function swap(a : int, b : int) (int, int) {
+		return b, a;
+	}
+	var f int = fib(15);
+	var x int = 0;
+	var y int = 0;
+	x, y = swap(1, 2);
+	var grid [][]int = [[0, 1], [1, 0]];
+	var alive int = 0;
+	for (r, row range grid) {
+		for (c, cell range row) {
+			alive += cell;
+		}
+	}
+	var letters string = "";
+	for (i, ch range "ecla") {
+		if (ch == 'c') {
+			continue;
+		}
+		letters += ch;
+	}
+	var total int = 0;
+	outer: for (var i int = 0, i < 5, i++) {
+		var j int = 0;
+		while (j < 5) {
+			j++;
+			if (j == 3) {
+				continue outer;
+			}
+			if (i == 3) {
+				break outer;
+			}
+			total += i * j;
+		}
+	}
+	var sign string = "";
+	if (-x > 0) {
+		sign = "positive";
+	} else if (!(x == 0)) {
+		sign = "negative";
+	} else {
+		sign = "zero";
+	}
+	var add function(int)(int) = function(a : int) (int) {
+		return a + f;
+	};
+	var added int = add(1);
+	var caught int = 0;
+	for (k, v range {"a": 1, "b": 2}) {
+		try {
+			throw "stop";
+		} catch (e) {
+			caught += v;
+			break;
+		}
+	}
+	`, []string{"f", "x", "y", "alive", "letters", "total", "sign", "added", "caught"})
+}
+
+func TestRunBytecodeDemo(t *testing.T) {
+	vm := NewEnv()
+	vm.Bytecode = true
+	vm.SetFile("../DEMO/AllTests.ecla")
+	vm.Execute()
+}
+
+func TestRunBytecodeError(t *testing.T) {
+	exited := false
+	env := NewEnv()
+	env.Bytecode = true
+	env.ErrorHandle.HookExit(func(int) {
+		exited = true
+	})
+	defer env.ErrorHandle.RestoreExit()
+	env.SetCode(`
+	var caught string = "";
+	for (i := 0, i < 3, i++) {
+		try {
+			var a int = 1 + missing;
+		} catch (e) {
+			caught = e.message;
+		}
+	}
+	var b int = 1 - "a";
+	`)
+	env.Execute()
+	v, ok := env.GetVar("caught")
+	if !ok || v.String() != "caught = variable missing not found" {
+		t.Errorf("Expected the error to be caught, got %v", v)
+	}
+	if !exited {
+		t.Error("Expected the vm to exit on an uncaught error")
+	}
+	reported := false
+	for _, err := range env.ErrorHandle.Errors {
+		if err.Level == errorHandler.LevelFatal && err.Line == 10 {
+			reported = true
+		}
+	}
+	if !reported {
+		t.Errorf("Expected a fatal error at line 10, got %v", env.ErrorHandle.Errors)
+	}
+}
@@ -0,0 +1,136 @@
+package lexer
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// EscapeError is an invalid escape sequence in the value of a string or of a char,
+// Offset is the index of its backslash in the value.
+type EscapeError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *EscapeError) Error() string {
+	return e.Msg
+}
+
+// Unquote returns the value of a STRING or of a CHAR token, quoted by quote, with its escape sequences
+// replaced by the characters they stand for:
+//
+//	\n \t \r \\ \" \' \0   newline, tab, carriage return, backslash, quotes and NUL
+//	\$                     a $, which does not start an interpolation when it is followed by a {
+//	\xHH                   the ASCII character HH, between \x00 and \x7F
+//	\u{HHHH}               the unicode code point HHHH, made of 1 to 6 hexadecimal digits
+//	\NNN                   the character of the 3 octal digits NNN, like \033, kept for the older scripts
+func Unquote(value string, quote byte) (string, error) {
+	if strings.IndexByte(value, '\\') == -1 && strings.IndexByte(value, quote) == -1 {
+		return value, nil
+	}
+	var result strings.Builder
+	result.Grow(len(value))
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == quote {
+			return "", &EscapeError{i, "unescaped " + string(quote) + " in " + quoted(quote)}
+		}
+		if c != '\\' {
+			result.WriteByte(c)
+			continue
+		}
+		r, size, err := unescape(value[i:])
+		if err != nil {
+			err.Offset += i
+			return "", err
+		}
+		result.WriteRune(r)
+		i += size - 1
+	}
+	return result.String(), nil
+}
+
+// unescape decodes the escape sequence at the start of sequence, it returns the character and the
+// length of the sequence.
+func unescape(sequence string) (rune, int, *EscapeError) {
+	if len(sequence) < 2 {
+		return 0, 0, &EscapeError{0, "unterminated escape sequence"}
+	}
+	switch sequence[1] {
+	case 'n':
+		return '\n', 2, nil
+	case 't':
+		return '\t', 2, nil
+	case 'r':
+		return '\r', 2, nil
+	case '\\', '"', '\'', '$':
+		return rune(sequence[1]), 2, nil
+	case 'x':
+		if len(sequence) < 4 || !isHex(sequence[2:4]) {
+			return 0, 0, &EscapeError{0, `\x must be followed by two hexadecimal digits`}
+		}
+		code, _ := strconv.ParseUint(sequence[2:4], 16, 8)
+		if code > utf8.RuneSelf-1 {
+			return 0, 0, &EscapeError{0, `\` + sequence[1:4] + ` is not an ASCII character, use \u{` + sequence[2:4] + `} instead`}
+		}
+		return rune(code), 4, nil
+	case 'u':
+		end := strings.IndexByte(sequence, '}')
+		if len(sequence) < 3 || sequence[2] != '{' || end == -1 || end-3 < 1 || end-3 > 6 || !isHex(sequence[3:end]) {
+			return 0, 0, &EscapeError{0, `\u must be followed by 1 to 6 hexadecimal digits between braces, like \u{1F600}`}
+		}
+		code, _ := strconv.ParseUint(sequence[3:end], 16, 32)
+		if !utf8.ValidRune(rune(code)) {
+			return 0, 0, &EscapeError{0, sequence[:end+1] + " is not a unicode code point"}
+		}
+		return rune(code), end + 1, nil
+	case '0', '1', '2', '3':
+		if len(sequence) >= 4 && isOctal(sequence[2:4]) {
+			code, _ := strconv.ParseUint(sequence[1:4], 8, 8)
+			return rune(code), 4, nil
+		}
+		if sequence[1] == '0' {
+			return 0, 2, nil
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(sequence[1:])
+	return 0, 0, &EscapeError{0, `unknown escape sequence \` + string(r)}
+}
+
+// quoted returns the name of the literals quoted by quote
+func quoted(quote byte) string {
+	if quote == '\'' {
+		return "char"
+	}
+	return "string"
+}
+
+func isHex(digits string) bool {
+	for i := 0; i < len(digits); i++ {
+		c := digits[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+func isOctal(digits string) bool {
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '7' {
+			return false
+		}
+	}
+	return true
+}
+
+// escapedQuote returns true when the quote following content is escaped, which is when content
+// ends with an odd number of backslashes.
+func escapedQuote(content string) bool {
+	backslashes := 0
+	for i := len(content) - 1; i >= 0 && content[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
@@ -0,0 +1,89 @@
+package lexer
+
+import "testing"
+
+func TestUnquote(t *testing.T) {
+	tests := []struct {
+		value    string
+		quote    byte
+		expected string
+	}{
+		{`hello`, '"', "hello"},
+		{`a\nb\tc\rd`, '"', "a\nb\tc\rd"},
+		{`\\ \" \'`, '"', `\ " '`},
+		{`\'`, '\'', "'"},
+		{`a\0b`, '"', "a\x00b"},
+		{`\x1Bc`, '"', "\x1bc"},
+		{`\x7f`, '"', "\x7f"},
+		{`\u{e9}t\u{E9}`, '"', "été"},
+		{`\u{1F600}`, '\'', "😀"},
+		{`\u{10FFFF}`, '"', "\U0010FFFF"},
+		{`\033[0;31m`, '"', "\x1b[0;31m"},
+		{`\012`, '"', "\n"},
+		{`\09`, '"', "\x009"},
+		{`é'`, '"', "é'"},
+		{`"`, '\'', `"`},
+		{`\${a}`, '"', "${a}"},
+	}
+	for _, test := range tests {
+		got, err := Unquote(test.value, test.quote)
+		if err != nil {
+			t.Errorf("Unquote(%q) returned the error %v", test.value, err)
+		} else if got != test.expected {
+			t.Errorf("Unquote(%q) = %q, expected %q", test.value, got, test.expected)
+		}
+	}
+}
+
+func TestUnquote_Errors(t *testing.T) {
+	tests := []struct {
+		value  string
+		quote  byte
+		offset int
+	}{
+		{`a\q`, '"', 1},
+		{`ab\`, '"', 2},
+		{`\x`, '"', 0},
+		{`é\xg1`, '"', 2},
+		{`\x80`, '"', 0},
+		{`\é`, '"', 0},
+		{`\u{}`, '"', 0},
+		{`\u{1234567}`, '"', 0},
+		{`\u{12`, '"', 0},
+		{`\u{D800}`, '"', 0},
+		{`\u{110000}`, '"', 0},
+		{`\8`, '"', 0},
+		{`a"b`, '"', 1},
+		{`'`, '\'', 0},
+	}
+	for _, test := range tests {
+		_, err := Unquote(test.value, test.quote)
+		escapeErr, ok := err.(*EscapeError)
+		if !ok {
+			t.Errorf("Unquote(%q) did not return an EscapeError but %v", test.value, err)
+		} else if escapeErr.Offset != test.offset {
+			t.Errorf("Unquote(%q) reported the offset %d instead of %d", test.value, escapeErr.Offset, test.offset)
+		}
+	}
+}
+
+func TestLexer_EscapedQuotes(t *testing.T) {
+	tests := []struct {
+		code  string
+		value string
+	}{
+		{`"a\"b";`, `a\"b`},
+		{`"a\\";`, `a\\`},
+		{`"a\\\"";`, `a\\\"`},
+		{`'\'';`, `\'`},
+		{`'\\';`, `\\`},
+		{`'\u{1F600}';`, `\u{1F600}`},
+		{`'{a}';`, `{a}`},
+	}
+	for _, test := range tests {
+		tokens := Lexer(test.code)
+		if len(tokens) != 5 || tokens[1].Value != test.value || tokens[2].TokenType != tokens[0].TokenType || tokens[3].TokenType != EOL {
+			t.Errorf("%s: expected the quoted value %s followed by its closing quote, got %v", test.code, test.value, tokens)
+		}
+	}
+}
@@ -1,486 +1,599 @@
 package lexer
 
+import (
+	"strings"
+	"unicode/utf8"
+)
+
 // Token is a struct that contains all the information about a token
 type Token struct {
 	TokenType string
 	Value     string
-	Position  int
-	Line      int
+	// Position and Line are the column and the line of the first character of the token, from 1
+	Position int
+	Line     int
+	// EndPosition and EndLine are the column and the line right after the last character of the token
+	EndPosition int
+	EndLine     int
+	// Offset and EndOffset are the index of the first byte of the token in the code and the index right after its
+	// last byte, the token is read from code[Offset:EndOffset]
+	Offset    int
+	EndOffset int
 }
 
 // Lexer do a lexical analysis of the string sentence to separate each element,
 // and associate each element with a token
 func Lexer(sentence string) []Token {
+	s := scanner{
+		sentence:   sentence,
+		line:       1,
+		startLine:  1,
+		commentEnd: -1,
+		groupEnd:   -1,
+		// most of the tokens are a few characters long
+		tokens: make([]Token, 0, len(sentence)/3+1),
+	}
+	for s.offset < len(s.sentence) {
+		s.next()
+	}
+	return s.end()
+}
 
-	// ret is the []Token that the lexer will return
-	var ret []Token
-
-	// prevIndex is index of the start of the element that we want to compare
-	// with the known syntaxe
-	var prevIndex int = 0
-	var actualIndex int = 1
-	// line will be increase each time a ";" is founded
-	var line int = 0
-	// canBeText is false when an element is already considered as a known
-	// syntaxe, and true elsewhere
-	var canBeText bool
-	var isSpaces bool
-	var inQuote bool
-	var inQuoteStep bool
-	var QuoteIdentifier string
-	var endOfComm int = -1
-	var endOfCommGroup int = -1
-
-	// tempVal is the current element that we want to compare with the known
-	// syntaxe
-	var tempVal string
-
-	for i := 0; i <= len(sentence); i++ {
-		// we assign tempVal as an element in the interval [prevIndex:i]
-		tempVal = sentence[prevIndex:i]
-		// we assign canBeText to true, because we actually don't know if the
-		// current element is a text or not
-		canBeText = true
-		inQuoteStep = false
-
-		for _, ident := range Identifier {
-			// -----------Is Known Syntaxe Part-------------
-			//
-			// for each element of Identifier, we compare all the known
-			// syntaxes with our tempVal, If the comparison is true,
-			// tempVal is a known syntaxes, and then a token
-			if ident.IsSyntaxe(tempVal) {
-
-				// canot be a text now
-				canBeText = false
-				if (ident.Identifier == COMMENT || ident.Identifier == COMMENTGROUP) && inQuote && len(ret) != 0 {
-					break
-				}
-				// -----------Previous Token COMMENT Part-------------
-				if len(ret) > 1 {
-					// if the previous token is a COMMENT, we must concat the actual value to the previous
-					// token instead of create a new one.
-					// we don't concat only if it's the end of the COMMENT token.
-					// if we stop concat the value with the COMMENT, we keep the COMMENT token index,
-					// like that we can be sure to not append something else in.
-					if ret[len(ret)-1].TokenType == COMMENT && len(ret)-1 != endOfComm && !inQuote {
-						if tempVal == "/" && ret[len(ret)-1].Value == "" {
-							ret[len(ret)-1].TokenType = COMMENTGROUP
-							break
-						} else if tempVal != "\n" && tempVal != "\r" {
-							ret[len(ret)-1].Value += tempVal
-						} else {
-							endOfComm = len(ret) - 1
-						}
-						tempVal = ""
-						prevIndex = i
-						break
-					}
-					// same things for the COMMENTGROUP, but with a different ending close.
-					if ret[len(ret)-1].TokenType == COMMENTGROUP && len(ret)-1 != endOfCommGroup && !inQuote {
-						if tempVal[len(tempVal)-1] == '/' {
-							break
-						} else if len(tempVal) > 1 {
-							if tempVal[len(tempVal)-2:] == "/#" {
-								endOfCommGroup = len(ret) - 1
-							} else {
-								ret[len(ret)-1].Value += tempVal
-							}
-						} else {
-							ret[len(ret)-1].Value += tempVal
-						}
-						tempVal = ""
-						prevIndex = i
-						break
-					}
-				}
-				// -----------Previous Token COMMENT Part END-------------
-				// -----------Quote Token Part-------------
-				// we ignore the "spaces" TOKEN, wo include " ","\r","\n" and more to
-				// only be include in string TOKEN
-				if ident.Identifier == "" && !inQuote {
-					isSpaces = true
-					prevIndex = i
-					tempVal = sentence[prevIndex:i]
-					break
-				}
-				if ident.Identifier == DQUOTE && (QuoteIdentifier != "'" && !inQuoteStep) {
-
-					// if the current lecture head is inside a string, we must be carefull about \", cause
-					// it does not end the current string.
-					// if we have a token DQUOTE without being in a string, its the start of a new string
-					if inQuote && QuoteIdentifier == "\"" {
-						if ret[len(ret)-1].Value[len(ret[len(ret)-1].Value)-1] != '\\' {
-							inQuote = false
-							QuoteIdentifier = ""
-						}
-					} else {
-						inQuote = true
-						inQuoteStep = true
-						QuoteIdentifier = "\""
-					}
-				}
-				if ident.Identifier == SQUOTE && (QuoteIdentifier != "\"" && !inQuoteStep) {
-
-					// if the current lecture head is inside a char, we must be carefull about \", cause
-					// it does not end the current string.
-					// if we have a token SQUOTE without being in a char, its the start of a new char
-					if inQuote && QuoteIdentifier == "'" {
-						if ret[len(ret)-1].Value[len(ret[len(ret)-1].Value)-1] != '\\' {
-							inQuote = false
-							QuoteIdentifier = ""
-						}
-					} else {
-						inQuote = true
-						inQuoteStep = true
-						QuoteIdentifier = "'"
-					}
-				}
-				// -----------Quote Token Part END-------------
-
-				// -----------Special Token Part-------------
-				//
-				// we change our behavior in case of some special TOKEN, like ASSIGN, cause he can be a part of
-				// a complexe token (ADDASSIGN for exemple)
-				beforeChangeVal := tempVal
-				ret = tokenCommentGroup(ident, ret, &prevIndex, &tempVal, i)
-				ret = tokenComment(ident, ret, &prevIndex, &tempVal, i, line, sentence)
-				ret = tokenInt(ident, ret, &prevIndex, &tempVal, i, isSpaces)
-				ret = tokenPeriod(ident, ret, &prevIndex, &tempVal, i, isSpaces, inQuote, sentence)
-				ret = tokenAssign(ident, ret, &prevIndex, &tempVal, i, isSpaces)
-				ret = tokenDiv(ident, ret, &prevIndex, &tempVal, i)
-				ret = tokenAddSub(ident, ret, &prevIndex, &tempVal, i, isSpaces, ADD, INC)
-				ret = tokenAddSub(ident, ret, &prevIndex, &tempVal, i, isSpaces, SUB, DEC)
-				ret = tokenAddSub(ident, ret, &prevIndex, &tempVal, i, isSpaces, XORBIN, XOR)
-				if beforeChangeVal != tempVal {
-					break
-				}
-				// ---------Special Token Part END-----------
-
-				// ---------Normal Token Part END-----------
-				//
-				// append a new Token to the variable ret
-
-				if QuoteIdentifier == "\"" {
-					if inQuote {
-						if inQuote && tempVal == "\n" {
-							ret = inQuoteChange(STRING, QuoteIdentifier, inQuote && !inQuoteStep, ret, ident, tempVal, prevIndex, sentence)
-							QuoteIdentifier = ""
-							inQuote = false
-						} else {
-							ret = inQuoteChange(STRING, QuoteIdentifier, inQuote && !inQuoteStep, ret, ident, tempVal, prevIndex, sentence)
-						}
-					} else {
-						ret = inQuoteChange(STRING, QuoteIdentifier, inQuote && !inQuoteStep, ret, ident, tempVal, prevIndex, sentence)
-						QuoteIdentifier = ""
-					}
-				} else if QuoteIdentifier == "'" {
-					if inQuote {
-						if inQuote && tempVal == "\n" {
-							ret = inQuoteChange(CHAR, QuoteIdentifier, inQuote && !inQuoteStep, ret, ident, tempVal, prevIndex, sentence)
-							QuoteIdentifier = ""
-							inQuote = false
-						} else {
-							ret = inQuoteChange(CHAR, QuoteIdentifier, inQuote && !inQuoteStep, ret, ident, tempVal, prevIndex, sentence)
-						}
-					} else {
-						ret = inQuoteChange(CHAR, QuoteIdentifier, inQuote && !inQuoteStep, ret, ident, tempVal, prevIndex, sentence)
-						QuoteIdentifier = ""
-					}
-				} else {
-					ret = inQuoteChange(ident.Identifier, QuoteIdentifier, inQuote && !inQuoteStep, ret, ident, tempVal, prevIndex, sentence)
-				}
-
-				isSpaces = false
-
-				tempVal = ""
-				prevIndex = i
-				// ---------Normal Token Part END-----------
-			}
-			// -----------Is Known Syntaxe Part END-------------
-
-			// -----------Previous Token COMMENT Part Again-------------
-			//
-			// we must check again if the previous one is a COMMENT in case its a text, or the end of the lexing.
-			if len(ret) >= 1 {
-				if ret[len(ret)-1].TokenType == COMMENT && len(ret)-1 != endOfComm && !inQuote {
-					if tempVal == "/" && ret[len(ret)-1].Value == "" {
-						ret[len(ret)-1].TokenType = COMMENTGROUP
-					} else if tempVal != "\n" && tempVal != "\r" {
-						ret[len(ret)-1].Value += tempVal
-					} else {
-						endOfComm = len(ret) - 1
-					}
-					canBeText = false
-					tempVal = ""
-					prevIndex = i
-					break
-				}
-				if ret[len(ret)-1].TokenType == COMMENTGROUP && len(ret)-1 != endOfCommGroup && !inQuote {
-					canBeText = false
-					if tempVal[len(tempVal)-1] == '/' {
-						break
-					} else if len(tempVal) > 1 {
-						if tempVal[len(tempVal)-2:] == "/#" {
-							endOfCommGroup = len(ret) - 1
-						} else {
-							ret[len(ret)-1].Value += tempVal
-						}
-					} else {
-						ret[len(ret)-1].Value += tempVal
-					}
-					tempVal = ""
-					prevIndex = i
-					break
-				}
-			}
-			// -----------Previous Token COMMENT Part Again END-------------
+// scanner reads the sentence in one pass, one character at a time, and classifies each
+// character with a switch instead of comparing the element with every known syntaxe.
+type scanner struct {
+	sentence string
+	tokens   []Token
+
+	// offset is the index of the next character, col and line its position
+	offset int
+	col    int
+	line   int
+
+	// start is the index of the element not yet associated with a token, the text is
+	// sentence[start:offset], startCol and startLine are its position
+	start     int
+	startCol  int
+	startLine int
+
+	// isSpaces is true when spaces separate the previous token from the current element
+	isSpaces bool
+	// quote is the quote of the string or of the char being read, 0 outside of them
+	quote   byte
+	inQuote bool
+	// interpolations are the number of braces opened in each expression interpolated in a string,
+	// the expression being read is the last one
+	interpolations []int
+	// commentEnd and groupEnd are the index of the last ended COMMENT and COMMENTGROUP tokens
+	commentEnd int
+	groupEnd   int
+	// valueEnd is the index of the end of the value of the last token, which is a part of the sentence,
+	// -1 when the value is made of several parts
+	valueEnd int
+}
+
+// keywords are the syntaxes of more than one character, they can end a text
+var keywords = [...]struct {
+	syntax    string
+	tokenType string
+}{
+	{"&&", AND},
+	{"||", OR},
+	{"mgrlgrl", MURLOC},
+	{"true", BOOL},
+	{"false", BOOL},
+}
+
+// syntaxOf returns the token type of the character c when it is a syntax on its own,
+// spaces is true for the characters separating the tokens
+func syntaxOf(c byte) (tokenType string, spaces bool, ok bool) {
+	switch c {
+	case '+':
+		return ADD, false, true
+	case '-':
+		return SUB, false, true
+	case '*':
+		return MULT, false, true
+	case '/':
+		return DIV, false, true
+	case '%':
+		return MOD, false, true
+	case '=':
+		return ASSIGN, false, true
+	case '>':
+		return GTR, false, true
+	case '<':
+		return LSS, false, true
+	case '^':
+		return XORBIN, false, true
+	case '(':
+		return LPAREN, false, true
+	case ')':
+		return RPAREN, false, true
+	case ';':
+		return EOL, false, true
+	case '"':
+		return DQUOTE, false, true
+	case '\'':
+		return SQUOTE, false, true
+	case '`':
+		return BACKQUOTE, false, true
+	case '.':
+		return PERIOD, false, true
+	case ':':
+		return COLON, false, true
+	case ',':
+		return COMMA, false, true
+	case '{':
+		return LBRACE, false, true
+	case '}':
+		return RBRACE, false, true
+	case '[':
+		return LBRACKET, false, true
+	case ']':
+		return RBRACKET, false, true
+	case '#':
+		return COMMENT, false, true
+	case '!':
+		return NOT, false, true
+	case ' ', '\n', '\t', '\r':
+		return "", true, true
+	}
+	return "", false, false
+}
+
+// next reads the next character of the sentence
+func (s *scanner) next() {
+	c := s.sentence[s.offset]
+	size := 1
+	if c >= utf8.RuneSelf {
+		_, size = utf8.DecodeRuneInString(s.sentence[s.offset:])
+	}
+	col, line := s.col, s.line
+	s.offset += size
+	if c == '\n' {
+		s.col, s.line = 0, s.line+1
+	} else {
+		s.col++
+	}
+
+	// -----------Comment Part-------------
+	//
+	// a COMMENT takes every character until the end of the line,
+	// a COMMENTGROUP takes every character until the closing /#
+	if last := len(s.tokens) - 1; last >= 0 && !s.inQuote {
+		if s.tokens[last].TokenType == COMMENT && last != s.commentEnd {
+			s.comment(c)
+			return
 		}
-		// -----------Can still be text Part-------------
-		//
-		// if after checking all the known syntaxe, the tempValue can still
-		// be a TEXT, we parse the tempValue backward to verifies if
-		// a substring of tempValue can also be a known syntaxe
-		if canBeText {
-			for y := len(tempVal) - 1; y >= 0; y-- {
-				for _, ident := range Identifier {
-					if ident.Identifier != INT {
-						if ident.IsSyntaxe(tempVal[y:]) {
-							canBeText = false
-							ret = inQuoteChange(STRING, QuoteIdentifier, inQuote && !inQuoteStep, ret, Identifier[0], tempVal[:y], prevIndex, sentence)
-							i += y - len(tempVal)
-							isSpaces = false
-							prevIndex = i
-						}
-					}
-
-				}
-			}
+		if s.tokens[last].TokenType == COMMENTGROUP && last != s.groupEnd {
+			s.commentGroup()
+			return
 		}
-		// -----------Can still be text Part END-------------
 	}
-	// -----------End of lexer Part-------------
+
+	// -----------Syntax Part-------------
 	//
-	// if at the end of the sentence parse, tempVal is not "", it means that
-	// a last token of type TEXT must be appended to the return value
-	if len(ret) > 0 {
-		if ret[len(ret)-1].TokenType == COMMENTGROUP && tempVal == "/" {
-			ret[len(ret)-1].Value += tempVal
-		} else if tempVal != "" {
-			actualIndex, line = positionDetector(prevIndex, sentence)
-			ret = append(ret, addToken(Identifier[0].Identifier, tempVal, actualIndex, line))
-
-			prevIndex += len(tempVal)
+	// a syntax of one character ends the text being read, an INT only starts a new element
+	tokenType, spaces, ok := syntaxOf(c)
+	if c >= '0' && c <= '9' && s.start == s.offset-size {
+		if !s.inQuote && !s.continuesNumber() {
+			s.number()
+			return
 		}
-	} else if tempVal != "" {
-		actualIndex, line = positionDetector(prevIndex, sentence)
-		ret = append(ret, addToken(Identifier[0].Identifier, tempVal, actualIndex, line))
-
-		prevIndex += len(tempVal)
+		tokenType, ok = INT, true
+	}
+	if ok {
+		if s.start < s.offset-size {
+			s.flush(s.offset-size, col, line)
+			s.start, s.startCol, s.startLine = s.offset-size, col, line
+		}
+		s.syntax(tokenType, spaces)
+		return
 	}
 
-	// created a last token of type EOF (EndOfFile)
-	actualIndex, line = positionDetector(prevIndex, sentence)
-	ret = append(ret, addToken(Identifier[len(Identifier)-1].Identifier, "", actualIndex, line))
+	// -----------Text Part-------------
+	//
+	// the character is a part of a text, which can end with a keyword
+	if c != '&' && c != '|' && c != 'l' && c != 'e' {
+		return
+	}
+	text := s.sentence[s.start:s.offset]
+	for _, keyword := range keywords {
+		if strings.HasSuffix(text, keyword.syntax) {
+			if len(text) > len(keyword.syntax) {
+				start, col := s.offset-len(keyword.syntax), s.col-len(keyword.syntax)
+				s.flush(start, col, s.line)
+				s.start, s.startCol, s.startLine = start, col, s.line
+			}
+			s.syntax(keyword.tokenType, false)
+			return
+		}
+	}
+}
 
-	return ret
-	// -----------End of lexer Part END-------------
+// continuesNumber returns true when the digit read is a part of the previous token, which is right before it
+func (s *scanner) continuesNumber() bool {
+	if len(s.tokens) == 0 || s.isSpaces {
+		return false
+	}
+	last := s.tokens[len(s.tokens)-1].TokenType
+	return last == INT || last == TEXT || last == FLOAT
 }
 
-func inQuoteChange(ttoken string, PreviousQuote string, inQuote bool, ret []Token, identi identifier, val string, prevIndex int, sentence string) []Token {
-	actualIndex, line := positionDetector(prevIndex, sentence)
-	if inQuote {
-		if len(ret) >= 1 {
-			if ret[len(ret)-1].TokenType == ttoken {
-				ret[len(ret)-1].Value += val
-			} else {
-				if PreviousQuote == "'" {
-					ret = append(ret, addToken(CHAR, val, actualIndex, line))
-				} else if PreviousQuote == "\"" {
-					ret = append(ret, addToken(STRING, val, actualIndex, line))
-				}
-			}
-		} else {
-			if PreviousQuote == "'" {
-				ret = append(ret, addToken(CHAR, val, actualIndex, line))
-			} else if PreviousQuote == "\"" {
-				ret = append(ret, addToken(STRING, val, actualIndex, line))
+// number reads the numeric literal starting with the digit read. The prefixes 0x, 0o and 0b start
+// the hexadecimal, octal and binary INTs, a fraction after a . or an exponent after an e makes a FLOAT
+// and _ can separate the digits. A malformed literal is still a single token, reported by the parser.
+func (s *scanner) number() {
+	end := s.offset
+	tokenType := INT
+	if s.sentence[s.start] == '0' && end < len(s.sentence) && strings.IndexByte("xXoObB", s.sentence[end]) != -1 {
+		end = s.skipDigits(end+1, true)
+	} else {
+		end = s.skipDigits(end, false)
+		if end < len(s.sentence) && s.sentence[end] == '.' {
+			tokenType = FLOAT
+			end = s.skipDigits(end+1, false)
+		}
+		if end < len(s.sentence) && (s.sentence[end] == 'e' || s.sentence[end] == 'E') {
+			if end+1 < len(s.sentence) && strings.IndexByte("0123456789_+-", s.sentence[end+1]) != -1 {
+				tokenType = FLOAT
+				end = s.skipDigits(end+2, false)
+			} else if end+1 == len(s.sentence) || !isLetter(s.sentence[end+1]) {
+				// an exponent without digits is a malformed FLOAT, unless the e starts a word like in 1else
+				tokenType = FLOAT
+				end++
 			}
 		}
-	} else {
-		ret = append(ret, addToken(identi.Identifier, val, actualIndex, line))
 	}
-	return ret
+	s.col += end - s.offset
+	s.offset = end
+	s.add(tokenType, s.sentence[s.start:end])
+	s.isSpaces = false
+	s.skip()
 }
 
-// positionDetector find the current position and line of the token we want to create.
-// Take our current []Token, the index of lexing, and the global sentence.
-//
-// return position, line
-func positionDetector(prevIndex int, sentence string) (int, int) {
-	var toRet = 0
-	var line = 1
-	for _, v := range sentence[:prevIndex] {
-		if v == '\n' {
-			toRet = 0
-			line += 1
-		} else {
-			toRet += 1
+// skipDigits returns the index of the first character from the index i which is not a digit or a _,
+// the letters are read as digits when letters is true
+func (s *scanner) skipDigits(i int, letters bool) int {
+	for ; i < len(s.sentence); i++ {
+		c := s.sentence[i]
+		if !(c >= '0' && c <= '9' || c == '_' || letters && isLetter(c)) {
+			break
 		}
 	}
-	return toRet + 1, line
+	return i
 }
 
-// addToken create a new token with the given parameters
-//
-// return the created token
-func addToken(TokenType string, Value string, Position int, Line int) Token {
-	var ret Token
+// isLetter returns true if the character c is an ASCII letter
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
 
-	ret.TokenType = TokenType
-	ret.Value = Value
-	ret.Position = Position
-	ret.Line = Line
+// comment adds the character c to the COMMENT token, the end of the line ends it
+// and a / right after the # makes it a COMMENTGROUP
+func (s *scanner) comment(c byte) {
+	last := &s.tokens[len(s.tokens)-1]
+	value := s.sentence[s.start:s.offset]
+	if c == '/' && last.Value == "" {
+		last.TokenType = COMMENTGROUP
+		s.stretch(s.offset, s.col, s.line)
+	} else if c != '\n' && c != '\r' {
+		s.extend(value)
+	} else {
+		s.commentEnd = len(s.tokens) - 1
+	}
+	s.skip()
+}
 
-	return ret
+// commentGroup adds the characters read to the COMMENTGROUP token, the /# ends it
+func (s *scanner) commentGroup() {
+	value := s.sentence[s.start:s.offset]
+	if value[len(value)-1] == '/' {
+		// the / can be the start of the closing /#
+		return
+	}
+	if len(value) > 1 && strings.HasSuffix(value, "/#") {
+		s.groupEnd = len(s.tokens) - 1
+		s.stretch(s.offset, s.col, s.line)
+	} else {
+		s.extend(value)
+	}
+	s.skip()
 }
 
-// tokenAddSub replace the previous token.tokenType in ret to toReplace if the current token.tokenType is equal to toFind.
-//
-// return the changed []Token
-func tokenAddSub(ident identifier, ret []Token, prevIndex *int, tempVal *string, index int, isSpaces bool, toFind string, toReplace string) []Token {
-	if ident.Identifier == ADD || ident.Identifier == SUB || ident.Identifier == XORBIN {
-		if len(ret) >= 1 {
-			if ret[len(ret)-1].TokenType == toFind && ret[len(ret)-1].TokenType == ident.Identifier && !isSpaces {
-				ret[len(ret)-1].TokenType = toReplace
-				ret[len(ret)-1].Value += *tempVal
-				*tempVal = ""
-				*prevIndex = index
-			}
-		}
+// skip ends the current element without associating it with a token
+func (s *scanner) skip() {
+	s.start, s.startCol, s.startLine = s.offset, s.col, s.line
+}
+
+// add adds a token of value, the current element, to the tokens
+func (s *scanner) add(tokenType string, value string) {
+	token := addToken(tokenType, value, s.startCol+1, s.startLine)
+	token.Offset = s.start
+	s.tokens = append(s.tokens, token)
+	s.valueEnd = s.start + len(value)
+	s.stretch(s.offset, s.col, s.line)
+}
+
+// extend adds value, the current element, to the value of the last token.
+// The value is a part of the sentence as long as it is extended by the element following it,
+// it is sliced from the sentence instead of being copied.
+func (s *scanner) extend(value string) {
+	last := &s.tokens[len(s.tokens)-1]
+	switch {
+	case last.Value == "":
+		last.Value = value
+	case s.valueEnd == s.start:
+		last.Value = s.sentence[s.start-len(last.Value) : s.start+len(value)]
+	default:
+		last.Value += value
+		s.valueEnd = -1
+		s.stretch(s.offset, s.col, s.line)
+		return
 	}
-	return ret
+	s.valueEnd = s.start + len(value)
+	s.stretch(s.offset, s.col, s.line)
 }
 
-// tokenAssign replace the previous token.tokenType in ret to the compose token of the current token and the previous one
-// if the current token.tokenType is ASSIGN, and if the previous one is one of the listed token able to merge with an
-// ASSIGN.
-//
-// return the changed []Token
-func tokenAssign(ident identifier, ret []Token, prevIndex *int, tempVal *string, index int, isSpaces bool) []Token {
-	if ident.Identifier == ASSIGN && !isSpaces {
-		if len(ret) >= 1 {
-			if concatEqual(ret[len(ret)-1].TokenType) {
-				if ret[len(ret)-1].TokenType == ASSIGN {
-					ret[len(ret)-1].TokenType = EQUAL
-				} else if ret[len(ret)-1].TokenType == ADD || ret[len(ret)-1].TokenType == SUB || ret[len(ret)-1].TokenType == MULT || ret[len(ret)-1].TokenType == DIV || ret[len(ret)-1].TokenType == QOT || ret[len(ret)-1].TokenType == MOD {
-					ret[len(ret)-1].TokenType = ret[len(ret)-1].TokenType + ident.Identifier
-				} else if ret[len(ret)-1].TokenType == LSS {
-					ret[len(ret)-1].TokenType = LEQ
-				} else if ret[len(ret)-1].TokenType == GTR {
-					ret[len(ret)-1].TokenType = GEQ
-				} else if ret[len(ret)-1].TokenType == NOT {
-					ret[len(ret)-1].TokenType = NEQ
-				}
-				ret[len(ret)-1].Value += *tempVal
-				*tempVal = ""
-				*prevIndex = index
-			}
-		}
+// stretch makes the last token end at the index end, whose column is col and line is line
+func (s *scanner) stretch(end int, col int, line int) {
+	last := &s.tokens[len(s.tokens)-1]
+	last.EndOffset, last.EndPosition, last.EndLine = end, col+1, line
+}
+
+// flush associates the text before end with a token, or adds it to the string or the char being read,
+// col and line are the position of end
+func (s *scanner) flush(end int, col int, line int) {
+	value := s.sentence[s.start:end]
+	if s.inQuote {
+		s.quoted(value)
+	} else {
+		s.add(TEXT, value)
 	}
-	return ret
+	s.stretch(end, col, line)
+	s.isSpaces = false
 }
 
-// tokenDiv replace the previous token.tokenType in ret to the QOT token
-// if the current token.tokenType and the previous token.tokenType are DIV
-//
-// return the changed []Token
-func tokenDiv(ident identifier, ret []Token, prevIndex *int, tempVal *string, index int) []Token {
-	if ident.Identifier == DIV {
-		if len(ret) >= 1 {
-			if ret[len(ret)-1].TokenType == DIV {
-				ret[len(ret)-1].TokenType = QOT
-				ret[len(ret)-1].Value += *tempVal
-				*tempVal = ""
-				*prevIndex = index
+// syntax associates the current element, a known syntax of type tokenType, with a token
+func (s *scanner) syntax(tokenType string, spaces bool) {
+	value := s.sentence[s.start:s.offset]
+
+	// a # in a string or in a char is a text
+	if tokenType == COMMENT && s.inQuote {
+		return
+	}
+	// the spaces are only a part of the strings and of the chars
+	if spaces && !s.inQuote {
+		s.isSpaces = true
+		s.skip()
+		return
+	}
+
+	// -----------Quote Part-------------
+	//
+	// a quote starts a string or a char, or ends it if it is not escaped by a \, which is not itself escaped.
+	// Nothing is escaped in a raw string, between backquotes.
+	opening := false
+	if (tokenType == DQUOTE || tokenType == SQUOTE || tokenType == BACKQUOTE) && (s.quote == 0 || s.quote == value[0]) {
+		if s.inQuote {
+			if s.quote == '`' || !escapedQuote(s.tokens[len(s.tokens)-1].Value) {
+				s.inQuote = false
+				s.quote = 0
 			}
+		} else {
+			s.inQuote = true
+			s.quote = value[0]
+			opening = true
 		}
 	}
-	return ret
-}
 
-// TokenPeriod replace the previous token.tokenType in ret to the FLOAT token
-// if the current token.tokenType is PERIOD and the previous token.tokenType is INT
-//
-// if we are in a string, ignore this behavior
-//
-// return the changed []Token
-func tokenPeriod(ident identifier, ret []Token, prevIndex *int, tempVal *string, index int, isSpaces bool, inQuote bool, sentence string) []Token {
-	if ident.Identifier == PERIOD {
-		if len(ret) >= 1 {
-			if ret[len(ret)-1].TokenType == INT && !isSpaces {
-				ret[len(ret)-1].Value += *tempVal
-				ret[len(ret)-1].TokenType = FLOAT
-				*tempVal = ""
-				*prevIndex = index
-			} else if !inQuote {
-				actualIndex, line := positionDetector(*prevIndex, sentence)
-				ret = append(ret, addToken(ident.Identifier, *tempVal, actualIndex, line))
-				*tempVal = ""
-				*prevIndex = index
-			}
+	// -----------Interpolation Part-------------
+	//
+	// a ${ in a string starts an interpolated expression, which is read as code until its closing }
+	if s.interpolation(tokenType, value) {
+		s.isSpaces = false
+		s.skip()
+		return
+	}
+
+	// -----------Special Token Part-------------
+	//
+	// some tokens are merged with the previous one, like an ASSIGN after an ADD
+	if s.merge(tokenType, value) {
+		s.skip()
+		return
+	}
+
+	// -----------Normal Token Part-------------
+	if s.inQuote && !opening {
+		s.quoted(value)
+		// a string or a char ends at the end of the line, a raw string can hold several lines
+		if value == "\n" && s.quote != '`' {
+			s.inQuote = false
+			s.quote = 0
 		}
+	} else {
+		s.add(tokenType, value)
 	}
-	return ret
+	s.isSpaces = false
+	s.skip()
 }
 
-// tokenDiv replace the previous token.tokenType in ret to the QOT tokenType
-// if the current token.tokenType and the previous token.tokenType are DIV
+// quoted adds value, the current element, to the string or the char being read
+func (s *scanner) quoted(value string) {
+	quoted := STRING
+	switch s.quote {
+	case '\'':
+		quoted = CHAR
+	case '`':
+		quoted = RAWSTRING
+	}
+	if last := len(s.tokens) - 1; s.tokens[last].TokenType == quoted {
+		s.extend(value)
+	} else {
+		s.add(quoted, value)
+	}
+}
+
+// interpolation reads the braces starting and ending the expressions interpolated in the strings.
+// A { right after a $ which is not escaped starts an INTERPOLATION token, ${, and ends the string until
+// the } closing it. The braces opened in the expression are counted so that their } do not end it.
 //
-// return the changed []Token
-func tokenInt(ident identifier, ret []Token, prevIndex *int, tempVal *string, index int, isSpaces bool) []Token {
-	if ident.Identifier == INT {
-		if !isSpaces {
-			if len(ret) >= 1 {
-				if ret[len(ret)-1].TokenType == INT || ret[len(ret)-1].TokenType == TEXT || ret[len(ret)-1].TokenType == FLOAT {
-					ret[len(ret)-1].Value += *tempVal
-					*tempVal = ""
-					*prevIndex = index
-				}
-			}
+// return true if the current element is associated with a token
+func (s *scanner) interpolation(tokenType string, value string) bool {
+	if tokenType == LBRACE && s.inQuote && s.quote == '"' {
+		last := &s.tokens[len(s.tokens)-1]
+		text := last.Value
+		if last.TokenType != STRING || !strings.HasSuffix(text, "$") || escapedQuote(text[:len(text)-1]) {
+			return false
 		}
-
+		// the $ is the last character of the string, it is moved to the INTERPOLATION token
+		last.Value = text[:len(text)-1]
+		last.EndOffset, last.EndPosition = last.EndOffset-1, last.EndPosition-1
+		if last.Value == "" {
+			s.tokens = s.tokens[:len(s.tokens)-1]
+		}
+		s.start, s.startCol = s.start-1, s.startCol-1
+		s.add(INTERPOLATION, s.sentence[s.start:s.offset])
+		s.interpolations = append(s.interpolations, 0)
+		s.inQuote = false
+		s.quote = 0
+		return true
 	}
-	return ret
+	if len(s.interpolations) == 0 || s.inQuote {
+		return false
+	}
+	braces := &s.interpolations[len(s.interpolations)-1]
+	switch {
+	case tokenType == LBRACE:
+		*braces++
+	case tokenType == RBRACE && *braces > 0:
+		*braces--
+	case tokenType == RBRACE:
+		// the } ends the expression, the string continues after it
+		s.add(RBRACE, value)
+		s.interpolations = s.interpolations[:len(s.interpolations)-1]
+		s.inQuote = true
+		s.quote = '"'
+		return true
+	}
+	return false
 }
 
-// tokenComment replace the previous token.tokenType in ret to the COMMENTGROUP tokenType
-// if the current token.tokenType is COMMENTGROUPIDENT, and of the previous one is COMMENT
+// merge merges the current element with the previous token when they form a single token,
+// like ++ or <=, or adds a token which do not depend on the quotes.
 //
-// return the changed []Token
-func tokenCommentGroup(ident identifier, ret []Token, prevIndex *int, tempVal *string, index int) []Token {
-	if ident.Identifier == COMMENTGROUPIDENT {
-		if len(ret) >= 1 {
-			if ret[len(ret)-1].TokenType == COMMENT {
-				ret[len(ret)-1].TokenType = COMMENTGROUP
-				ret[len(ret)-1].Value += ""
-				*tempVal = ""
-				*prevIndex = index
+// return true if the current element is associated with a token
+func (s *scanner) merge(tokenType string, value string) bool {
+	if len(s.tokens) == 0 {
+		if tokenType == COMMENT {
+			s.add(COMMENT, "")
+			return true
+		}
+		return false
+	}
+	last := &s.tokens[len(s.tokens)-1]
+	switch tokenType {
+	case COMMENT:
+		s.add(COMMENT, "")
+		return true
+	case INT:
+		if !s.isSpaces && (last.TokenType == INT || last.TokenType == TEXT || last.TokenType == FLOAT) {
+			s.extend(value)
+			return true
+		}
+	case PERIOD:
+		if last.TokenType == INT && !s.isSpaces {
+			last.TokenType = FLOAT
+			s.extend(value)
+			return true
+		} else if !s.inQuote {
+			s.add(PERIOD, value)
+			return true
+		}
+	case ASSIGN:
+		if s.isSpaces || !concatEqual(last.TokenType) {
+			return false
+		}
+		switch last.TokenType {
+		case ASSIGN:
+			last.TokenType = EQUAL
+		case ADD, SUB, MULT, DIV, QOT, MOD:
+			last.TokenType += ASSIGN
+		case LSS:
+			last.TokenType = LEQ
+		case GTR:
+			last.TokenType = GEQ
+		case NOT:
+			last.TokenType = NEQ
+		}
+		s.extend(value)
+		return true
+	case DIV:
+		if last.TokenType == DIV {
+			last.TokenType = QOT
+			s.extend(value)
+			return true
+		}
+	case ADD, SUB, XORBIN:
+		if last.TokenType == tokenType && !s.isSpaces {
+			switch tokenType {
+			case ADD:
+				last.TokenType = INC
+			case SUB:
+				last.TokenType = DEC
+			case XORBIN:
+				last.TokenType = XOR
 			}
+			s.extend(value)
+			return true
 		}
 	}
-	return ret
+	return false
 }
 
-// tokenComment add a new token if the no value if the identifier is COMMENT
+// end associates the text left with a token and adds the EOF token
 //
-// return the changed []Token
-func tokenComment(ident identifier, ret []Token, prevIndex *int, tempVal *string, index int, line int, sentence string) []Token {
-	if ident.Identifier == COMMENT {
-		actualIndex, line := positionDetector(*prevIndex, sentence)
-		ret = append(ret, addToken(ident.Identifier, "", actualIndex, line))
-		*tempVal = ""
-		*prevIndex = index
+// return the tokens of the sentence
+func (s *scanner) end() []Token {
+	value := s.sentence[s.start:]
+	if last := len(s.tokens) - 1; last >= 0 && s.tokens[last].TokenType == COMMENTGROUP && value == "/" {
+		s.extend(value)
+		s.eof(s.start, s.startCol, s.startLine)
+		return s.tokens
+	}
+	if value != "" {
+		// the text left is a TEXT, even in a string or in a char
+		s.add(TEXT, value)
 	}
+	s.eof(s.offset, s.col, s.line)
+	return s.tokens
+}
+
+// eof adds the EOF token, it is empty and starts at the index offset, whose column is col and line is line
+func (s *scanner) eof(offset int, col int, line int) {
+	s.tokens = append(s.tokens, Token{
+		TokenType:   EOF,
+		Position:    col + 1,
+		Line:        line,
+		EndPosition: col + 1,
+		EndLine:     line,
+		Offset:      offset,
+		EndOffset:   offset,
+	})
+}
+
+// addToken create a new token with the given parameters
+//
+// return the created token
+func addToken(TokenType string, Value string, Position int, Line int) Token {
+	var ret Token
+
+	ret.TokenType = TokenType
+	ret.Value = Value
+	ret.Position = Position
+	ret.Line = Line
+
 	return ret
 }
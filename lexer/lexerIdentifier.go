@@ -4,6 +4,7 @@ package lexer
 var (
 	TEXT              = "TEXT"
 	STRING            = "STRING"
+	RAWSTRING         = "RAWSTRING"
 	CHAR              = "CHAR"
 	PRINT             = "PRINT"
 	INT               = "INT"
@@ -32,6 +33,8 @@ var (
 	EOL               = "EOL"
 	DQUOTE            = "DQUOTE"
 	SQUOTE            = "SQUOTE"
+	BACKQUOTE         = "BACKQUOTE"
+	INTERPOLATION     = "INTERPOLATION"
 	PERIOD            = "PERIOD"
 	COLON             = "COLON"
 	LBRACE            = "LBRACE"
@@ -48,12 +51,8 @@ var (
 	COMMENTGROUPIDENT = "COMMENTGROUPIDENT"
 )
 
-//--------------------------------------------//
-// Need to be optimized
-// Change for a switch case of a hashmap
-//--------------------------------------------//
-
-// link between syntax and token
+// link between syntax and token, Lexer classifies the characters with the same
+// syntaxes in syntaxOf and keywords
 var Identifier []identifier = []identifier{
 	{
 		Identifier: TEXT,
@@ -170,6 +169,18 @@ var Identifier []identifier = []identifier{
 			"'",
 		},
 	},
+	{
+		Identifier: BACKQUOTE,
+		Syntax: []string{
+			"`",
+		},
+	},
+	{
+		Identifier: INTERPOLATION,
+		Syntax: []string{
+			"${",
+		},
+	},
 	{
 		Identifier: MURLOC,
 		Syntax: []string{
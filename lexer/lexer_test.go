@@ -1,7 +1,12 @@
 package lexer
 
 import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -22,7 +27,7 @@ func tLexer(t *testing.T, tested testList, name string) {
 	}
 	for Position, expct := range expected {
 		if Position < len(l) {
-			if expct != l[Position] {
+			if expct != withoutSpan(l[Position]) {
 				diff++
 				result += "\n--------------------------------------------------\nDiff " + strconv.Itoa(diff) + " Expected {" + expct.TokenType + " " + expct.Value + " " + strconv.Itoa(expct.Position) + " " + strconv.Itoa(expct.Line) + "} for the token n°" + strconv.Itoa(Position+1) + "\n       Got \t{" + l[Position].TokenType + " " + l[Position].Value + " " + strconv.Itoa(l[Position].Position) + " " + strconv.Itoa(l[Position].Line) + "}\n--------------------------------------------------\n"
 			}
@@ -813,3 +818,296 @@ func TestPeriod_BAD(t *testing.T) {
 	}
 
 }
+
+// withoutSpan returns the token without the end and the offsets of its span, the lists of tokens
+// of the tests give only its position
+func withoutSpan(token Token) Token {
+	return addToken(token.TokenType, token.Value, token.Position, token.Line)
+}
+
+// sameTokens returns the first token which differs between got and expected, -1 when they are the same
+func sameTokens(got []Token, expected []Token) int {
+	for i := range expected {
+		if i >= len(got) || withoutSpan(got[i]) != expected[i] {
+			return i
+		}
+	}
+	if len(got) != len(expected) {
+		return len(expected)
+	}
+	return -1
+}
+
+// lexerFragments are the pieces of the random sentences compared with legacyLexer
+var lexerFragments = []string{
+	"a", "b", "x1", "_", "0", "1", "42", "3.14", " ", "  ", "\t", "\n", "\r\n", "\r",
+	"+", "-", "*", "/", "%", "=", ">", "<", "^", "!", "(", ")", ";", ".", ":", ",", "{", "}", "[", "]",
+	"&", "&&", "|", "||", "\"", "'", "\\", "#", "#/", "/#", "//", "$",
+	"true", "false", "tru", "fals", "mgrlgrl", "mgrl", "grl", "e", "l",
+	"é", "日本", "\xff", "\x80",
+}
+
+// extendedNumber matches the sentences which can hold a numeric literal unknown to legacyLexer,
+// with a prefix, a _ or an exponent
+var extendedNumber = regexp.MustCompile(`[0-9]\.?[_xXoObBeE]`)
+
+// extendedString matches the sentences which can hold a raw string or an interpolation unknown to legacyLexer
+var extendedString = regexp.MustCompile("`|\\$\\{")
+
+func TestLexer_SameAsLegacy(t *testing.T) {
+	var sentences []string
+	for _, tested := range []testList{testCalc, testDQuote, testMurloc, testSpeChar, testCHAR, testCHARSTRING, testCHARSTRING2, testCHARSTRING3, testEOL, testNoFile, testHashtag, testHashtag2, testHashtag3, testHashtag4, testHashtag5, testBoolOpperand, testMultiLigneString} {
+		sentences = append(sentences, tested.input)
+	}
+	files, _ := filepath.Glob("../DEMO/**/*.ecla")
+	more, _ := filepath.Glob("../DEMO/*.ecla")
+	for _, file := range append(files, more...) {
+		code, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sentences = append(sentences, string(code))
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		var sentence strings.Builder
+		for j := r.Intn(30); j >= 0; j-- {
+			sentence.WriteString(lexerFragments[r.Intn(len(lexerFragments))])
+		}
+		if !extendedNumber.MatchString(sentence.String()) && !extendedString.MatchString(sentence.String()) {
+			sentences = append(sentences, sentence.String())
+		}
+	}
+	for _, sentence := range sentences {
+		got, expected := Lexer(sentence), legacyLexer(sentence)
+		if i := sameTokens(got, expected); i != -1 {
+			t.Errorf("%q: token n°%d differs\n got      %v\n expected %v", sentence, i+1, got, expected)
+		}
+	}
+}
+
+func FuzzLexer(f *testing.F) {
+	for _, fragment := range lexerFragments {
+		f.Add(fragment)
+	}
+	f.Add(testHashtag.input)
+	f.Add(testCHARSTRING3.input)
+	f.Fuzz(func(t *testing.T, sentence string) {
+		if len(sentence) > 1000 {
+			// legacyLexer is too slow for the long sentences
+			t.Skip()
+		}
+		if extendedNumber.MatchString(sentence) || extendedString.MatchString(sentence) {
+			t.Skip()
+		}
+		got, expected := Lexer(sentence), legacyLexer(sentence)
+		if i := sameTokens(got, expected); i != -1 {
+			t.Errorf("%q: token n°%d differs\n got      %v\n expected %v", sentence, i+1, got, expected)
+		}
+	})
+}
+
+// benchmarkScript is a script of a few thousand lines, made of the DEMO scripts
+func benchmarkScript(b *testing.B) string {
+	files, _ := filepath.Glob("../DEMO/Test/*.ecla")
+	var script strings.Builder
+	for script.Len() < 100000 {
+		for _, file := range files {
+			code, err := os.ReadFile(file)
+			if err != nil {
+				b.Fatal(err)
+			}
+			script.Write(code)
+			script.WriteByte('\n')
+		}
+	}
+	return script.String()
+}
+
+func BenchmarkLexer(b *testing.B) {
+	script := benchmarkScript(b)
+	b.SetBytes(int64(len(script)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Lexer(script)
+	}
+}
+
+func BenchmarkLegacyLexer(b *testing.B) {
+	script := benchmarkScript(b)
+	b.SetBytes(int64(len(script)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		legacyLexer(script)
+	}
+}
+
+func TestLexer_Spans(t *testing.T) {
+	expected := []Token{
+		{TokenType: TEXT, Value: "a", Position: 1, Line: 1, EndPosition: 2, EndLine: 1, Offset: 0, EndOffset: 1},
+		{TokenType: COLON, Value: ":", Position: 3, Line: 1, EndPosition: 4, EndLine: 1, Offset: 2, EndOffset: 3},
+		{TokenType: ASSIGN, Value: "=", Position: 4, Line: 1, EndPosition: 5, EndLine: 1, Offset: 3, EndOffset: 4},
+		{TokenType: DQUOTE, Value: "\"", Position: 6, Line: 1, EndPosition: 7, EndLine: 1, Offset: 5, EndOffset: 6},
+		{TokenType: STRING, Value: "é b", Position: 7, Line: 1, EndPosition: 10, EndLine: 1, Offset: 6, EndOffset: 10},
+		{TokenType: DQUOTE, Value: "\"", Position: 10, Line: 1, EndPosition: 11, EndLine: 1, Offset: 10, EndOffset: 11},
+		{TokenType: EOL, Value: ";", Position: 11, Line: 1, EndPosition: 12, EndLine: 1, Offset: 11, EndOffset: 12},
+		{TokenType: COMMENTGROUP, Value: " x\n", Position: 1, Line: 2, EndPosition: 3, EndLine: 3, Offset: 13, EndOffset: 20},
+		{TokenType: FLOAT, Value: "1.5", Position: 3, Line: 3, EndPosition: 6, EndLine: 3, Offset: 20, EndOffset: 23},
+		{TokenType: INC, Value: "++", Position: 7, Line: 3, EndPosition: 9, EndLine: 3, Offset: 24, EndOffset: 26},
+		{TokenType: EOF, Value: "", Position: 9, Line: 3, EndPosition: 9, EndLine: 3, Offset: 26, EndOffset: 26},
+	}
+	got := Lexer("a := \"é b\";\n#/ x\n/#1.5 ++")
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %v", len(expected), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("token n°%d: expected %+v, got %+v", i+1, expected[i], got[i])
+		}
+	}
+}
+
+func TestLexer_SpansMatchPositions(t *testing.T) {
+	var sentences []string
+	files, _ := filepath.Glob("../DEMO/**/*.ecla")
+	for _, file := range files {
+		code, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sentences = append(sentences, string(code))
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		var sentence strings.Builder
+		for j := r.Intn(30); j >= 0; j-- {
+			sentence.WriteString(lexerFragments[r.Intn(len(lexerFragments))])
+		}
+		sentences = append(sentences, sentence.String())
+	}
+	for _, sentence := range sentences {
+		end := 0
+		for _, token := range Lexer(sentence) {
+			if token.TokenType != EOF && token.Offset < end || token.EndOffset < token.Offset {
+				t.Errorf("%q: the span of %+v overlaps the previous token", sentence, token)
+			}
+			end = token.EndOffset
+			// the positions are the ones of the offsets in the sentence
+			position, line := positionDetector(token.Offset, sentence)
+			endPosition, endLine := positionDetector(token.EndOffset, sentence)
+			if position != token.Position || line != token.Line || endPosition != token.EndPosition || endLine != token.EndLine {
+				t.Errorf("%q: the position of %+v is not the one of its offsets", sentence, token)
+			}
+			switch token.TokenType {
+			case COMMENT, COMMENTGROUP, QOT, QOT + ASSIGN:
+				// the value of a comment is without its delimiters, the slashes of a QOT can be separated by spaces
+			default:
+				if token.Value != sentence[token.Offset:token.EndOffset] {
+					t.Errorf("%q: the value of %+v is not the code of its span", sentence, token)
+				}
+			}
+		}
+	}
+}
+
+func TestLexer_Numbers(t *testing.T) {
+	tests := []struct {
+		code   string
+		tokens []string
+	}{
+		{"0x1F;", []string{INT, "0x1F", EOL, ";"}},
+		{"0XfF_fF", []string{INT, "0XfF_fF"}},
+		{"0o17+0b1_01", []string{INT, "0o17", ADD, "+", INT, "0b1_01"}},
+		{"1_000_000", []string{INT, "1_000_000"}},
+		{"1_000.5e-3", []string{FLOAT, "1_000.5e-3"}},
+		{"12e+3*1E5", []string{FLOAT, "12e+3", MULT, "*", FLOAT, "1E5"}},
+		{"0xFFe-1", []string{INT, "0xFFe", SUB, "-", INT, "1"}},
+		{"1.5.3", []string{FLOAT, "1.5", PERIOD, ".", INT, "3"}},
+		{"1.", []string{FLOAT, "1."}},
+		{"1else", []string{INT, "1", TEXT, "else"}},
+		// the malformed literals are single tokens
+		{"0x", []string{INT, "0x"}},
+		{"0b102", []string{INT, "0b102"}},
+		{"1__0 2_", []string{INT, "1__0", INT, "2_"}},
+		{"1e+;", []string{FLOAT, "1e+", EOL, ";"}},
+		{"1e;", []string{FLOAT, "1e", EOL, ";"}},
+		{"1E", []string{FLOAT, "1E"}},
+		// a number in a string is a part of the string
+		{"\"0x1F\"", []string{DQUOTE, "\"", STRING, "0x1F", DQUOTE, "\""}},
+	}
+	for _, test := range tests {
+		var got []string
+		for _, token := range Lexer(test.code) {
+			if token.TokenType != EOF {
+				got = append(got, token.TokenType, token.Value)
+			}
+		}
+		if strings.Join(got, " ") != strings.Join(test.tokens, " ") {
+			t.Errorf("%s: expected %v, got %v", test.code, test.tokens, got)
+		}
+	}
+}
+
+func TestLexer_RawStrings(t *testing.T) {
+	tests := []struct {
+		code   string
+		tokens []string
+	}{
+		{"`a\\n\"b`;", []string{BACKQUOTE, "`", RAWSTRING, `a\n"b`, BACKQUOTE, "`", EOL, ";"}},
+		{"``", []string{BACKQUOTE, "`", BACKQUOTE, "`"}},
+		{"`a\n# b\n${c}'`", []string{BACKQUOTE, "`", RAWSTRING, "a\n# b\n${c}'", BACKQUOTE, "`"}},
+		{"`a\\`+\"`\"", []string{BACKQUOTE, "`", RAWSTRING, `a\`, BACKQUOTE, "`", ADD, "+", DQUOTE, "\"", STRING, "`", DQUOTE, "\""}},
+	}
+	for _, test := range tests {
+		var got []string
+		for _, token := range Lexer(test.code) {
+			if token.TokenType != EOF {
+				got = append(got, token.TokenType, token.Value)
+			}
+		}
+		if strings.Join(got, " ") != strings.Join(test.tokens, " ") {
+			t.Errorf("%q: expected %q, got %q", test.code, test.tokens, got)
+		}
+	}
+	tokens := Lexer("`a\nbc`")
+	if tokens[1].Line != 1 || tokens[1].EndLine != 2 || tokens[2].Line != 2 || tokens[2].Position != 3 {
+		t.Errorf("expected the raw string to end on the second line, got %v", tokens)
+	}
+}
+
+func TestLexer_Interpolations(t *testing.T) {
+	tests := []struct {
+		code   string
+		tokens []string
+	}{
+		{`"Hello ${name}, you are ${age + 1}";`, []string{DQUOTE, `"`, STRING, "Hello ", INTERPOLATION, "${", TEXT, "name", RBRACE, "}",
+			STRING, ", you are ", INTERPOLATION, "${", TEXT, "age", ADD, "+", INT, "1", RBRACE, "}", DQUOTE, `"`, EOL, ";"}},
+		{`"${a}"`, []string{DQUOTE, `"`, INTERPOLATION, "${", TEXT, "a", RBRACE, "}", DQUOTE, `"`}},
+		{`"a$${b}"`, []string{DQUOTE, `"`, STRING, "a$", INTERPOLATION, "${", TEXT, "b", RBRACE, "}", DQUOTE, `"`}},
+		// the braces and the strings of the expression do not end it
+		{`"${ {"}": 1}["}"] }!"`, []string{DQUOTE, `"`, INTERPOLATION, "${", LBRACE, "{", DQUOTE, `"`, STRING, "}", DQUOTE, `"`, COLON, ":", INT, "1", RBRACE, "}",
+			LBRACKET, "[", DQUOTE, `"`, STRING, "}", DQUOTE, `"`, RBRACKET, "]", RBRACE, "}", STRING, "!", DQUOTE, `"`}},
+		{`"${f("${x}")}"`, []string{DQUOTE, `"`, INTERPOLATION, "${", TEXT, "f", LPAREN, "(", DQUOTE, `"`, INTERPOLATION, "${", TEXT, "x", RBRACE, "}", DQUOTE, `"`,
+			RPAREN, ")", RBRACE, "}", DQUOTE, `"`}},
+		// an escaped $ or a $ not followed by a { is a part of the string, as well as a ${ in a char
+		{`"\${x} $ {y} $"`, []string{DQUOTE, `"`, STRING, `\${x} $ {y} $`, DQUOTE, `"`}},
+		{`"\\${x}"`, []string{DQUOTE, `"`, STRING, `\\`, INTERPOLATION, "${", TEXT, "x", RBRACE, "}", DQUOTE, `"`}},
+		{`'${'`, []string{SQUOTE, "'", CHAR, "${", SQUOTE, "'"}},
+		{`${a}`, []string{TEXT, "$", LBRACE, "{", TEXT, "a", RBRACE, "}"}},
+	}
+	for _, test := range tests {
+		var got []string
+		for _, token := range Lexer(test.code) {
+			if token.TokenType != EOF {
+				got = append(got, token.TokenType, token.Value)
+			}
+		}
+		if strings.Join(got, " ") != strings.Join(test.tokens, " ") {
+			t.Errorf("%s: expected %q, got %q", test.code, test.tokens, got)
+		}
+	}
+	tokens := Lexer(`"ab${c}"`)
+	if tokens[1].EndPosition != 4 || tokens[1].EndOffset != 3 || tokens[2].Position != 4 || tokens[2].Offset != 3 || tokens[2].EndPosition != 6 {
+		t.Errorf("expected the $ to be a part of the INTERPOLATION token, got %v", tokens)
+	}
+}
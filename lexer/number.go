@@ -0,0 +1,52 @@
+package lexer
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ParseInt returns the value of an INT token, a decimal integer or an hexadecimal, octal or binary integer
+// prefixed by 0x, 0o or 0b, whose digits can be separated by _ like 1_000 or 0xFF_FF.
+// A malformed literal or a value which overflows an int is an error.
+func ParseInt(value string) (int, error) {
+	literal, base := value, 10
+	if len(value) > 1 && value[0] == '0' && strings.IndexByte("xXoObB", value[1]) != -1 {
+		// the base is given by the prefix
+		base = 0
+	} else if value == "" || value[0] == '_' || strings.HasSuffix(value, "_") || strings.Contains(value, "__") {
+		return 0, errors.New("malformed int literal " + value)
+	} else {
+		// the base stays 10 so that the leading zeros of a decimal are not an octal prefix
+		value = strings.ReplaceAll(value, "_", "")
+	}
+	result, err := strconv.ParseInt(value, base, strconv.IntSize)
+	if err != nil {
+		return 0, numberError("int", literal, err)
+	}
+	return int(result), nil
+}
+
+// ParseFloat returns the value of a FLOAT token, a decimal with a fraction, an exponent or both like 1.5e-3,
+// whose digits can be separated by _.
+// A malformed literal or a value which overflows a float is an error.
+func ParseFloat(value string) (float32, error) {
+	for i := 0; i < len(value); i++ {
+		if strings.IndexByte("0123456789_.eE+-", value[i]) == -1 {
+			return 0, errors.New("malformed float literal " + value)
+		}
+	}
+	result, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return 0, numberError("float", value, err)
+	}
+	return float32(result), nil
+}
+
+// numberError returns the error of the literal value of type typ, err is the error of strconv
+func numberError(typ string, value string, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return errors.New(typ + " literal " + value + " overflows " + typ)
+	}
+	return errors.New("malformed " + typ + " literal " + value)
+}
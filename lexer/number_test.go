@@ -0,0 +1,93 @@
+package lexer
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestParseInt(t *testing.T) {
+	tests := map[string]int{
+		"0":                         0,
+		"42":                        42,
+		"017":                       17,
+		"1_000":                     1000,
+		"0x1F":                      31,
+		"0XfF_fF":                   65535,
+		"0o17":                      15,
+		"0b1_01":                    5,
+		"0x_FF":                     255,
+		"00_9":                      9,
+		"-12":                       -12,
+		"0b0":                       0,
+		"9_223_372_036_854_775_807": 1<<63 - 1,
+	}
+	for value, expected := range tests {
+		got, err := ParseInt(value)
+		if err != nil {
+			t.Errorf("ParseInt(%s) returned the error %v", value, err)
+		} else if got != expected {
+			t.Errorf("ParseInt(%s) = %d, expected %d", value, got, expected)
+		}
+	}
+}
+
+func TestParseInt_Errors(t *testing.T) {
+	tests := map[string]string{
+		"":                          "malformed int literal ",
+		"0x":                        "malformed int literal 0x",
+		"0b102":                     "malformed int literal 0b102",
+		"0o8":                       "malformed int literal 0o8",
+		"0xG":                       "malformed int literal 0xG",
+		"1_":                        "malformed int literal 1_",
+		"1__0":                      "malformed int literal 1__0",
+		"_1":                        "malformed int literal _1",
+		"9223372036854775808":       "int literal 9223372036854775808 overflows int",
+		"9_223_372_036_854_775_808": "int literal 9_223_372_036_854_775_808 overflows int",
+		"0xFFFFFFFFFFFFFFFFFF":      "int literal 0xFFFFFFFFFFFFFFFFFF overflows int",
+	}
+	for value, expected := range tests {
+		if _, err := ParseInt(value); err == nil || err.Error() != expected {
+			t.Errorf("ParseInt(%s) returned the error %v, expected %s", value, err, expected)
+		}
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	tests := map[string]float32{
+		"1.":        1,
+		"3.14":      3.14,
+		"1.5e-3":    1.5e-3,
+		"1e10":      1e10,
+		"12E+3":     12e3,
+		"1_000.5":   1000.5,
+		"1.e5":      1e5,
+		"1e-50":     0,
+		"3.4028e38": 3.4028e38,
+	}
+	for value, expected := range tests {
+		got, err := ParseFloat(value)
+		if err != nil {
+			t.Errorf("ParseFloat(%s) returned the error %v", value, err)
+		} else if got != expected {
+			t.Errorf("ParseFloat(%s) = %s, expected %s", value, strconv.FormatFloat(float64(got), 'g', -1, 32), strconv.FormatFloat(float64(expected), 'g', -1, 32))
+		}
+	}
+}
+
+func TestParseFloat_Errors(t *testing.T) {
+	tests := map[string]string{
+		"1e+":    "malformed float literal 1e+",
+		"1e":     "malformed float literal 1e",
+		"1._5":   "malformed float literal 1._5",
+		"1__0.5": "malformed float literal 1__0.5",
+		"0x1F.":  "malformed float literal 0x1F.",
+		"inf":    "malformed float literal inf",
+		"1e39":   "float literal 1e39 overflows float",
+		"3.5e38": "float literal 3.5e38 overflows float",
+	}
+	for value, expected := range tests {
+		if _, err := ParseFloat(value); err == nil || err.Error() != expected {
+			t.Errorf("ParseFloat(%s) returned the error %v, expected %s", value, err, expected)
+		}
+	}
+}
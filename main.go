@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"github.com/Eclalang/Ecla/checker"
 	"github.com/Eclalang/Ecla/interpreter"
 	"github.com/Eclalang/mainthread"
+	"os"
 	"strings"
 	"time"
 )
@@ -17,6 +19,7 @@ var (
 	lexerDebug  = false
 	parserDebug = false
 	Metrics     = false
+	Bytecode    = false
 )
 
 func init() {
@@ -30,6 +33,8 @@ func init() {
 	flag.BoolVar(&parserDebug, "dp", parserDebug, "enable parser debug")
 	flag.BoolVar(&Metrics, "metrics", Metrics, "enable metrics measurement")
 	flag.BoolVar(&Metrics, "m", Metrics, "enable metrics measurement")
+	flag.BoolVar(&Bytecode, "bytecode", Bytecode, "execute the code with the bytecode vm")
+	flag.BoolVar(&Bytecode, "vm", Bytecode, "execute the code with the bytecode vm (shorthand)")
 	flag.Parse()
 }
 
@@ -39,6 +44,10 @@ func eclaCli() {
 		fmt.Println("invalid input")
 		return
 	}
+	if args[0] == "check" {
+		checkCli(args[1:])
+		return
+	}
 	Env := interpreter.NewEnv()
 	if t := strings.Split(args[0], "."); t[len(t)-1] == "ecla" || t[len(t)-1] == "eclaw" {
 		Env.SetFile(args[0])
@@ -48,6 +57,7 @@ func eclaCli() {
 		fmt.Print("Ecla: invalid input file")
 		return
 	}
+	Env.Bytecode = Bytecode
 	if Metrics {
 		m := Env.ExecuteMetrics()
 		fmt.Println("--------------------")
@@ -77,6 +87,32 @@ func eclaCli() {
 	}
 }
 
+// checkCli checks the types of the files without executing them.
+func checkCli(files []string) {
+	if len(files) == 0 {
+		fmt.Println("invalid input")
+		return
+	}
+	failed := false
+	for _, file := range files {
+		errs, err := checker.CheckPath(file)
+		if err != nil {
+			fmt.Println(err)
+			failed = true
+			continue
+		}
+		for _, e := range errs {
+			fmt.Println(file + ": " + e.String())
+		}
+		if len(errs) > 0 {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
 func main() {
 	mainthread.Run(eclaCli)
 }
@@ -12,19 +12,30 @@ const (
 	Function   = "function"
 	ArrayStart = "["
 	Any        = "any"
+	Error      = "error"
 
 	// keywords
-	Var    = "var"
-	Return = "return"
-	Range  = "range"
-	Import = "import"
-	For    = "for"
-	While  = "while"
-	If     = "if"
-	Else   = "else"
-	Null   = "null"
-	Struct = "struct"
-	Murloc = "mgrlmgrl"
+	Var       = "var"
+	Return    = "return"
+	Range     = "range"
+	Import    = "import"
+	For       = "for"
+	While     = "while"
+	If        = "if"
+	Else      = "else"
+	Null      = "null"
+	Struct    = "struct"
+	Murloc    = "mgrlmgrl"
+	Break     = "break"
+	Continue  = "continue"
+	Try       = "try"
+	Catch     = "catch"
+	Finally   = "finally"
+	Throw     = "throw"
+	Interface = "interface"
+
+	// implicit receiver of the methods declared inside a struct
+	Self = "self"
 
 	// built-in functions
 	TypeOf = "typeOf"
@@ -36,19 +47,26 @@ const (
 
 var (
 	Keywords = map[string]interface{}{
-		Var:      nil,
-		Function: nil,
-		Return:   nil,
-		Range:    nil,
-		Import:   nil,
-		For:      nil,
-		While:    nil,
-		If:       nil,
-		Else:     nil,
-		Null:     nil,
-		Any:      nil,
-		Struct:   nil,
-		Murloc:   nil,
+		Var:       nil,
+		Function:  nil,
+		Return:    nil,
+		Range:     nil,
+		Import:    nil,
+		For:       nil,
+		While:     nil,
+		If:        nil,
+		Else:      nil,
+		Null:      nil,
+		Any:       nil,
+		Struct:    nil,
+		Murloc:    nil,
+		Break:     nil,
+		Continue:  nil,
+		Try:       nil,
+		Catch:     nil,
+		Finally:   nil,
+		Throw:     nil,
+		Interface: nil,
 	}
 	BuiltInFunctions = map[string]interface{}{
 		TypeOf: nil,
@@ -67,6 +85,7 @@ var (
 		Function:   nil,
 		ArrayStart: nil,
 		Any:        nil,
+		Error:      nil,
 	}
 	DefaultVarTypes = map[string]interface{}{
 		Int:        nil,
@@ -6,6 +6,10 @@ type Node interface {
 	EndPos() int
 	StartLine() int
 	EndLine() int
+	// StartOffset and EndOffset are the index of the first byte of the node in the code and the index right after
+	// its last byte, the node is read from code[StartOffset():EndOffset()]
+	StartOffset() int
+	EndOffset() int
 }
 
 // Expr is an expression
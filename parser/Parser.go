@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"github.com/Eclalang/Ecla/errorHandler"
 	"github.com/Eclalang/Ecla/lexer"
+	"io"
+	"os"
 	"strings"
+	"unicode/utf8"
 )
 
 // Parser is the parser for the Ecla language
@@ -17,11 +20,20 @@ type Parser struct {
 	CurrentFile  *File
 	IsEndOfBrace bool
 	VarTypes     map[string]interface{}
+	// Output is where PrintBacktrace prints the tokens, the standard output when it is nil
+	Output io.Writer
+	// loopLabels is the stack of the loops being parsed, an unlabeled loop is pushed as ""
+	loopLabels []string
+	// pendingLabel is the label waiting to be attached to the next parsed loop
+	pendingLabel string
+	// errors is the list of the syntax errors the parser recovered from
+	errors []errorHandler.Error
+	// recovering is the number of nodes being parsed by ParseRecovering
+	recovering int
+	// inFunction is true while the body of a function is parsed
+	inFunction bool
 }
 
-var selectorDepth int
-var inFunction bool
-
 // Step moves the parser to the next token
 func (p *Parser) Step() {
 	p.TokenIndex++
@@ -68,30 +80,127 @@ func (p *Parser) Peek(lookAhead int) lexer.Token {
 	return p.Tokens[p.TokenIndex+lookAhead]
 }
 
-// PrintBacktrace prints the last 10 tokens for debugging purposes
+// PrintBacktrace prints the last 10 tokens for debugging purposes,
+// nothing is printed while recovering as the syntax errors are reported together once collected
 func (p *Parser) PrintBacktrace() {
+	if p.recovering > 0 {
+		return
+	}
 	// print back the 10 last token values
+	index := p.TokenIndex
 	p.MultiBack(10)
-	for i := 0; i < 10; i++ {
-		fmt.Print(p.CurrentToken.Value)
+	out := p.Output
+	if out == nil {
+		out = os.Stdout
+	}
+	for p.TokenIndex < index {
+		fmt.Fprint(out, p.CurrentToken.Value)
 		p.Step()
 	}
-	fmt.Println()
+	fmt.Fprintln(out)
+	p.TokenIndex = index
+	p.CurrentToken = p.Peek(0)
 }
 
 // HandleWarning handles a warning level error
 func (p *Parser) HandleWarning(message string) {
-	p.ErrorHandler.HandleError(p.CurrentToken.Line, p.CurrentToken.Position, message, errorHandler.LevelWarning)
+	p.ErrorHandler.HandleErrorAt(tokenSpan(p.CurrentToken), message, errorHandler.LevelWarning)
 }
 
 // HandleError handles an error level error
 func (p *Parser) HandleError(message string) {
-	p.ErrorHandler.HandleError(p.CurrentToken.Line, p.CurrentToken.Position, message, errorHandler.LevelError)
+	p.ErrorHandler.HandleErrorAt(tokenSpan(p.CurrentToken), message, errorHandler.LevelError)
 }
 
 // HandleFatal handles a fatal level error
 func (p *Parser) HandleFatal(message string) {
-	p.ErrorHandler.HandleError(p.CurrentToken.Line, p.CurrentToken.Position, message, errorHandler.LevelFatal)
+	p.handleFatalAt(tokenSpan(p.CurrentToken), message)
+}
+
+// handleFatalAt handles a fatal level error raised on the code of s, it is raised to ParseRecovering while recovering
+func (p *Parser) handleFatalAt(s errorHandler.Span, message string) {
+	if p.recovering > 0 {
+		panic(errorHandler.NewError(s, message, errorHandler.LevelFatal))
+	}
+	p.ErrorHandler.HandleErrorAt(s, message, errorHandler.LevelFatal)
+}
+
+// Errors returns the syntax errors found by the last call to Parse, they are also collected in the ErrorHandler.
+func (p *Parser) Errors() []errorHandler.Error {
+	return p.errors
+}
+
+// ParseRecovering parses a node like ParseNode, but a syntax error is collected instead of exiting
+// and the parser resynchronises at the end of the statement, the returned node is then nil.
+// inBody tells if the node is in a body, whose closing brace must not be skipped.
+func (p *Parser) ParseRecovering(inBody bool) (node Node) {
+	savedInFunction := p.inFunction
+	savedLoopLabels := p.loopLabels
+	p.recovering++
+	defer func() {
+		p.recovering--
+	}()
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		err, ok := r.(errorHandler.Error)
+		if !ok {
+			panic(r)
+		}
+		p.collect(err)
+		p.inFunction = savedInFunction
+		p.loopLabels = savedLoopLabels
+		p.pendingLabel = ""
+		p.IsEndOfBrace = false
+		p.Synchronise(inBody)
+		node = nil
+	}()
+	return p.ParseNode()
+}
+
+// collect records a syntax error the parser recovers from.
+func (p *Parser) collect(err errorHandler.Error) {
+	p.errors = append(p.errors, err)
+	p.ErrorHandler.Collect(err)
+}
+
+// Synchronise skips the tokens up to the end of the current statement, which is the next EOL or closing brace outside a block.
+// The parser is left on the last token of the statement, the closing brace of the enclosing body is left to be parsed when inBody is true.
+func (p *Parser) Synchronise(inBody bool) {
+	depth := 0
+	for {
+		if p.TokenIndex >= len(p.Tokens) || p.CurrentToken.TokenType == lexer.EOF {
+			// stop right before the end of the file
+			p.TokenIndex = len(p.Tokens) - 1
+			p.Back()
+			return
+		}
+		switch p.CurrentToken.TokenType {
+		case lexer.LBRACE:
+			depth++
+		case lexer.RBRACE:
+			if depth == 0 {
+				if inBody {
+					p.Back()
+				}
+				return
+			}
+			depth--
+			if depth == 0 {
+				if p.Peek(1).TokenType == lexer.EOL {
+					p.Step()
+				}
+				return
+			}
+		case lexer.EOL:
+			if depth == 0 {
+				return
+			}
+		}
+		p.Step()
+	}
 }
 
 // DisableEOLChecking disables the EOL checking for the parser to allow for semicolon-less syntax on conditionals statements and loops
@@ -107,6 +216,7 @@ func (p *Parser) DisableEOLChecking() {
 /*
 Parse is the main function of the parser.
 It parses the tokens within itself and returns a File struct containing the AST and the parsed declarations of variables and functions
+The syntax errors do not stop the parsing, they are returned by Errors and the AST only holds the statements parsed without errors
 It also runs the dependency checker to find any missing dependencies and notifies the user
 */
 func (p *Parser) Parse() *File {
@@ -116,10 +226,16 @@ func (p *Parser) Parse() *File {
 	for k, v := range VarTypes {
 		p.VarTypes[k] = v
 	}
+	p.errors = nil
 	p.Tokens = tempFile.ConsumeComments(p.Tokens)
 	p.CurrentToken = p.Tokens[0]
 	file := p.ParseFile()
 	file.ConsumedComments = tempFile.ConsumedComments
+	file.Resolve()
+	if len(p.errors) > 0 {
+		// the dependencies of a partial syntax tree are not reliable
+		return file
+	}
 	ok, UnresolvedDep := file.DepChecker()
 	if !ok {
 		Unresolved := ""
@@ -141,8 +257,8 @@ func (p *Parser) ParseFile() *File {
 	tempFile := new(File)
 	tempFile.ParseTree = new(AST)
 	p.CurrentFile = tempFile
-	for p.CurrentToken.TokenType != lexer.EOF {
-		NewNode := p.ParseNode()
+	for p.CurrentToken.TokenType != lexer.EOF && p.TokenIndex < len(p.Tokens) {
+		NewNode := p.ParseRecovering(false)
 		if NewNode != nil {
 			tempFile.ParseTree.Operations = append(tempFile.ParseTree.Operations, NewNode)
 		}
@@ -171,6 +287,12 @@ func (p *Parser) ParseNode() Node {
 		tempExpr := p.ParseText()
 		if p.CurrentToken.TokenType != lexer.EOL && !p.IsEndOfBrace {
 			p.PrintBacktrace()
+			if p.recovering > 0 {
+				// the statement is complete, the parsing goes on with the token following it
+				p.collect(errorHandler.NewError(tokenSpan(p.CurrentToken), "Expected semicolon at the end of the line", errorHandler.LevelFatal))
+				p.Back()
+				return tempExpr
+			}
 			p.HandleFatal("Expected semicolon at the end of the line")
 			return nil
 		}
@@ -185,7 +307,13 @@ func (p *Parser) ParseNode() Node {
 func (p *Parser) ParseBody() []Node {
 	tempBody := make([]Node, 0)
 	for p.CurrentToken.TokenType != lexer.RBRACE {
-		tempBody = append(tempBody, p.ParseNode())
+		if p.CurrentToken.TokenType == lexer.EOF || p.TokenIndex >= len(p.Tokens) {
+			p.HandleFatal("Expected } at the end of the body")
+			return tempBody
+		}
+		if node := p.ParseRecovering(true); node != nil {
+			tempBody = append(tempBody, node)
+		}
 		p.Step()
 	}
 	return tempBody
@@ -201,10 +329,7 @@ func (p *Parser) ParseText() Node {
 		if p.CurrentToken.TokenType == lexer.PERIOD {
 			// check if the ident is a Expr
 			p.Step()
-			selectorDepth++
 			exp := p.ParseSelector(tempNode.(Expr))
-			selectorDepth--
-
 			return exp
 		}
 		return tempNode
@@ -226,6 +351,9 @@ func (p *Parser) ParseKeyword() Node {
 		return p.ParseVariableDecl()
 	}
 	if p.CurrentToken.Value == Function {
+		if p.IsMethodDecl() {
+			return p.ParseMethodDecl()
+		}
 		if p.Peek(1).TokenType == lexer.LPAREN {
 			return p.ParseAnonymousFunctionExpr()
 		}
@@ -263,6 +391,25 @@ func (p *Parser) ParseKeyword() Node {
 	if p.CurrentToken.Value == Struct {
 		return p.ParseStructDecl()
 	}
+	if p.CurrentToken.Value == Interface {
+		return p.ParseInterfaceDecl()
+	}
+	if p.CurrentToken.Value == Break {
+		return p.ParseBreakStmt()
+	}
+	if p.CurrentToken.Value == Continue {
+		return p.ParseContinueStmt()
+	}
+	if p.CurrentToken.Value == Try {
+		return p.ParseTryStmt()
+	}
+	if p.CurrentToken.Value == Throw {
+		return p.ParseThrowStmt()
+	}
+	if p.CurrentToken.Value == Catch || p.CurrentToken.Value == Finally {
+		p.HandleFatal(p.CurrentToken.Value + " without a matching try")
+		return nil
+	}
 
 	p.HandleFatal("Unknown keyword: " + p.CurrentToken.Value)
 	return nil
@@ -300,6 +447,14 @@ func (p *Parser) ParseStructDecl() Node {
 	tempStructDecl.LeftBrace = p.CurrentToken
 	p.Step()
 	for p.CurrentToken.TokenType != lexer.RBRACE {
+		if p.CurrentToken.TokenType == lexer.TEXT && p.CurrentToken.Value == Function {
+			method := p.ParseStructMethod(tempStructDecl.Name)
+			if method == nil {
+				return nil
+			}
+			tempStructDecl.Methods = append(tempStructDecl.Methods, *method)
+			continue
+		}
 		tempStructDecl.Fields = append(tempStructDecl.Fields, p.ParseStructField())
 		p.Back()
 		if p.CurrentToken.TokenType != lexer.EOL && p.CurrentToken.TokenType != lexer.RBRACE {
@@ -316,6 +471,150 @@ func (p *Parser) ParseStructDecl() Node {
 	return tempStructDecl
 }
 
+// ParseStructMethod parses a method declared inside the body of a struct, its receiver is bound to the name "self"
+func (p *Parser) ParseStructMethod(structName string) *FunctionDecl {
+	parsed := p.ParseFunctionDecl()
+	if parsed == nil {
+		return nil
+	}
+	method := parsed.(FunctionDecl)
+	method.Receiver = &FunctionParams{Name: Self, Type: structName}
+	// step over the closing brace or the optional semicolon of the method
+	p.IsEndOfBrace = false
+	p.Step()
+	return &method
+}
+
+// IsMethodDecl checks if the current function keyword starts a method declaration in the form of "function (receiver : Type) name(...)"
+func (p *Parser) IsMethodDecl() bool {
+	return p.Peek(1).TokenType == lexer.LPAREN &&
+		p.Peek(2).TokenType == lexer.TEXT &&
+		p.Peek(3).TokenType == lexer.COLON &&
+		p.Peek(4).TokenType == lexer.TEXT &&
+		p.Peek(5).TokenType == lexer.RPAREN &&
+		p.Peek(6).TokenType == lexer.TEXT
+}
+
+// ParseMethodDecl parses a method declaration in the form of "function (receiver : Type) name(...) (...) {...}"
+func (p *Parser) ParseMethodDecl() Node {
+	functionToken := p.CurrentToken
+	p.MultiStep(2)
+	receiver := FunctionParams{Name: p.CurrentToken.Value}
+	if _, ok := Keywords[receiver.Name]; ok {
+		p.HandleFatal("Cannot use keyword " + receiver.Name + " as receiver name")
+		return nil
+	}
+	p.MultiStep(2)
+	receiver.Type = p.CurrentToken.Value
+	_, isType := p.VarTypes[receiver.Type]
+	_, isDefault := DefaultVarTypes[receiver.Type]
+	if !isType || isDefault || receiver.Type == Error {
+		p.HandleFatal("Cannot declare a method on " + receiver.Type + ", methods can only be declared on structs")
+		return nil
+	}
+	// the right parenthesis of the receiver takes the place of the function keyword
+	p.Step()
+	parsed := p.ParseFunctionDecl()
+	if parsed == nil {
+		return nil
+	}
+	method := parsed.(FunctionDecl)
+	method.FunctionToken = functionToken
+	method.Receiver = &receiver
+	if DuplicateParam(method.Prototype.Parameters, receiver.Name) {
+		p.HandleFatal("Duplicate parameter " + receiver.Name)
+		return nil
+	}
+	return method
+}
+
+// ParseInterfaceDecl parses an interface declaration
+func (p *Parser) ParseInterfaceDecl() Node {
+	tempInterfaceDecl := InterfaceDecl{InterfaceToken: p.CurrentToken}
+	p.Step()
+	if p.CurrentToken.TokenType == lexer.TEXT {
+		if _, ok := Keywords[p.CurrentToken.Value]; ok {
+			p.HandleFatal("Cannot use keyword " + p.CurrentToken.Value + " as interface name")
+			return nil
+		}
+		if _, ok := p.VarTypes[p.CurrentToken.Value]; ok {
+			p.HandleFatal("Cannot use type name " + p.CurrentToken.Value + " as interface name")
+			return nil
+		}
+		if _, ok := BuiltInFunctions[p.CurrentToken.Value]; ok {
+			p.HandleFatal("Cannot use built-in function name " + p.CurrentToken.Value + " as interface name")
+			return nil
+		}
+	} else {
+		p.HandleFatal("Expected interface name instead of " + p.CurrentToken.Value)
+		return nil
+	}
+	tempInterfaceDecl.Name = p.CurrentToken.Value
+	// add the interface name to the list of types
+	p.VarTypes[tempInterfaceDecl.Name] = nil
+	p.Step()
+	if p.CurrentToken.TokenType != lexer.LBRACE {
+		p.HandleFatal("Expected '{' after interface name")
+		return nil
+	}
+	tempInterfaceDecl.LeftBrace = p.CurrentToken
+	p.Step()
+	for p.CurrentToken.TokenType != lexer.RBRACE {
+		if p.CurrentToken.TokenType == lexer.TEXT && p.CurrentToken.Value == Function {
+			method := p.ParseInterfaceMethod()
+			if method == nil {
+				return nil
+			}
+			tempInterfaceDecl.Methods = append(tempInterfaceDecl.Methods, *method)
+			continue
+		}
+		tempInterfaceDecl.Fields = append(tempInterfaceDecl.Fields, p.ParseStructField())
+		p.Back()
+		if p.CurrentToken.TokenType != lexer.EOL && p.CurrentToken.TokenType != lexer.RBRACE {
+			p.HandleFatal("Expected semicolon or '}' after interface field")
+			return nil
+		}
+		if p.CurrentToken.TokenType == lexer.EOL {
+			p.Step()
+		}
+	}
+	tempInterfaceDecl.RightBrace = p.CurrentToken
+	p.Step()
+	p.DisableEOLChecking()
+	return tempInterfaceDecl
+}
+
+// ParseInterfaceMethod parses a method required by an interface in the form of "function name(...) (...);"
+func (p *Parser) ParseInterfaceMethod() *InterfaceMethod {
+	p.Step()
+	if p.CurrentToken.TokenType != lexer.TEXT {
+		p.HandleFatal("Expected method name instead of " + p.CurrentToken.Value)
+		return nil
+	}
+	if _, ok := Keywords[p.CurrentToken.Value]; ok {
+		p.HandleFatal("Cannot use keyword " + p.CurrentToken.Value + " as method name")
+		return nil
+	}
+	tempMethod := InterfaceMethod{Name: p.CurrentToken.Value}
+	p.Step()
+	prototype, ok := p.ParseSignature()
+	if !ok {
+		return nil
+	}
+	if prototype.LeftRetsParen.Value != "" {
+		prototype.RightRetsParen = p.CurrentToken
+	}
+	tempMethod.Prototype = prototype
+	p.Step()
+	if p.CurrentToken.TokenType == lexer.EOL {
+		p.Step()
+	} else if p.CurrentToken.TokenType != lexer.RBRACE {
+		p.HandleFatal("Expected semicolon or '}' after interface method")
+		return nil
+	}
+	return &tempMethod
+}
+
 // ParseStructField parses a struct field
 func (p *Parser) ParseStructField() StructField {
 	tempStructField := StructField{}
@@ -371,11 +670,96 @@ func (p *Parser) ParseIdent() Node {
 			return tempExpr
 		}
 	} else if p.Peek(1).TokenType == lexer.COLON {
+		if lookAhead := p.Peek(2); lookAhead.TokenType == lexer.TEXT && (lookAhead.Value == For || lookAhead.Value == While) {
+			return p.ParseLabeledStmt()
+		}
 		return p.ParseImplicitVariableDecl()
 	}
 	return p.ParseVariableAssign(nil)
 }
 
+// ParseLabeledStmt parses a loop preceded by a label in the form of "label: for (...) {...}"
+func (p *Parser) ParseLabeledStmt() Stmt {
+	label := p.CurrentToken.Value
+	if _, ok := p.VarTypes[label]; ok {
+		p.HandleFatal("Cannot use type name " + label + " as loop label")
+		return nil
+	}
+	if _, ok := BuiltInFunctions[label]; ok {
+		p.HandleFatal("Cannot use built-in function name " + label + " as loop label")
+		return nil
+	}
+	if contains(label, p.loopLabels) {
+		p.HandleFatal("Loop label " + label + " is already used by an enclosing loop")
+		return nil
+	}
+	p.MultiStep(2)
+	p.pendingLabel = label
+	if p.CurrentToken.Value == While {
+		return p.ParseWhileStmt()
+	}
+	return p.ParseForStmt()
+}
+
+// enterLoop pushes the pending label on the stack of loops being parsed and returns it
+func (p *Parser) enterLoop() string {
+	label := p.pendingLabel
+	p.pendingLabel = ""
+	p.loopLabels = append(p.loopLabels, label)
+	return label
+}
+
+// exitLoop pops the last loop from the stack of loops being parsed
+func (p *Parser) exitLoop() {
+	if len(p.loopLabels) > 0 {
+		p.loopLabels = p.loopLabels[:len(p.loopLabels)-1]
+	}
+}
+
+// parseLoopJumpLabel parses the optional label of a break or continue statement and checks that it refers to an enclosing loop
+func (p *Parser) parseLoopJumpLabel(keyword string) (lexer.Token, string, bool) {
+	var labelToken lexer.Token
+	label := ""
+	if p.CurrentToken.TokenType == lexer.TEXT {
+		labelToken = p.CurrentToken
+		label = p.CurrentToken.Value
+		p.Step()
+	}
+	if len(p.loopLabels) == 0 {
+		p.HandleFatal(keyword + " statement outside of a loop")
+		return labelToken, label, false
+	}
+	if label != "" && !contains(label, p.loopLabels) {
+		p.HandleFatal("Unknown loop label " + label + " in " + keyword + " statement")
+		return labelToken, label, false
+	}
+	return labelToken, label, true
+}
+
+// ParseBreakStmt parses a break statement
+func (p *Parser) ParseBreakStmt() Node {
+	tempBreak := BreakStmt{BreakToken: p.CurrentToken}
+	p.Step()
+	var ok bool
+	tempBreak.LabelToken, tempBreak.Label, ok = p.parseLoopJumpLabel(Break)
+	if !ok {
+		return nil
+	}
+	return tempBreak
+}
+
+// ParseContinueStmt parses a continue statement
+func (p *Parser) ParseContinueStmt() Node {
+	tempContinue := ContinueStmt{ContinueToken: p.CurrentToken}
+	p.Step()
+	var ok bool
+	tempContinue.LabelToken, tempContinue.Label, ok = p.parseLoopJumpLabel(Continue)
+	if !ok {
+		return nil
+	}
+	return tempContinue
+}
+
 // ParseIfStmt parses an if statement
 func (p *Parser) ParseIfStmt() Stmt {
 	tempIf := IfStmt{IfToken: p.CurrentToken}
@@ -448,6 +832,113 @@ func (p *Parser) ParseElseStmt() *ElseStmt {
 	return tempElse
 }
 
+// ParseTryStmt parses a try statement followed by an optional catch clause and an optional finally clause
+func (p *Parser) ParseTryStmt() Stmt {
+	tempTry := TryStmt{TryToken: p.CurrentToken}
+	p.Step()
+	if p.CurrentToken.TokenType != lexer.LBRACE {
+		p.HandleFatal("Expected '{' after try")
+		return nil
+	}
+	tempTry.LeftBrace = p.CurrentToken
+	p.Step()
+	tempTry.Body = p.ParseBody()
+	tempTry.RightBrace = p.CurrentToken
+	p.Step()
+	if p.CurrentToken.TokenType == lexer.TEXT && p.CurrentToken.Value == Catch {
+		tempTry.CatchStmt = p.ParseCatchStmt()
+		if tempTry.CatchStmt == nil {
+			return nil
+		}
+	}
+	if p.CurrentToken.TokenType == lexer.TEXT && p.CurrentToken.Value == Finally {
+		tempTry.FinallyStmt = p.ParseFinallyStmt()
+		if tempTry.FinallyStmt == nil {
+			return nil
+		}
+	}
+	if tempTry.CatchStmt == nil && tempTry.FinallyStmt == nil {
+		p.HandleFatal("Expected catch or finally after try block")
+		return nil
+	}
+	p.DisableEOLChecking()
+	return tempTry
+}
+
+// ParseCatchStmt parses a catch clause in the form of "catch (name) {...}" where the name of the caught error is optional
+func (p *Parser) ParseCatchStmt() *CatchStmt {
+	tempCatch := new(CatchStmt)
+	tempCatch.CatchToken = p.CurrentToken
+	p.Step()
+	if p.CurrentToken.TokenType == lexer.LPAREN {
+		tempCatch.LeftParen = p.CurrentToken
+		p.Step()
+		if p.CurrentToken.TokenType != lexer.TEXT {
+			p.HandleFatal("Expected error name after '(' in catch clause")
+			return nil
+		}
+		if _, ok := Keywords[p.CurrentToken.Value]; ok {
+			p.HandleFatal("Cannot use keyword " + p.CurrentToken.Value + " as error name")
+			return nil
+		}
+		if _, ok := p.VarTypes[p.CurrentToken.Value]; ok {
+			p.HandleFatal("Cannot use type name " + p.CurrentToken.Value + " as error name")
+			return nil
+		}
+		tempCatch.ErrorName = p.CurrentToken.Value
+		tempCatch.ErrorBinding = NewBinding()
+		// the caught error is an instance of the error struct so its fields are not dependencies
+		p.CurrentFile.StructInstances = append(p.CurrentFile.StructInstances, tempCatch.ErrorName)
+		p.CurrentFile.VariableDecl = append(p.CurrentFile.VariableDecl, tempCatch.ErrorName)
+		p.Step()
+		if p.CurrentToken.TokenType != lexer.RPAREN {
+			p.HandleFatal("Expected ')' after error name in catch clause")
+			return nil
+		}
+		tempCatch.RightParen = p.CurrentToken
+		p.Step()
+	}
+	if p.CurrentToken.TokenType != lexer.LBRACE {
+		p.HandleFatal("Expected '{' after catch")
+		return nil
+	}
+	tempCatch.LeftBrace = p.CurrentToken
+	p.Step()
+	tempCatch.Body = p.ParseBody()
+	tempCatch.RightBrace = p.CurrentToken
+	p.Step()
+	return tempCatch
+}
+
+// ParseFinallyStmt parses a finally clause
+func (p *Parser) ParseFinallyStmt() *FinallyStmt {
+	tempFinally := new(FinallyStmt)
+	tempFinally.FinallyToken = p.CurrentToken
+	p.Step()
+	if p.CurrentToken.TokenType != lexer.LBRACE {
+		p.HandleFatal("Expected '{' after finally")
+		return nil
+	}
+	tempFinally.LeftBrace = p.CurrentToken
+	p.Step()
+	tempFinally.Body = p.ParseBody()
+	tempFinally.RightBrace = p.CurrentToken
+	p.Step()
+	return tempFinally
+}
+
+// ParseThrowStmt parses a throw statement
+func (p *Parser) ParseThrowStmt() Node {
+	tempThrow := ThrowStmt{ThrowToken: p.CurrentToken}
+	p.Step()
+	if p.CurrentToken.TokenType == lexer.EOL || p.CurrentToken.TokenType == lexer.EOF {
+		p.HandleFatal("Expected expression after throw")
+		return nil
+	}
+	tempThrow.Value = p.ParseExpr()
+	return tempThrow
+}
+
 // ParseWhileStmt parses a while statement
 func (p *Parser) ParseWhileStmt() Stmt {
 	tempWhile := WhileStmt{WhileToken: p.CurrentToken}
@@ -471,7 +962,9 @@ func (p *Parser) ParseWhileStmt() Stmt {
 	}
 	tempWhile.LeftBrace = p.CurrentToken
 	p.Step()
+	tempWhile.Label = p.enterLoop()
 	tempWhile.Body = p.ParseBody()
+	p.exitLoop()
 	tempWhile.RightBrace = p.CurrentToken
 	p.Step()
 	p.DisableEOLChecking()
@@ -480,7 +973,7 @@ func (p *Parser) ParseWhileStmt() Stmt {
 
 // ParseForStmt parses a for statement
 func (p *Parser) ParseForStmt() Stmt {
-	tempFor := ForStmt{}
+	tempFor := ForStmt{KeyBinding: NewBinding(), ValueBinding: NewBinding()}
 	tempFor.ForToken = p.CurrentToken
 	p.Step()
 	if p.CurrentToken.TokenType != lexer.LPAREN {
@@ -539,7 +1032,9 @@ func (p *Parser) ParseForStmt() Stmt {
 	}
 	tempFor.LeftBrace = p.CurrentToken
 	p.Step()
+	tempFor.Label = p.enterLoop()
 	tempFor.Body = p.ParseBody()
+	p.exitLoop()
 	tempFor.RightBrace = p.CurrentToken
 	p.Step()
 	p.DisableEOLChecking()
@@ -548,7 +1043,7 @@ func (p *Parser) ParseForStmt() Stmt {
 
 // ParseVariableDecl parses a variable declaration
 func (p *Parser) ParseVariableDecl() Decl {
-	tempDecl := VariableDecl{VarToken: p.CurrentToken}
+	tempDecl := VariableDecl{VarToken: p.CurrentToken, Binding: NewBinding()}
 	p.Step()
 	if p.CurrentToken.TokenType == lexer.TEXT {
 		if _, ok := Keywords[p.CurrentToken.Value]; ok {
@@ -575,6 +1070,7 @@ func (p *Parser) ParseVariableDecl() Decl {
 		return nil
 	}
 	tempDecl.Type = typeName
+	tempDecl.TypeEnd = p.Peek(-1)
 	if p.CurrentToken.TokenType != lexer.ASSIGN {
 		if p.CurrentToken.TokenType != lexer.COMMA && p.CurrentToken.TokenType != lexer.EOL && p.CurrentToken.TokenType != lexer.EOF {
 			p.HandleFatal("Expected '=' after variable type")
@@ -597,7 +1093,7 @@ func (p *Parser) ParseVariableDecl() Decl {
 }
 
 func (p *Parser) ParseImplicitVariableDecl() Decl {
-	tempDecl := VariableDecl{VarToken: p.CurrentToken}
+	tempDecl := VariableDecl{VarToken: p.CurrentToken, Binding: NewBinding()}
 	if p.CurrentToken.TokenType == lexer.TEXT {
 		if _, ok := Keywords[p.CurrentToken.Value]; ok {
 			p.HandleFatal("Cannot use keyword " + p.CurrentToken.Value + " as variable name")
@@ -649,7 +1145,7 @@ func (p *Parser) ParseFunctionCallExpr() Expr {
 		p.HandleFatal("Expected function name instead of " + p.CurrentToken.Value)
 		return nil
 	}
-	tempFunctionCall := FunctionCallExpr{FunctionCallToken: p.CurrentToken, Name: p.CurrentToken.Value}
+	tempFunctionCall := FunctionCallExpr{FunctionCallToken: p.CurrentToken, Name: p.CurrentToken.Value, Binding: NewBinding()}
 	p.Step()
 	if p.CurrentToken.TokenType != lexer.LPAREN {
 		p.HandleFatal("Expected '(' after function name")
@@ -765,6 +1261,11 @@ func (p *Parser) ParseFunctionType() string {
 		return ""
 	}
 	tempType += p.CurrentToken.Value
+	// function without arguments
+	if p.Peek(1).TokenType == lexer.RPAREN {
+		p.Step()
+		tempType += p.CurrentToken.Value
+	}
 	// parse arguments types using the parseType function
 	for p.CurrentToken.TokenType != lexer.RPAREN {
 		temp, success := p.ParseType()
@@ -830,6 +1331,7 @@ func (p *Parser) ParseVariableAssign(lhs Expr) Stmt {
 	} else {
 		toAssign = append(toAssign, lhs)
 	}
+	OppToken := p.CurrentToken
 	if _, ok := AssignOperators[p.CurrentToken.Value]; ok {
 		Opp = p.CurrentToken.Value
 	} else {
@@ -839,18 +1341,20 @@ func (p *Parser) ParseVariableAssign(lhs Expr) Stmt {
 	p.Step()
 	if p.CurrentToken.TokenType == lexer.EOL || p.CurrentToken.TokenType == lexer.EOF || p.CurrentToken.TokenType == lexer.RPAREN || p.CurrentToken.TokenType == lexer.RBRACKET || p.CurrentToken.TokenType == lexer.RBRACE {
 		return VariableAssignStmt{
-			VarToken: Var,
-			Names:    toAssign,
-			Operator: Opp,
-			Values:   []Expr{nil},
+			VarToken:      Var,
+			Names:         toAssign,
+			Operator:      Opp,
+			OperatorToken: OppToken,
+			Values:        []Expr{nil},
 		}
 	}
 	rhs := p.ParseVariableAssignSide()
 	return VariableAssignStmt{
-		VarToken: Var,
-		Names:    toAssign,
-		Operator: Opp,
-		Values:   rhs,
+		VarToken:      Var,
+		Names:         toAssign,
+		Operator:      Opp,
+		OperatorToken: OppToken,
+		Values:        rhs,
 	}
 }
 
@@ -909,13 +1413,7 @@ func (p *Parser) ParsePrimaryExpr(exp Expr) Expr {
 
 	if p.CurrentToken.TokenType == lexer.PERIOD {
 		p.Step()
-		selectorDepth++
 		exp = p.ParseSelector(exp)
-		selectorDepth--
-		// check if exp.Expr is a Literal
-		if _, ok := exp.(SelectorExpr).Expr.(Literal); ok && selectorDepth == 0 {
-			p.CurrentFile.AddDependency(exp.(SelectorExpr).Expr.(Literal).Token.Value)
-		}
 	}
 
 	return exp
@@ -963,9 +1461,7 @@ func (p *Parser) ParseSelector(x Expr) Expr {
 	// check if there is a period after the selector to see if it is a selector
 	if p.CurrentToken.TokenType == lexer.PERIOD {
 		p.Step()
-		selectorDepth++
 		selector = p.ParseSelector(selector)
-		selectorDepth--
 	}
 	return SelectorExpr{Field: p.CurrentToken, Expr: x, Sel: selector}
 }
@@ -1054,6 +1550,7 @@ func (p *Parser) ParseImportStmt() Stmt {
 		p.HandleFatal("Expected closing double quote")
 		return nil
 	}
+	tempImportStmt.RightQuote = p.CurrentToken
 
 	p.Step()
 	return tempImportStmt
@@ -1068,13 +1565,12 @@ func (p *Parser) ParseAnonymousFunctionExpr() Expr {
 	}
 	tempAnonymousFunctionDecl.Prototype = p.ParsePrototype()
 	p.Step()
+	// loops enclosing the function cannot be broken from inside its body
+	loopLabels := p.loopLabels
+	p.loopLabels = nil
 	tempAnonymousFunctionDecl.Body = p.ParseBody()
-	// check the parsed prototype parameters and remove them from the list of dependencies if they are inside
-	for _, param := range tempAnonymousFunctionDecl.Prototype.Parameters {
-		if contains(param.Name, p.CurrentFile.Dependencies) {
-			p.CurrentFile.RemoveDependency(param.Name)
-		}
-	}
+	tempAnonymousFunctionDecl.Prototype.RightBrace = p.CurrentToken
+	p.loopLabels = loopLabels
 	p.Step()
 	//check if it is a call
 	if p.CurrentToken.TokenType == lexer.LPAREN {
@@ -1129,20 +1625,18 @@ func (p *Parser) ParseFunctionDecl() Node {
 		p.HandleFatal("Expected '(' after function name")
 		return nil
 	}
-	inFunction = true
+	p.inFunction = true
 	tempFunctionDecl.Prototype = p.ParsePrototype()
 	p.Step()
+	loopLabels := p.loopLabels
+	p.loopLabels = nil
 	tempFunctionDecl.Body = p.ParseBody()
-	// check the parsed prototype parameters and remove them from the list of dependencies if they are inside
-	for _, param := range tempFunctionDecl.Prototype.Parameters {
-		if contains(param.Name, p.CurrentFile.Dependencies) {
-			p.CurrentFile.RemoveDependency(param.Name)
-		}
-	}
+	tempFunctionDecl.Prototype.RightBrace = p.CurrentToken
+	p.loopLabels = loopLabels
 	p.Step()
 	p.CurrentFile.FunctionDecl = append(p.CurrentFile.FunctionDecl, tempFunctionDecl.Name)
 	p.DisableEOLChecking()
-	inFunction = false
+	p.inFunction = false
 	return tempFunctionDecl
 }
 
@@ -1173,16 +1667,85 @@ func (p *Parser) ParseReturnStmt() Node {
 
 // ParseIndexableAccessExpr parses an indexable variable access expression
 func (p *Parser) ParseIndexableAccessExpr() Expr {
-	tempIndexableAccessExpr := IndexableAccessExpr{VariableToken: p.CurrentToken, VariableName: p.CurrentToken.Value}
+	tempIndexableAccessExpr := IndexableAccessExpr{VariableToken: p.CurrentToken, VariableName: p.CurrentToken.Value, Binding: NewBinding()}
 	p.Step()
 	for p.CurrentToken.TokenType == lexer.LBRACKET {
 		p.Step()
 		tempIndexableAccessExpr.Indexes = append(tempIndexableAccessExpr.Indexes, p.ParseExpr())
+		tempIndexableAccessExpr.LastBracket = p.CurrentToken
 		p.Step()
 	}
 	return tempIndexableAccessExpr
 }
 
+// checkEscapes checks the escape sequences of the current STRING or CHAR token, quoted by quote,
+// an invalid sequence is reported at its own position
+func (p *Parser) checkEscapes(quote byte) bool {
+	_, err := lexer.Unquote(p.CurrentToken.Value, quote)
+	if err == nil {
+		return true
+	}
+	col := p.CurrentToken.Position
+	if escapeErr, ok := err.(*lexer.EscapeError); ok {
+		col += utf8.RuneCountInString(p.CurrentToken.Value[:escapeErr.Offset])
+	}
+	p.handleFatalAt(span{startLine: p.CurrentToken.Line, startCol: col, endLine: p.CurrentToken.Line, endCol: col + 1}, err.Error())
+	return false
+}
+
+// ParseInterpolatedStringExpr parses a string holding expressions interpolated with ${}
+func (p *Parser) ParseInterpolatedStringExpr() Expr {
+	tempInterpolatedString := InterpolatedStringExpr{LeftQuote: p.CurrentToken}
+	p.Step()
+	for p.CurrentToken.TokenType != lexer.DQUOTE {
+		switch p.CurrentToken.TokenType {
+		case lexer.STRING:
+			if strings.Contains(p.CurrentToken.Value, "\n") || strings.Contains(p.CurrentToken.Value, "\r") {
+				p.HandleFatal("strings cannot continue on multiple lines")
+				return nil
+			}
+			if !p.checkEscapes('"') {
+				return nil
+			}
+			tempInterpolatedString.Parts = append(tempInterpolatedString.Parts, Literal{Token: p.CurrentToken, Type: lexer.STRING, Value: p.CurrentToken.Value})
+			p.Step()
+		case lexer.INTERPOLATION:
+			p.Step()
+			if p.CurrentToken.TokenType == lexer.RBRACE {
+				p.HandleFatal("Expected expression between ${ and }")
+				return nil
+			}
+			tempInterpolatedString.Parts = append(tempInterpolatedString.Parts, p.ParseExpr())
+			if p.CurrentToken.TokenType != lexer.RBRACE {
+				p.HandleFatal("Expected '}' after interpolated expression")
+				return nil
+			}
+			p.Step()
+		default:
+			p.HandleFatal("Expected '\"' after string value")
+			return nil
+		}
+	}
+	tempInterpolatedString.RightQuote = p.CurrentToken
+	p.Step()
+	return tempInterpolatedString
+}
+
+// checkNumber checks that the current INT or FLOAT token is a well-formed literal which fits in its type
+func (p *Parser) checkNumber() bool {
+	var err error
+	if p.CurrentToken.TokenType == lexer.INT {
+		_, err = lexer.ParseInt(p.CurrentToken.Value)
+	} else {
+		_, err = lexer.ParseFloat(p.CurrentToken.Value)
+	}
+	if err != nil {
+		p.HandleFatal(err.Error())
+		return false
+	}
+	return true
+}
+
 // ParseVariableAccess parses a variable access
 func (p *Parser) ParseVariableAccess() Expr {
 	// check if the variable name is not in the keywords and a text
@@ -1205,13 +1768,16 @@ func (p *Parser) ParseVariableAccess() Expr {
 		p.Back()
 		return temp
 	} else {
-		return Literal{Token: p.CurrentToken, Type: "VAR", Value: p.CurrentToken.Value}
+		return Literal{Token: p.CurrentToken, Type: "VAR", Value: p.CurrentToken.Value, Binding: NewBinding()}
 	}
 }
 
 // ParseLiteral parses a literal
 func (p *Parser) ParseLiteral() Expr {
 	if p.CurrentToken.TokenType == lexer.INT || p.CurrentToken.TokenType == lexer.FLOAT {
+		if !p.checkNumber() {
+			return nil
+		}
 		tempLiteral := Literal{Token: p.CurrentToken, Type: p.CurrentToken.TokenType, Value: p.CurrentToken.Value}
 		p.Step()
 		return tempLiteral
@@ -1238,11 +1804,38 @@ func (p *Parser) ParseLiteral() Expr {
 		p.Step()
 		return tempLiteral
 	}
+	if p.CurrentToken.TokenType == lexer.DQUOTE && (p.Peek(1).TokenType == lexer.INTERPOLATION || p.Peek(2).TokenType == lexer.INTERPOLATION) {
+		return p.ParseInterpolatedStringExpr()
+	}
+	if p.CurrentToken.TokenType == lexer.BACKQUOTE {
+		open := p.CurrentToken
+		p.Step()
+		tempLiteral := Literal{Token: p.CurrentToken, Type: lexer.RAWSTRING, Value: ""}
+		if p.CurrentToken.TokenType == lexer.BACKQUOTE {
+			tempLiteral.Token = quotedToken(tempLiteral.Token, open, p.CurrentToken)
+			p.Step()
+			return tempLiteral
+		}
+		if p.CurrentToken.TokenType != lexer.RAWSTRING {
+			p.HandleFatal("Expected raw string value between backquotes")
+			return nil
+		}
+		tempLiteral.Value = p.CurrentToken.Value
+		p.Step()
+		if p.CurrentToken.TokenType != lexer.BACKQUOTE {
+			p.HandleFatal("Expected '`' after raw string value")
+			return nil
+		}
+		tempLiteral.Token = quotedToken(tempLiteral.Token, open, p.CurrentToken)
+		p.Step()
+		return tempLiteral
+	}
 	if p.CurrentToken.TokenType == lexer.DQUOTE {
+		open := p.CurrentToken
 		p.Step()
 		tempLiteral := Literal{}
 		if p.CurrentToken.TokenType == lexer.DQUOTE {
-			tempLiteral = Literal{Token: p.CurrentToken, Type: lexer.STRING, Value: ""}
+			tempLiteral = Literal{Token: quotedToken(p.CurrentToken, open, p.CurrentToken), Type: lexer.STRING, Value: ""}
 			p.Step()
 			return tempLiteral
 		} else {
@@ -1256,6 +1849,9 @@ func (p *Parser) ParseLiteral() Expr {
 				p.HandleFatal("strings cannot continue on multiple lines")
 				return nil
 			}
+			if !p.checkEscapes('"') {
+				return nil
+			}
 			tempLiteral = Literal{Token: p.CurrentToken, Type: lexer.STRING, Value: p.CurrentToken.Value}
 		}
 
@@ -1264,14 +1860,16 @@ func (p *Parser) ParseLiteral() Expr {
 			p.HandleFatal("Expected '\"' after string value")
 			return nil
 		}
+		tempLiteral.Token = quotedToken(tempLiteral.Token, open, p.CurrentToken)
 		p.Step()
 		return tempLiteral
 	}
 	if p.CurrentToken.TokenType == lexer.SQUOTE {
+		open := p.CurrentToken
 		p.Step()
 		tempLiteral := Literal{}
 		if p.CurrentToken.TokenType == lexer.SQUOTE {
-			tempLiteral = Literal{Token: p.CurrentToken, Type: lexer.CHAR, Value: ""}
+			tempLiteral = Literal{Token: quotedToken(p.CurrentToken, open, p.CurrentToken), Type: lexer.CHAR, Value: ""}
 			p.Step()
 			return tempLiteral
 		} else {
@@ -1285,6 +1883,13 @@ func (p *Parser) ParseLiteral() Expr {
 				p.HandleFatal("chars cannot continue on multiple lines")
 				return nil
 			}
+			if !p.checkEscapes('\'') {
+				return nil
+			}
+			if value, _ := lexer.Unquote(p.CurrentToken.Value, '\''); utf8.RuneCountInString(value) != 1 {
+				p.HandleFatal("'" + p.CurrentToken.Value + "' is not a char, a char holds a single character")
+				return nil
+			}
 			tempLiteral = Literal{Token: p.CurrentToken, Type: lexer.CHAR, Value: p.CurrentToken.Value}
 		}
 
@@ -1293,6 +1898,7 @@ func (p *Parser) ParseLiteral() Expr {
 			p.HandleFatal("Expected ' after char value")
 			return nil
 		}
+		tempLiteral.Token = quotedToken(tempLiteral.Token, open, p.CurrentToken)
 		p.Step()
 		return tempLiteral
 	}
@@ -1323,11 +1929,27 @@ func DuplicateParam(params []FunctionParams, newParam string) bool {
 }
 
 func (p *Parser) ParsePrototype() FunctionPrototype {
+	tempFunctionPrototype, ok := p.ParseSignature()
+	if !ok {
+		return tempFunctionPrototype
+	}
+	p.Step()
+	tempFunctionPrototype.RightRetsParen = p.CurrentToken
+	if p.CurrentToken.TokenType != lexer.LBRACE {
+		p.HandleFatal("Expected '{' after function prototype")
+		return tempFunctionPrototype
+	}
+	tempFunctionPrototype.LeftBrace = p.CurrentToken
+	return tempFunctionPrototype
+}
+
+// ParseSignature parses the parameters and the return types of a function prototype
+func (p *Parser) ParseSignature() (FunctionPrototype, bool) {
 	tempFunctionPrototype := FunctionPrototype{}
 	if p.CurrentToken.TokenType != lexer.LPAREN {
 		p.PrintBacktrace()
 		p.HandleFatal("Expected '(' after function name")
-		return tempFunctionPrototype
+		return tempFunctionPrototype, false
 	}
 	tempFunctionPrototype.LeftParamParen = p.CurrentToken
 	isParen := p.Peek(1)
@@ -1341,12 +1963,12 @@ func (p *Parser) ParsePrototype() FunctionPrototype {
 			p.Step()
 			if p.CurrentToken.TokenType != lexer.COLON {
 				p.HandleFatal("Expected ':' after parameter name")
-				return tempFunctionPrototype
+				return tempFunctionPrototype, false
 			}
 			ParamType, succes := p.ParseType()
 			if !succes {
 				p.HandleFatal("Unknown type " + p.CurrentToken.Value + " for parameter " + ParamName)
-				return tempFunctionPrototype
+				return tempFunctionPrototype, false
 			}
 			p.Back()
 			if !(DuplicateParam(tempFunctionPrototype.Parameters, ParamName)) {
@@ -1354,12 +1976,12 @@ func (p *Parser) ParsePrototype() FunctionPrototype {
 				tempFunctionPrototype.Parameters = append(tempFunctionPrototype.Parameters, newParams)
 			} else {
 				p.HandleFatal("Duplicate parameter " + ParamName)
-				return tempFunctionPrototype
+				return tempFunctionPrototype, false
 			}
 			p.Step()
 			if p.CurrentToken.TokenType != lexer.COMMA && p.CurrentToken.TokenType != lexer.RPAREN {
 				p.HandleFatal("Expected ',' between parameter type")
-				return tempFunctionPrototype
+				return tempFunctionPrototype, false
 			}
 		}
 	} else {
@@ -1373,20 +1995,14 @@ func (p *Parser) ParsePrototype() FunctionPrototype {
 			retType, success := p.ParseType()
 			if !success {
 				p.HandleFatal("Unknown type " + p.CurrentToken.Value + " for return type")
-				return tempFunctionPrototype
+				return tempFunctionPrototype, false
 			}
 			tempFunctionPrototype.ReturnTypes = append(tempFunctionPrototype.ReturnTypes, retType)
 			if p.CurrentToken.TokenType != lexer.COMMA && p.CurrentToken.TokenType != lexer.RPAREN {
 				p.HandleFatal("Expected ',' between return type")
-				return tempFunctionPrototype
+				return tempFunctionPrototype, false
 			}
 		}
 	}
-	p.Step()
-	tempFunctionPrototype.RightRetsParen = p.CurrentToken
-	if p.CurrentToken.TokenType != lexer.LBRACE {
-		p.HandleFatal("Expected '{' after function prototype")
-		return tempFunctionPrototype
-	}
-	return tempFunctionPrototype
+	return tempFunctionPrototype, true
 }
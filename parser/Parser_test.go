@@ -1,16 +1,20 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/Eclalang/Ecla/errorHandler"
 	"github.com/Eclalang/Ecla/lexer"
+	"strings"
 	"testing"
 )
 
 var tok = lexer.Lexer("import \"console\";")
 var unresolved1Tokens = lexer.Lexer("console.println(\"not working\");")
 var unresolved2Tokens = lexer.Lexer("console.println(\"not working\");math.abs(-10);")
+var unresolved3Tokens = lexer.Lexer("function f() {var console int = 1;} console.println(\"not working\");")
 var helloWorld = lexer.Lexer("import \"console\";console.println(\"Hello, World!\");")
+var selectedVarTokens = lexer.Lexer("struct Point {x : int;} function f() {var p = Point{1}; p.x = 2;}")
 var eol = lexer.Lexer("{};")
 
 var nodesTok = [][]lexer.Token{
@@ -61,6 +65,13 @@ var keywords = [][]lexer.Token{
 	lexer.Lexer(Murloc),
 	lexer.Lexer(Any),
 	lexer.Lexer(Struct + " test{}"),
+	lexer.Lexer(Interface + " test{}"),
+	lexer.Lexer(Break),
+	lexer.Lexer(Continue),
+	lexer.Lexer(Try + " {} catch (e) {}"),
+	lexer.Lexer(Throw + " \"error\";"),
+	lexer.Lexer(Catch + " (e) {}"),
+	lexer.Lexer(Finally + " {}"),
 	lexer.Lexer("randomName"),
 }
 
@@ -75,6 +86,7 @@ func resetWithTokens(parser *Parser, tokens []lexer.Token) {
 	tempFile := new(File)
 	tempFile.ParseTree = new(AST)
 	parser.CurrentFile = tempFile
+	parser.errors = nil
 	parser.Tokens = tokens
 	parser.TokenIndex = 0
 	parser.CurrentToken = parser.Tokens[0]
@@ -181,6 +193,17 @@ func TestParser_PrintBacktrace(t *testing.T) {
 	par.PrintBacktrace()
 	// restore the parser to the original state
 	par = TestParser
+
+	var out bytes.Buffer
+	par = Parser{Tokens: lexer.Lexer("var a int = 1;"), Output: &out}
+	par.MultiStep(5)
+	par.PrintBacktrace()
+	if out.String() != "varaint=1\n" {
+		t.Errorf("PrintBacktrace() printed %q instead of %q", out.String(), "varaint=1\n")
+	}
+	if par.TokenIndex != 5 {
+		t.Errorf("PrintBacktrace() moved the parser to %d", par.TokenIndex)
+	}
 }
 
 func TestParser_HandleWarning(t *testing.T) {
@@ -259,6 +282,15 @@ func TestParser_Parse(t *testing.T) {
 		t.Errorf("Parse() did not raise the unsatisfied dependancy error")
 	}
 
+	// a variable declared in another scope does not satisfy the dependency
+	par = TestParser
+	ok = false
+	par.Tokens = unresolved3Tokens
+	par.Parse()
+	if !ok {
+		t.Errorf("Parse() did not raise the unsatisfied dependancy error")
+	}
+
 	par = TestParser
 	ok = false
 	par.Tokens = helloWorld
@@ -266,9 +298,105 @@ func TestParser_Parse(t *testing.T) {
 	if ok {
 		t.Errorf("Parse() raised an error when it should not")
 	}
+
+	par = TestParser
+	ok = false
+	par.Tokens = selectedVarTokens
+	par.Parse()
+	if ok {
+		t.Errorf("Parse() raised an error on a selector on a variable")
+	}
 	e.RestoreExit()
 }
 
+func TestParser_ParseRecovering(t *testing.T) {
+	handler := errorHandler.NewHandler()
+	var exited bool
+	handler.HookExit(func(i int) {
+		exited = true
+	})
+	defer handler.RestoreExit()
+	var out bytes.Buffer
+	par := Parser{Output: &out, Tokens: lexer.Lexer(`import "console";
+var a int = 1
+var b int = ;
+console.println(a);
+function f(x : int) (int) {
+	var y int = x +;
+	return y;
+}
+if (a == 1 {
+	console.println("one");
+}
+var c = 3;
+var d int = 4;
+`), ErrorHandler: handler}
+	file := par.Parse()
+	if exited {
+		t.Errorf("Parse() exited on a syntax error")
+	}
+	lines := []int{3, 3, 6, 9, 12}
+	if len(par.Errors()) != len(lines) {
+		t.Fatalf("Parse() reported %d errors instead of %d: %v", len(par.Errors()), len(lines), par.Errors())
+	}
+	for i, err := range par.Errors() {
+		if err.Line != lines[i] {
+			t.Errorf("error %d is at line %d instead of %d", i, err.Line, lines[i])
+		}
+		if err.Level != errorHandler.LevelFatal {
+			t.Errorf("error %d has level %s", i, err.Level)
+		}
+	}
+	if len(handler.Errors) != len(lines) {
+		t.Errorf("the errors were not collected by the ErrorHandler")
+	}
+	// the recovery does not use the try blocks of the ErrorHandler nor dump the tokens
+	if handler.InTry() {
+		t.Errorf("Parse() left the ErrorHandler in a try block")
+	}
+	if out.Len() != 0 {
+		t.Errorf("Parse() printed %q while recovering", out.String())
+	}
+	// import, var a, console.println, function f and var d are parsed
+	ops := file.ParseTree.Operations
+	if len(ops) != 5 {
+		t.Fatalf("expected 5 nodes in the partial AST, got %d", len(ops))
+	}
+	if ops[1].(VariableDecl).Name != "a" || ops[4].(VariableDecl).Name != "d" {
+		t.Errorf("Parse() did not keep the statements without errors")
+	}
+	fn := ops[3].(FunctionDecl)
+	if len(fn.Body) != 1 {
+		t.Errorf("expected the function body to keep its valid statement, got %d nodes", len(fn.Body))
+	}
+
+	// a body without closing brace
+	par = Parser{Tokens: lexer.Lexer(`function f() { var a int = 1;`), ErrorHandler: handler}
+	par.Parse()
+	if exited || len(par.Errors()) != 1 {
+		t.Errorf("Parse() did not report the missing brace, got %v", par.Errors())
+	}
+}
+
+func TestParser_Synchronise(t *testing.T) {
+	par := TestParser
+	resetWithTokens(&par, lexer.Lexer(`a b { c; }; d; e;`))
+	par.Synchronise(false)
+	if par.CurrentToken.TokenType != lexer.EOL || par.Peek(1).Value != "d" {
+		t.Errorf("Synchronise() did not stop after the block, stopped at %v", par.CurrentToken)
+	}
+	resetWithTokens(&par, lexer.Lexer(`a b } c;`))
+	par.Synchronise(true)
+	if par.Peek(1).TokenType != lexer.RBRACE {
+		t.Errorf("Synchronise() skipped the closing brace of the body")
+	}
+	resetWithTokens(&par, lexer.Lexer(`a b c`))
+	par.Synchronise(false)
+	if par.Peek(1).TokenType != lexer.EOF {
+		t.Errorf("Synchronise() did not stop before the end of the file")
+	}
+}
+
 func TestParser_ParseFile(t *testing.T) {
 	// save the current state of the parser
 	par := TestParser
@@ -425,6 +553,89 @@ func TestParser_ParseStructDecl(t *testing.T) {
 		t.Errorf("ParseStructDecl() did not raise the missing semicolon error")
 	}
 	ok = false
+	// test the struct with a method declared in its body
+	resetWithTokens(&par, lexer.Lexer("struct Point{x : int; function getX() (int) {return self.x;} y : int;}"))
+	tree := par.ParseStructDecl()
+	if ok {
+		t.Errorf("ParseStructDecl() raised an error when it should not")
+	}
+	structDecl := tree.(StructDecl)
+	if len(structDecl.Fields) != 2 || len(structDecl.Methods) != 1 {
+		t.Errorf("ParseStructDecl() did not parse the fields and the methods of the struct")
+	} else if receiver := structDecl.Methods[0].Receiver; receiver == nil || receiver.Name != Self || receiver.Type != "Point" {
+		t.Errorf("ParseStructDecl() did not set the receiver of the method")
+	}
+	ok = false
+
+	e.RestoreExit()
+}
+
+func TestParser_ParseInterfaceDecl(t *testing.T) {
+	var ok bool
+	var f = func(i int) {
+		ok = i == 1
+	}
+	e.HookExit(f)
+
+	par := TestParser
+	resetWithTokens(&par, lexer.Lexer("interface Shape{name : string; function area() (int); function scale(k : int, n : int);}"))
+	tree := par.ParseInterfaceDecl()
+	if ok {
+		t.Errorf("ParseInterfaceDecl() raised an error when it should not")
+	}
+	interfaceDecl := tree.(InterfaceDecl)
+	if len(interfaceDecl.Fields) != 1 || interfaceDecl.Fields[0].Name != "name" {
+		t.Errorf("ParseInterfaceDecl() did not parse the fields of the interface")
+	}
+	if len(interfaceDecl.Methods) != 2 {
+		t.Errorf("ParseInterfaceDecl() did not parse the methods of the interface")
+	} else {
+		if interfaceDecl.Methods[0].Name != "area" || len(interfaceDecl.Methods[0].Prototype.ReturnTypes) != 1 {
+			t.Errorf("ParseInterfaceDecl() did not parse the prototype of the method area")
+		}
+		if interfaceDecl.Methods[1].Name != "scale" || len(interfaceDecl.Methods[1].Prototype.Parameters) != 2 {
+			t.Errorf("ParseInterfaceDecl() did not parse the prototype of the method scale")
+		}
+	}
+	if _, isType := par.VarTypes["Shape"]; !isType {
+		t.Errorf("ParseInterfaceDecl() did not add the interface to the types")
+	}
+	ok = false
+	// interface with type as name
+	resetWithTokens(&par, lexer.Lexer("interface int{}"))
+	par.ParseInterfaceDecl()
+	if !ok {
+		t.Errorf("ParseInterfaceDecl() did not raise the invalid name error")
+	}
+	ok = false
+	// interface with keyword as name
+	resetWithTokens(&par, lexer.Lexer("interface var{}"))
+	par.ParseInterfaceDecl()
+	if !ok {
+		t.Errorf("ParseInterfaceDecl() did not raise the invalid name error")
+	}
+	ok = false
+	// interface without left brace
+	resetWithTokens(&par, lexer.Lexer("interface Shape 1{}"))
+	par.ParseInterfaceDecl()
+	if !ok {
+		t.Errorf("ParseInterfaceDecl() did not raise the missing left brace error")
+	}
+	ok = false
+	// interface method with a body
+	resetWithTokens(&par, lexer.Lexer("interface Shape{function area() (int) {return 1;}}"))
+	par.ParseInterfaceDecl()
+	if !ok {
+		t.Errorf("ParseInterfaceDecl() did not raise the missing semicolon error")
+	}
+	ok = false
+	// interface method without name
+	resetWithTokens(&par, lexer.Lexer("interface Shape{function () (int);}"))
+	par.ParseInterfaceDecl()
+	if !ok {
+		t.Errorf("ParseInterfaceDecl() did not raise the missing method name error")
+	}
+	ok = false
 
 	e.RestoreExit()
 }
@@ -637,6 +848,209 @@ func TestParser_ParseWhileStmt(t *testing.T) {
 
 }
 
+func TestParser_ParseBreakStmt(t *testing.T) {
+	var ok bool
+	var f = func(i int) {
+		ok = i == 1
+	}
+	e.HookExit(f)
+
+	par := TestParser
+
+	// break inside a loop
+	resetWithTokens(&par, lexer.Lexer("while (true){break;}"))
+	tree := par.ParseWhileStmt()
+	if ok {
+		t.Errorf("ParseBreakStmt() raised an error when it should not")
+	}
+	if _, isBreak := tree.(WhileStmt).Body[0].(BreakStmt); !isBreak {
+		t.Errorf("ParseBreakStmt() did not return a BreakStmt")
+	}
+	ok = false
+	// labeled break inside a labeled loop
+	resetWithTokens(&par, lexer.Lexer("outer: for (i:=0,i<10,i++){while (true){break outer;}}"))
+	tree = par.ParseIdent().(Stmt)
+	if ok {
+		t.Errorf("ParseBreakStmt() raised an error when it should not")
+	}
+	if tree.(ForStmt).Label != "outer" {
+		t.Errorf("ParseLabeledStmt() did not set the label of the loop")
+	}
+	if tree.(ForStmt).Body[0].(WhileStmt).Body[0].(BreakStmt).Label != "outer" {
+		t.Errorf("ParseBreakStmt() did not set the label of the break statement")
+	}
+	ok = false
+	// break outside a loop
+	resetWithTokens(&par, lexer.Lexer("break;"))
+	par.ParseBreakStmt()
+	if !ok {
+		t.Errorf("ParseBreakStmt() did not raise the break outside of a loop error")
+	}
+	ok = false
+	// break with an unknown label
+	resetWithTokens(&par, lexer.Lexer("while (true){break outer;}"))
+	par.ParseWhileStmt()
+	// the error is raised in the body of the loop, the parser recovers from it
+	if len(par.Errors()) != 1 {
+		t.Errorf("ParseBreakStmt() did not raise the unknown label error")
+	}
+	ok = false
+	// break inside a function declared inside a loop
+	resetWithTokens(&par, lexer.Lexer("while (true){function test(){break;}}"))
+	par.ParseWhileStmt()
+	// the error is raised in the body of the loop, the parser recovers from it
+	if len(par.Errors()) != 1 {
+		t.Errorf("ParseBreakStmt() did not raise the break outside of a loop error inside a function")
+	}
+	e.RestoreExit()
+}
+
+func TestParser_ParseContinueStmt(t *testing.T) {
+	var ok bool
+	var f = func(i int) {
+		ok = i == 1
+	}
+	e.HookExit(f)
+
+	par := TestParser
+
+	// continue inside a loop
+	resetWithTokens(&par, lexer.Lexer("for (i:=0,i<10,i++){continue;}"))
+	tree := par.ParseForStmt()
+	if ok {
+		t.Errorf("ParseContinueStmt() raised an error when it should not")
+	}
+	if _, isContinue := tree.(ForStmt).Body[0].(ContinueStmt); !isContinue {
+		t.Errorf("ParseContinueStmt() did not return a ContinueStmt")
+	}
+	ok = false
+	// continue outside a loop
+	resetWithTokens(&par, lexer.Lexer("continue;"))
+	par.ParseContinueStmt()
+	if !ok {
+		t.Errorf("ParseContinueStmt() did not raise the continue outside of a loop error")
+	}
+	ok = false
+	// labeled loop using an already used label
+	resetWithTokens(&par, lexer.Lexer("outer: while (true){outer: while (true){continue outer;}}"))
+	par.ParseIdent()
+	// the error is raised in the body of the loop, the parser recovers from it
+	if len(par.Errors()) != 1 {
+		t.Errorf("ParseLabeledStmt() did not raise the duplicate label error")
+	}
+	e.RestoreExit()
+}
+
+func TestParser_ParseTryStmt(t *testing.T) {
+	var ok bool
+	var f = func(i int) {
+		ok = i == 1
+	}
+	e.HookExit(f)
+
+	par := TestParser
+
+	// try with catch and finally
+	resetWithTokens(&par, lexer.Lexer("try {throw \"error\";} catch (err) {a := 1;} finally {b := 2;}"))
+	tree := par.ParseTryStmt()
+	if ok {
+		t.Errorf("ParseTryStmt() raised an error when it should not")
+	}
+	tryStmt := tree.(TryStmt)
+	if _, isThrow := tryStmt.Body[0].(ThrowStmt); !isThrow {
+		t.Errorf("ParseTryStmt() did not parse the throw statement of the body")
+	}
+	if tryStmt.CatchStmt == nil || tryStmt.CatchStmt.ErrorName != "err" || len(tryStmt.CatchStmt.Body) != 1 {
+		t.Errorf("ParseTryStmt() did not parse the catch clause")
+	}
+	if tryStmt.FinallyStmt == nil || len(tryStmt.FinallyStmt.Body) != 1 {
+		t.Errorf("ParseTryStmt() did not parse the finally clause")
+	}
+	ok = false
+	// try with a catch clause without error name
+	resetWithTokens(&par, lexer.Lexer("try {a := 1;} catch {a := 2;}"))
+	tree = par.ParseTryStmt()
+	if ok {
+		t.Errorf("ParseTryStmt() raised an error when it should not")
+	}
+	if tree.(TryStmt).CatchStmt.ErrorName != "" || tree.(TryStmt).FinallyStmt != nil {
+		t.Errorf("ParseTryStmt() did not parse the catch clause without error name")
+	}
+	ok = false
+	// try with only a finally clause
+	resetWithTokens(&par, lexer.Lexer("try {a := 1;} finally {a := 2;}"))
+	tree = par.ParseTryStmt()
+	if ok {
+		t.Errorf("ParseTryStmt() raised an error when it should not")
+	}
+	if tree.(TryStmt).CatchStmt != nil || tree.(TryStmt).FinallyStmt == nil {
+		t.Errorf("ParseTryStmt() did not parse the finally clause")
+	}
+	ok = false
+	// try without catch nor finally
+	resetWithTokens(&par, lexer.Lexer("try {a := 1;}"))
+	par.ParseTryStmt()
+	if !ok {
+		t.Errorf("ParseTryStmt() did not raise the missing catch or finally error")
+	}
+	ok = false
+	// try without brace
+	resetWithTokens(&par, lexer.Lexer("try a := 1;"))
+	par.ParseTryStmt()
+	if !ok {
+		t.Errorf("ParseTryStmt() did not raise the missing brace error")
+	}
+	ok = false
+	// catch with a type name as error name
+	resetWithTokens(&par, lexer.Lexer("try {} catch (int) {}"))
+	par.ParseTryStmt()
+	if !ok {
+		t.Errorf("ParseCatchStmt() did not raise the type name error")
+	}
+	ok = false
+	// catch with a missing closing parenthesis
+	resetWithTokens(&par, lexer.Lexer("try {} catch (e {}"))
+	par.ParseTryStmt()
+	if !ok {
+		t.Errorf("ParseCatchStmt() did not raise the missing parenthesis error")
+	}
+	ok = false
+	// finally without brace
+	resetWithTokens(&par, lexer.Lexer("try {} finally a := 1;"))
+	par.ParseTryStmt()
+	if !ok {
+		t.Errorf("ParseFinallyStmt() did not raise the missing brace error")
+	}
+	e.RestoreExit()
+}
+
+func TestParser_ParseThrowStmt(t *testing.T) {
+	var ok bool
+	var f = func(i int) {
+		ok = i == 1
+	}
+	e.HookExit(f)
+
+	par := TestParser
+
+	resetWithTokens(&par, lexer.Lexer("throw \"error\";"))
+	tree := par.ParseThrowStmt()
+	if ok {
+		t.Errorf("ParseThrowStmt() raised an error when it should not")
+	}
+	if tree.(ThrowStmt).Value.(Literal).Value != "error" {
+		t.Errorf("ParseThrowStmt() did not parse the thrown value")
+	}
+	ok = false
+	// throw without value
+	resetWithTokens(&par, lexer.Lexer("throw;"))
+	par.ParseThrowStmt()
+	if !ok {
+		t.Errorf("ParseThrowStmt() did not raise the missing expression error")
+	}
+	e.RestoreExit()
+}
+
 func TestParser_ParseForStmt(t *testing.T) {
 	// hook the error handler to avoid the fatal errors from the keywords not completing
 	var ok bool
@@ -1134,6 +1548,11 @@ func TestParser_ParseFunctionType(t *testing.T) {
 	if par.ParseFunctionType() == "" {
 		t.Errorf("ParseFunctionType() did not return the correct type")
 	}
+	// function type without arguments
+	resetWithTokens(&par, lexer.Lexer("functions()(int)"))
+	if typ := par.ParseFunctionType(); typ != "function()(int)" {
+		t.Errorf("ParseFunctionType() returned %s instead of function()(int)", typ)
+	}
 
 	e.RestoreExit()
 }
@@ -1663,6 +2082,85 @@ func TestParser_ParseFunctionDecl(t *testing.T) {
 	e.RestoreExit()
 }
 
+func TestParser_ParseMethodDecl(t *testing.T) {
+	par := TestParser
+
+	var ok bool
+
+	e.HookExit(func(i int) {
+		ok = i == 1
+	})
+
+	// method declaration
+	resetWithTokens(&par, lexer.Lexer("function (p : Point) move(dx : int) {p.x += dx;}"))
+	par.VarTypes["Point"] = "struct"
+	if !par.IsMethodDecl() {
+		t.Errorf("IsMethodDecl() did not detect the method declaration")
+	}
+	tree := par.ParseMethodDecl()
+	if ok {
+		t.Errorf("ParseMethodDecl() raised an error when it should not")
+	}
+	method := tree.(FunctionDecl)
+	if method.Name != "move" || method.Receiver == nil || method.Receiver.Name != "p" || method.Receiver.Type != "Point" {
+		t.Errorf("ParseMethodDecl() did not parse the receiver and the name of the method")
+	}
+	if method.FunctionToken.Value != "function" {
+		t.Errorf("ParseMethodDecl() did not keep the function keyword token")
+	}
+	ok = false
+	// function declaration is not a method declaration
+	resetWithTokens(&par, lexer.Lexer("function move(dx : int) {dx += 1;}"))
+	if par.IsMethodDecl() {
+		t.Errorf("IsMethodDecl() detected a method declaration in a function declaration")
+	}
+	// anonymous function is not a method declaration
+	resetWithTokens(&par, lexer.Lexer("function (dx : int) {dx += 1;}"))
+	if par.IsMethodDecl() {
+		t.Errorf("IsMethodDecl() detected a method declaration in an anonymous function")
+	}
+	// method declaration on a default type
+	resetWithTokens(&par, lexer.Lexer("function (i : int) double() (int) {return i * 2;}"))
+	par.VarTypes["Point"] = "struct"
+	par.ParseMethodDecl()
+	if !ok {
+		t.Errorf("ParseMethodDecl() did not raise the invalid receiver type error")
+	}
+	ok = false
+	// method declaration on the built-in error struct
+	resetWithTokens(&par, lexer.Lexer("function (err : error) describe() (string) {return err.message;}"))
+	par.ParseMethodDecl()
+	if !ok {
+		t.Errorf("ParseMethodDecl() did not raise the invalid receiver type error")
+	}
+	ok = false
+	// method declaration on an unknown type
+	resetWithTokens(&par, lexer.Lexer("function (u : Unknown) double() (int) {return 2;}"))
+	par.ParseMethodDecl()
+	if !ok {
+		t.Errorf("ParseMethodDecl() did not raise the invalid receiver type error")
+	}
+	ok = false
+	// method declaration with keyword as receiver name
+	resetWithTokens(&par, lexer.Lexer("function (var : Point) move(dx : int) {dx += 1;}"))
+	par.VarTypes["Point"] = "struct"
+	par.ParseMethodDecl()
+	if !ok {
+		t.Errorf("ParseMethodDecl() did not raise the invalid receiver name error")
+	}
+	ok = false
+	// method declaration with a parameter named like the receiver
+	resetWithTokens(&par, lexer.Lexer("function (p : Point) move(p : int) {p += 1;}"))
+	par.VarTypes["Point"] = "struct"
+	par.ParseMethodDecl()
+	if !ok {
+		t.Errorf("ParseMethodDecl() did not raise the duplicate parameter error")
+	}
+	ok = false
+
+	e.RestoreExit()
+}
+
 func TestParser_ParseReturnStmt(t *testing.T) {
 	// save the current state of the parser
 	par := TestParser
@@ -1887,6 +2385,91 @@ func TestParser_ParseLiteral(t *testing.T) {
 		t.Errorf("ParseLiteral() did not raise the missing single quote error")
 	}
 	ok = false
+	// string and char literals with escape sequences
+	resetWithTokens(&par, lexer.Lexer(`"a\n\"\u{1F600}"`))
+	par.ParseLiteral()
+	if ok {
+		t.Errorf("ParseLiteral() raised an error when it should not")
+	}
+	resetWithTokens(&par, lexer.Lexer(`'\''`))
+	par.ParseLiteral()
+	if ok {
+		t.Errorf("ParseLiteral() raised an error when it should not")
+	}
+	// string literal with an invalid escape sequence
+	resetWithTokens(&par, lexer.Lexer(`"a\q"`))
+	if par.ParseLiteral() != nil || !ok {
+		t.Errorf("ParseLiteral() did not raise the invalid escape sequence error")
+	}
+	ok = false
+	// char literal with an invalid escape sequence
+	resetWithTokens(&par, lexer.Lexer(`'\u{D800}'`))
+	if par.ParseLiteral() != nil || !ok {
+		t.Errorf("ParseLiteral() did not raise the invalid escape sequence error")
+	}
+	ok = false
+	// char literals holding several characters
+	for _, code := range []string{`'ab'`, `'\n\t'`, `'é!'`} {
+		resetWithTokens(&par, lexer.Lexer(code))
+		if par.ParseLiteral() != nil || !ok {
+			t.Errorf("ParseLiteral() did not raise the error of the char %s holding several characters", code)
+		}
+		ok = false
+	}
+	// char literals holding a single character
+	for _, code := range []string{`'é'`, `'\u{1F600}'`, `'\\'`} {
+		resetWithTokens(&par, lexer.Lexer(code))
+		if lit, isLiteral := par.ParseLiteral().(Literal); ok || !isLiteral || lit.Type != lexer.CHAR {
+			t.Errorf("ParseLiteral() did not return the char %s", code)
+		}
+		ok = false
+	}
+	// numeric literals with a prefix, separators or an exponent
+	for _, code := range []string{"0x1F", "0b1010", "0o17", "1_000", "1.5e-3", "2E10"} {
+		resetWithTokens(&par, lexer.Lexer(code))
+		par.ParseLiteral()
+		if ok {
+			t.Errorf("ParseLiteral() raised an error for %s when it should not", code)
+		}
+	}
+	// raw strings
+	for _, code := range []string{"`a\\q\"\n${b}`", "``"} {
+		resetWithTokens(&par, lexer.Lexer(code))
+		lit, isLiteral := par.ParseLiteral().(Literal)
+		if ok || !isLiteral || lit.Type != lexer.RAWSTRING {
+			t.Errorf("ParseLiteral() did not return the raw string %s", code)
+		}
+	}
+	// raw string without its closing backquote
+	resetWithTokens(&par, lexer.Lexer("`abc"))
+	if par.ParseLiteral() != nil || !ok {
+		t.Errorf("ParseLiteral() did not raise the missing backquote error")
+	}
+	ok = false
+	// interpolated string
+	resetWithTokens(&par, lexer.Lexer(`"Hello ${name}, you are ${age + 1}!"`))
+	interpolated, isInterpolated := par.ParseLiteral().(InterpolatedStringExpr)
+	if ok || !isInterpolated || len(interpolated.Parts) != 5 || interpolated.StartPos() != 1 || interpolated.EndPos() != 37 {
+		t.Errorf("ParseLiteral() did not return the interpolated string, got %v", interpolated)
+	} else if _, isBinary := interpolated.Parts[3].(BinaryExpr); !isBinary || interpolated.Parts[4].(Literal).Value != "!" {
+		t.Errorf("ParseLiteral() did not parse the parts of the interpolated string, got %v", interpolated.Parts)
+	}
+	// invalid interpolated strings
+	for _, code := range []string{`"${}"`, `"${a"`, `"${a b}"`, `"${a}\q"`} {
+		resetWithTokens(&par, lexer.Lexer(code))
+		if par.ParseLiteral() != nil || !ok {
+			t.Errorf("ParseLiteral() did not raise an error for %s", code)
+		}
+		ok = false
+	}
+	// malformed or overflowing numeric literals
+	for _, code := range []string{"0x", "1__0", "99999999999999999999", "1e39"} {
+		resetWithTokens(&par, lexer.Lexer(code))
+		if par.ParseLiteral() != nil || !ok {
+			t.Errorf("ParseLiteral() did not raise the invalid number error for %s", code)
+		}
+		ok = false
+	}
 
 	e.RestoreExit()
 }
@@ -1997,3 +2580,50 @@ func TestParser_ParsePrototype(t *testing.T) {
 	e.RestoreExit()
 
 }
+
+func TestParser_Spans(t *testing.T) {
+	code := "import \"console\";\n" +
+		"var a int;\n" +
+		"a++;\n" +
+		"var b []int = [1, 2];\n" +
+		"b[0] += 3;\n" +
+		"if (a < 1) {\n} else if (a > 2) {\n} else {\n}\n" +
+		"function f(x : int) (int) {\n\treturn x;\n}\n" +
+		"console.println(a);"
+	expected := [][4]int{
+		// start line, start position, end line, end position
+		{1, 1, 1, 17},
+		{2, 1, 2, 10},
+		{3, 1, 3, 4},
+		{4, 1, 4, 21},
+		{5, 1, 5, 10},
+		{6, 1, 9, 2},
+		{10, 1, 12, 2},
+		{13, 1, 13, 19},
+	}
+	lines := strings.Split(code, "\n")
+	par := Parser{Tokens: lexer.Lexer(code), ErrorHandler: errorHandler.NewHandler()}
+	nodes := par.Parse().ParseTree.Operations
+	if len(par.errors) > 0 {
+		t.Fatalf("Parse() raised %v", par.errors)
+	}
+	if len(nodes) != len(expected) {
+		t.Fatalf("Parse() returned %d nodes instead of %d", len(nodes), len(expected))
+	}
+	for i, node := range nodes {
+		span := [4]int{node.StartLine(), node.StartPos(), node.EndLine(), node.EndPos()}
+		if span != expected[i] {
+			t.Errorf("node %d spans %v instead of %v", i, span, expected[i])
+		}
+		// the offsets cut the lines of the node out of the code, without the semicolon ending the statement
+		text := strings.TrimSuffix(strings.Join(lines[span[0]-1:span[2]], "\n"), ";")
+		if code[node.StartOffset():node.EndOffset()] != text {
+			t.Errorf("node %d is read as %q instead of %q", i, code[node.StartOffset():node.EndOffset()], text)
+		}
+	}
+	// the nodes nested in a statement have their own offsets
+	value := nodes[3].(VariableDecl).Value.(ArrayLiteral).Values[1]
+	if code[value.StartOffset():value.EndOffset()] != "2" {
+		t.Errorf("the nested node is read as %q instead of %q", code[value.StartOffset():value.EndOffset()], "2")
+	}
+}
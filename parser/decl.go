@@ -4,6 +4,7 @@ import "github.com/Eclalang/Ecla/lexer"
 
 type FunctionDecl struct {
 	FunctionToken lexer.Token
+	Receiver      *FunctionParams
 	Name          string
 	Prototype     FunctionPrototype
 	Body          []Node
@@ -14,7 +15,7 @@ func (f FunctionDecl) StartPos() int {
 }
 
 func (f FunctionDecl) EndPos() int {
-	return f.Prototype.RightBrace.Position
+	return f.Prototype.RightBrace.EndPosition
 }
 
 func (f FunctionDecl) StartLine() int {
@@ -22,7 +23,15 @@ func (f FunctionDecl) StartLine() int {
 }
 
 func (f FunctionDecl) EndLine() int {
-	return f.Prototype.RightBrace.Line
+	return f.Prototype.RightBrace.EndLine
+}
+
+func (f FunctionDecl) StartOffset() int {
+	return f.FunctionToken.Offset
+}
+
+func (f FunctionDecl) EndOffset() int {
+	return f.Prototype.RightBrace.EndOffset
 }
 
 func (f FunctionDecl) declNode() {}
@@ -32,6 +41,7 @@ type StructDecl struct {
 	Name        string
 	LeftBrace   lexer.Token
 	Fields      []StructField
+	Methods     []FunctionDecl
 	RightBrace  lexer.Token
 }
 
@@ -40,7 +50,7 @@ func (s StructDecl) StartPos() int {
 }
 
 func (s StructDecl) EndPos() int {
-	return s.RightBrace.Position
+	return s.RightBrace.EndPosition
 }
 
 func (s StructDecl) StartLine() int {
@@ -48,16 +58,69 @@ func (s StructDecl) StartLine() int {
 }
 
 func (s StructDecl) EndLine() int {
-	return s.RightBrace.Line
+	return s.RightBrace.EndLine
+}
+
+func (s StructDecl) StartOffset() int {
+	return s.StructToken.Offset
+}
+
+func (s StructDecl) EndOffset() int {
+	return s.RightBrace.EndOffset
 }
 
 func (s StructDecl) declNode() {}
 
+type InterfaceDecl struct {
+	InterfaceToken lexer.Token
+	Name           string
+	LeftBrace      lexer.Token
+	Fields         []StructField
+	Methods        []InterfaceMethod
+	RightBrace     lexer.Token
+}
+
+func (i InterfaceDecl) StartPos() int {
+	return i.InterfaceToken.Position
+}
+
+func (i InterfaceDecl) EndPos() int {
+	return i.RightBrace.EndPosition
+}
+
+func (i InterfaceDecl) StartLine() int {
+	return i.InterfaceToken.Line
+}
+
+func (i InterfaceDecl) EndLine() int {
+	return i.RightBrace.EndLine
+}
+
+func (i InterfaceDecl) StartOffset() int {
+	return i.InterfaceToken.Offset
+}
+
+func (i InterfaceDecl) EndOffset() int {
+	return i.RightBrace.EndOffset
+}
+
+func (i InterfaceDecl) declNode() {}
+
+// InterfaceMethod is a method that a struct must have to implement an interface.
+type InterfaceMethod struct {
+	Name      string
+	Prototype FunctionPrototype
+}
+
 type VariableDecl struct {
 	VarToken lexer.Token
 	Name     string
 	Type     string
-	Value    Expr
+	// TypeEnd is the last token of the type, it ends a declaration without a value
+	TypeEnd lexer.Token
+	Value   Expr
+	// Binding is the slot of the variable in the scope in which it is declared, it is set by Resolve
+	Binding *Binding
 }
 
 func (v VariableDecl) StartPos() int {
@@ -65,6 +128,9 @@ func (v VariableDecl) StartPos() int {
 }
 
 func (v VariableDecl) EndPos() int {
+	if v.Value == nil {
+		return v.TypeEnd.EndPosition
+	}
 	return v.Value.EndPos()
 }
 
@@ -73,7 +139,21 @@ func (v VariableDecl) StartLine() int {
 }
 
 func (v VariableDecl) EndLine() int {
+	if v.Value == nil {
+		return v.TypeEnd.EndLine
+	}
 	return v.Value.EndLine()
 }
 
+func (v VariableDecl) StartOffset() int {
+	return v.VarToken.Offset
+}
+
+func (v VariableDecl) EndOffset() int {
+	if v.Value == nil {
+		return v.TypeEnd.EndOffset
+	}
+	return v.Value.EndOffset()
+}
+
 func (v VariableDecl) declNode() {}
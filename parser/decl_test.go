@@ -7,17 +7,21 @@ import (
 
 var f FunctionDecl = FunctionDecl{
 	FunctionToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "function",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "function",
+		Position:    1,
+		Line:        1,
+		EndPosition: 9,
+		EndLine:     1,
 	},
 	Prototype: FunctionPrototype{
 		RightBrace: lexer.Token{
-			TokenType: lexer.RBRACE,
-			Value:     "}",
-			Position:  0,
-			Line:      12,
+			TokenType:   lexer.RBRACE,
+			Value:       "}",
+			Position:    0,
+			Line:        12,
+			EndPosition: 1,
+			EndLine:     12,
 		},
 	},
 }
@@ -29,7 +33,7 @@ func TestFunctionDecl_StartPos(t *testing.T) {
 }
 
 func TestFunctionDecl_EndPos(t *testing.T) {
-	if f.EndPos() != 0 {
+	if f.EndPos() != 1 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -52,16 +56,20 @@ func TestFunctionDecl_declNode(t *testing.T) {
 
 var s StructDecl = StructDecl{
 	StructToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "struct",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "struct",
+		Position:    1,
+		Line:        1,
+		EndPosition: 7,
+		EndLine:     1,
 	},
 	RightBrace: lexer.Token{
-		TokenType: lexer.RBRACE,
-		Value:     "}",
-		Position:  0,
-		Line:      12,
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    0,
+		Line:        12,
+		EndPosition: 1,
+		EndLine:     12,
 	},
 }
 
@@ -72,7 +80,7 @@ func TestStructDecl_StartPos(t *testing.T) {
 }
 
 func TestStructDecl_EndPos(t *testing.T) {
-	if s.EndPos() != 0 {
+	if s.EndPos() != 1 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -93,34 +101,89 @@ func TestStructDecl_declNode(t *testing.T) {
 	s.declNode()
 }
 
+var i InterfaceDecl = InterfaceDecl{
+	InterfaceToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "interface",
+		Position:    1,
+		Line:        1,
+		EndPosition: 10,
+		EndLine:     1,
+	},
+	RightBrace: lexer.Token{
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    0,
+		Line:        12,
+		EndPosition: 1,
+		EndLine:     12,
+	},
+}
+
+func TestInterfaceDecl_StartPos(t *testing.T) {
+	if i.StartPos() != 1 {
+		t.Error("StartPos failed to return the correct value")
+	}
+}
+
+func TestInterfaceDecl_EndPos(t *testing.T) {
+	if i.EndPos() != 1 {
+		t.Error("EndPos failed to return the correct value")
+	}
+}
+
+func TestInterfaceDecl_StartLine(t *testing.T) {
+	if i.StartLine() != 1 {
+		t.Error("StartLine failed to return the correct value")
+	}
+}
+
+func TestInterfaceDecl_EndLine(t *testing.T) {
+	if i.EndLine() != 12 {
+		t.Error("EndLine failed to return the correct value")
+	}
+}
+
+func TestInterfaceDecl_declNode(t *testing.T) {
+	i.declNode()
+}
+
 var v VariableDecl = VariableDecl{
 	VarToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "var",
-		Position:  0,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "var",
+		Position:    0,
+		Line:        1,
+		EndPosition: 3,
+		EndLine:     1,
 	},
 	Value: &BinaryExpr{
 		LeftExpr: &Literal{
 			Token: lexer.Token{
-				TokenType: lexer.INT,
-				Value:     "1",
-				Position:  12,
-				Line:      1,
+				TokenType:   lexer.INT,
+				Value:       "1",
+				Position:    12,
+				Line:        1,
+				EndPosition: 13,
+				EndLine:     1,
 			},
 		},
 		Operator: lexer.Token{
-			TokenType: lexer.ADD,
-			Value:     "+",
-			Position:  13,
-			Line:      1,
+			TokenType:   lexer.ADD,
+			Value:       "+",
+			Position:    13,
+			Line:        1,
+			EndPosition: 14,
+			EndLine:     1,
 		},
 		RightExpr: &Literal{
 			Token: lexer.Token{
-				TokenType: lexer.INT,
-				Value:     "1",
-				Position:  14,
-				Line:      1,
+				TokenType:   lexer.INT,
+				Value:       "1",
+				Position:    14,
+				Line:        1,
+				EndPosition: 15,
+				EndLine:     1,
 			},
 		},
 	},
@@ -133,7 +196,7 @@ func TestVariableDecl_StartPos(t *testing.T) {
 }
 
 func TestVariableDecl_EndPos(t *testing.T) {
-	if v.EndPos() != 14 {
+	if v.EndPos() != 15 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
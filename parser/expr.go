@@ -14,7 +14,7 @@ func (f AnonymousFunctionCallExpr) StartPos() int {
 }
 
 func (f AnonymousFunctionCallExpr) EndPos() int {
-	return f.RightParen.Position
+	return f.RightParen.EndPosition
 }
 
 func (f AnonymousFunctionCallExpr) StartLine() int {
@@ -22,7 +22,15 @@ func (f AnonymousFunctionCallExpr) StartLine() int {
 }
 
 func (f AnonymousFunctionCallExpr) EndLine() int {
-	return f.RightParen.Line
+	return f.RightParen.EndLine
+}
+
+func (f AnonymousFunctionCallExpr) StartOffset() int {
+	return f.AnonymousFunction.StartOffset()
+}
+
+func (f AnonymousFunctionCallExpr) EndOffset() int {
+	return f.RightParen.EndOffset
 }
 
 func (f AnonymousFunctionCallExpr) precedence() int {
@@ -42,7 +50,7 @@ func (f AnonymousFunctionExpr) StartPos() int {
 }
 
 func (f AnonymousFunctionExpr) EndPos() int {
-	return f.Prototype.RightBrace.Position
+	return f.Prototype.RightBrace.EndPosition
 }
 
 func (f AnonymousFunctionExpr) StartLine() int {
@@ -50,7 +58,15 @@ func (f AnonymousFunctionExpr) StartLine() int {
 }
 
 func (f AnonymousFunctionExpr) EndLine() int {
-	return f.Prototype.RightBrace.Line
+	return f.Prototype.RightBrace.EndLine
+}
+
+func (f AnonymousFunctionExpr) StartOffset() int {
+	return f.FunctionToken.Offset
+}
+
+func (f AnonymousFunctionExpr) EndOffset() int {
+	return f.Prototype.RightBrace.EndOffset
 }
 
 func (f AnonymousFunctionExpr) precedence() int {
@@ -70,7 +86,7 @@ func (p ArrayLiteral) StartPos() int {
 }
 
 func (p ArrayLiteral) EndPos() int {
-	return p.RBRACKET.Position
+	return p.RBRACKET.EndPosition
 }
 
 func (p ArrayLiteral) StartLine() int {
@@ -78,7 +94,15 @@ func (p ArrayLiteral) StartLine() int {
 }
 
 func (p ArrayLiteral) EndLine() int {
-	return p.RBRACKET.Line
+	return p.RBRACKET.EndLine
+}
+
+func (p ArrayLiteral) StartOffset() int {
+	return p.LBRACKET.Offset
+}
+
+func (p ArrayLiteral) EndOffset() int {
+	return p.RBRACKET.EndOffset
 }
 
 func (p ArrayLiteral) precedence() int {
@@ -110,6 +134,14 @@ func (b BinaryExpr) EndLine() int {
 	return b.RightExpr.EndLine()
 }
 
+func (b BinaryExpr) StartOffset() int {
+	return b.LeftExpr.StartOffset()
+}
+
+func (b BinaryExpr) EndOffset() int {
+	return b.RightExpr.EndOffset()
+}
+
 func (b BinaryExpr) precedence() int {
 	return TokenPrecedence(b.Operator)
 }
@@ -122,6 +154,8 @@ type FunctionCallExpr struct {
 	LeftParen         lexer.Token
 	RightParen        lexer.Token
 	Args              []Expr
+	// Binding is where the function is found when it is a variable, it is set by Resolve
+	Binding *Binding
 }
 
 func (f FunctionCallExpr) StartPos() int {
@@ -129,7 +163,7 @@ func (f FunctionCallExpr) StartPos() int {
 }
 
 func (f FunctionCallExpr) EndPos() int {
-	return f.RightParen.Position
+	return f.RightParen.EndPosition
 }
 
 func (f FunctionCallExpr) StartLine() int {
@@ -137,7 +171,15 @@ func (f FunctionCallExpr) StartLine() int {
 }
 
 func (f FunctionCallExpr) EndLine() int {
-	return f.RightParen.Line
+	return f.RightParen.EndLine
+}
+
+func (f FunctionCallExpr) StartOffset() int {
+	return f.FunctionCallToken.Offset
+}
+
+func (f FunctionCallExpr) EndOffset() int {
+	return f.RightParen.EndOffset
 }
 
 func (f FunctionCallExpr) precedence() int {
@@ -151,6 +193,8 @@ type IndexableAccessExpr struct {
 	VariableName  string
 	Indexes       []Expr
 	LastBracket   lexer.Token
+	// Binding is where the variable accessed is found, it is set by Resolve
+	Binding *Binding
 }
 
 func (a IndexableAccessExpr) StartPos() int {
@@ -158,7 +202,7 @@ func (a IndexableAccessExpr) StartPos() int {
 }
 
 func (a IndexableAccessExpr) EndPos() int {
-	return a.LastBracket.Position
+	return a.LastBracket.EndPosition
 }
 
 func (a IndexableAccessExpr) StartLine() int {
@@ -166,7 +210,15 @@ func (a IndexableAccessExpr) StartLine() int {
 }
 
 func (a IndexableAccessExpr) EndLine() int {
-	return a.LastBracket.Line
+	return a.LastBracket.EndLine
+}
+
+func (a IndexableAccessExpr) StartOffset() int {
+	return a.VariableToken.Offset
+}
+
+func (a IndexableAccessExpr) EndOffset() int {
+	return a.LastBracket.EndOffset
 }
 
 func (a IndexableAccessExpr) precedence() int {
@@ -175,11 +227,51 @@ func (a IndexableAccessExpr) precedence() int {
 
 func (a IndexableAccessExpr) exprNode() {}
 
+// InterpolatedStringExpr is a string made of the texts and of the expressions interpolated with ${} between its quotes,
+// the texts are STRING literals
+type InterpolatedStringExpr struct {
+	LeftQuote  lexer.Token
+	Parts      []Expr
+	RightQuote lexer.Token
+}
+
+func (s InterpolatedStringExpr) StartPos() int {
+	return s.LeftQuote.Position
+}
+
+func (s InterpolatedStringExpr) EndPos() int {
+	return s.RightQuote.EndPosition
+}
+
+func (s InterpolatedStringExpr) StartLine() int {
+	return s.LeftQuote.Line
+}
+
+func (s InterpolatedStringExpr) EndLine() int {
+	return s.RightQuote.EndLine
+}
+
+func (s InterpolatedStringExpr) StartOffset() int {
+	return s.LeftQuote.Offset
+}
+
+func (s InterpolatedStringExpr) EndOffset() int {
+	return s.RightQuote.EndOffset
+}
+
+func (s InterpolatedStringExpr) precedence() int {
+	return HighestPrecedence
+}
+
+func (s InterpolatedStringExpr) exprNode() {}
+
 // Literal is a struct that defines a literal value for all types
 type Literal struct {
 	Token lexer.Token
 	Type  string
 	Value string
+	// Binding is where the variable of a VAR literal is found, it is set by Resolve
+	Binding *Binding
 }
 
 func (l Literal) StartPos() int {
@@ -187,7 +279,7 @@ func (l Literal) StartPos() int {
 }
 
 func (l Literal) EndPos() int {
-	return l.Token.Position
+	return l.Token.EndPosition
 }
 
 func (l Literal) StartLine() int {
@@ -195,7 +287,15 @@ func (l Literal) StartLine() int {
 }
 
 func (l Literal) EndLine() int {
-	return l.Token.Line
+	return l.Token.EndLine
+}
+
+func (l Literal) StartOffset() int {
+	return l.Token.Offset
+}
+
+func (l Literal) EndOffset() int {
+	return l.Token.EndOffset
 }
 
 func (l Literal) precedence() int {
@@ -216,7 +316,7 @@ func (m MapLiteral) StartPos() int {
 }
 
 func (m MapLiteral) EndPos() int {
-	return m.RBRACE.Position
+	return m.RBRACE.EndPosition
 }
 
 func (m MapLiteral) StartLine() int {
@@ -224,7 +324,15 @@ func (m MapLiteral) StartLine() int {
 }
 
 func (m MapLiteral) EndLine() int {
-	return m.RBRACE.Line
+	return m.RBRACE.EndLine
+}
+
+func (m MapLiteral) StartOffset() int {
+	return m.LBRACE.Offset
+}
+
+func (m MapLiteral) EndOffset() int {
+	return m.RBRACE.EndOffset
 }
 
 func (m MapLiteral) precedence() int {
@@ -245,7 +353,7 @@ func (p ParenExpr) StartPos() int {
 }
 
 func (p ParenExpr) EndPos() int {
-	return p.Rparen.Position
+	return p.Rparen.EndPosition
 }
 
 func (p ParenExpr) StartLine() int {
@@ -253,7 +361,15 @@ func (p ParenExpr) StartLine() int {
 }
 
 func (p ParenExpr) EndLine() int {
-	return p.Rparen.Line
+	return p.Rparen.EndLine
+}
+
+func (p ParenExpr) StartOffset() int {
+	return p.Lparen.Offset
+}
+
+func (p ParenExpr) EndOffset() int {
+	return p.Rparen.EndOffset
 }
 
 func (p ParenExpr) precedence() int {
@@ -269,6 +385,9 @@ type SelectorExpr struct {
 }
 
 func (s SelectorExpr) StartPos() int {
+	if s.Expr != nil {
+		return s.Expr.StartPos()
+	}
 	return s.Field.Position
 }
 
@@ -277,6 +396,9 @@ func (s SelectorExpr) EndPos() int {
 }
 
 func (s SelectorExpr) StartLine() int {
+	if s.Expr != nil {
+		return s.Expr.StartLine()
+	}
 	return s.Field.Line
 }
 
@@ -284,6 +406,17 @@ func (s SelectorExpr) EndLine() int {
 	return s.Sel.EndLine()
 }
 
+func (s SelectorExpr) StartOffset() int {
+	if s.Expr != nil {
+		return s.Expr.StartOffset()
+	}
+	return s.Field.Offset
+}
+
+func (s SelectorExpr) EndOffset() int {
+	return s.Sel.EndOffset()
+}
+
 func (s SelectorExpr) precedence() int {
 	return HighestPrecedence
 }
@@ -303,7 +436,7 @@ func (s StructInstantiationExpr) StartPos() int {
 }
 
 func (s StructInstantiationExpr) EndPos() int {
-	return s.RightBrace.Position
+	return s.RightBrace.EndPosition
 }
 
 func (s StructInstantiationExpr) StartLine() int {
@@ -311,7 +444,15 @@ func (s StructInstantiationExpr) StartLine() int {
 }
 
 func (s StructInstantiationExpr) EndLine() int {
-	return s.RightBrace.Line
+	return s.RightBrace.EndLine
+}
+
+func (s StructInstantiationExpr) StartOffset() int {
+	return s.StructNameToken.Offset
+}
+
+func (s StructInstantiationExpr) EndOffset() int {
+	return s.RightBrace.EndOffset
 }
 
 func (s StructInstantiationExpr) precedence() int {
@@ -342,6 +483,14 @@ func (u UnaryExpr) EndLine() int {
 	return u.RightExpr.EndLine()
 }
 
+func (u UnaryExpr) StartOffset() int {
+	return u.Operator.Offset
+}
+
+func (u UnaryExpr) EndOffset() int {
+	return u.RightExpr.EndOffset()
+}
+
 func (u UnaryExpr) precedence() int {
 	return TokenPrecedence(u.Operator)
 }
@@ -7,49 +7,63 @@ import (
 
 var aFunc = AnonymousFunctionExpr{
 	FunctionToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "function",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.TEXT,
+		Value:       "function",
+		Position:    0,
+		Line:        0,
+		EndPosition: 8,
+		EndLine:     0,
 	},
 	Prototype: FunctionPrototype{
 		LeftParamParen: lexer.Token{
-			TokenType: lexer.LPAREN,
-			Value:     "(",
-			Position:  1,
-			Line:      0,
+			TokenType:   lexer.LPAREN,
+			Value:       "(",
+			Position:    1,
+			Line:        0,
+			EndPosition: 2,
+			EndLine:     0,
 		},
 		RightParamParen: lexer.Token{
-			TokenType: lexer.RPAREN,
-			Value:     ")",
-			Position:  2,
-			Line:      0,
+			TokenType:   lexer.RPAREN,
+			Value:       ")",
+			Position:    2,
+			Line:        0,
+			EndPosition: 3,
+			EndLine:     0,
 		},
 		Parameters: []FunctionParams{},
 		LeftRetsParen: lexer.Token{
-			TokenType: lexer.LPAREN,
-			Value:     "(",
-			Position:  3,
-			Line:      0,
+			TokenType:   lexer.LPAREN,
+			Value:       "(",
+			Position:    3,
+			Line:        0,
+			EndPosition: 4,
+			EndLine:     0,
 		},
 		RightRetsParen: lexer.Token{
-			TokenType: lexer.RPAREN,
-			Value:     ")",
-			Position:  4,
-			Line:      0,
+			TokenType:   lexer.RPAREN,
+			Value:       ")",
+			Position:    4,
+			Line:        0,
+			EndPosition: 5,
+			EndLine:     0,
 		},
 		ReturnTypes: []string{},
 		LeftBrace: lexer.Token{
-			TokenType: lexer.LBRACE,
-			Value:     "{",
-			Position:  5,
-			Line:      0,
+			TokenType:   lexer.LBRACE,
+			Value:       "{",
+			Position:    5,
+			Line:        0,
+			EndPosition: 6,
+			EndLine:     0,
 		},
 		RightBrace: lexer.Token{
-			TokenType: lexer.RBRACE,
-			Value:     "}",
-			Position:  6,
-			Line:      3,
+			TokenType:   lexer.RBRACE,
+			Value:       "}",
+			Position:    6,
+			Line:        3,
+			EndPosition: 7,
+			EndLine:     3,
 		},
 	},
 	Body: []Node{},
@@ -58,16 +72,20 @@ var aFunc = AnonymousFunctionExpr{
 var aCall = AnonymousFunctionCallExpr{
 	AnonymousFunction: aFunc,
 	LeftParen: lexer.Token{
-		TokenType: lexer.LPAREN,
-		Value:     "(",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.LPAREN,
+		Value:       "(",
+		Position:    0,
+		Line:        0,
+		EndPosition: 1,
+		EndLine:     0,
 	},
 	RightParen: lexer.Token{
-		TokenType: lexer.RPAREN,
-		Value:     ")",
-		Position:  6,
-		Line:      3,
+		TokenType:   lexer.RPAREN,
+		Value:       ")",
+		Position:    6,
+		Line:        3,
+		EndPosition: 7,
+		EndLine:     3,
 	},
 	Args: []Expr{},
 }
@@ -79,7 +97,7 @@ func TestAnonymousFunctionCallExpr_StartPos(t *testing.T) {
 }
 
 func TestAnonymousFunctionCallExpr_EndPos(t *testing.T) {
-	if aCall.EndPos() != 6 {
+	if aCall.EndPos() != 7 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -113,7 +131,7 @@ func TestAnonymousFunctionExpr_StartPos(t *testing.T) {
 }
 
 func TestAnonymousFunctionExpr_EndPos(t *testing.T) {
-	if aFunc.EndPos() != 6 {
+	if aFunc.EndPos() != 7 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -142,16 +160,20 @@ func TestAnonymousFunctionExpr_exprNode(t *testing.T) {
 
 var arrLiteral = ArrayLiteral{
 	LBRACKET: lexer.Token{
-		TokenType: lexer.LBRACKET,
-		Value:     "[",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.LBRACKET,
+		Value:       "[",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	RBRACKET: lexer.Token{
-		TokenType: lexer.RBRACKET,
-		Value:     "]",
-		Position:  2,
-		Line:      1,
+		TokenType:   lexer.RBRACKET,
+		Value:       "]",
+		Position:    2,
+		Line:        1,
+		EndPosition: 3,
+		EndLine:     1,
 	},
 	Values: []Expr{},
 }
@@ -163,7 +185,7 @@ func TestArrayLiteral_StartPos(t *testing.T) {
 }
 
 func TestArrayLiteral_EndPos(t *testing.T) {
-	if arrLiteral.EndPos() != 2 {
+	if arrLiteral.EndPos() != 3 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -193,26 +215,32 @@ func TestArrayLiteral_exprNode(t *testing.T) {
 var bExpr = BinaryExpr{
 	LeftExpr: Literal{
 		Token: lexer.Token{
-			TokenType: lexer.TEXT,
-			Value:     "a",
-			Position:  0,
-			Line:      0,
+			TokenType:   lexer.TEXT,
+			Value:       "a",
+			Position:    0,
+			Line:        0,
+			EndPosition: 1,
+			EndLine:     0,
 		},
 		Type:  "VAR",
 		Value: "a",
 	},
 	Operator: lexer.Token{
-		TokenType: lexer.ADD,
-		Value:     "+",
-		Position:  1,
-		Line:      0,
+		TokenType:   lexer.ADD,
+		Value:       "+",
+		Position:    1,
+		Line:        0,
+		EndPosition: 2,
+		EndLine:     0,
 	},
 	RightExpr: Literal{
 		Token: lexer.Token{
-			TokenType: lexer.TEXT,
-			Value:     "b",
-			Position:  2,
-			Line:      0,
+			TokenType:   lexer.TEXT,
+			Value:       "b",
+			Position:    2,
+			Line:        0,
+			EndPosition: 3,
+			EndLine:     0,
 		},
 	},
 }
@@ -224,7 +252,7 @@ func TestBinaryExpr_StartPos(t *testing.T) {
 }
 
 func TestBinaryExpr_EndPos(t *testing.T) {
-	if bExpr.EndPos() != 2 {
+	if bExpr.EndPos() != 3 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -253,23 +281,29 @@ func TestBinaryExpr_exprNode(t *testing.T) {
 
 var fCall = FunctionCallExpr{
 	FunctionCallToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "f",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.TEXT,
+		Value:       "f",
+		Position:    0,
+		Line:        0,
+		EndPosition: 1,
+		EndLine:     0,
 	},
 	Name: "f",
 	LeftParen: lexer.Token{
-		TokenType: lexer.LPAREN,
-		Value:     "(",
-		Position:  1,
-		Line:      0,
+		TokenType:   lexer.LPAREN,
+		Value:       "(",
+		Position:    1,
+		Line:        0,
+		EndPosition: 2,
+		EndLine:     0,
 	},
 	RightParen: lexer.Token{
-		TokenType: lexer.RPAREN,
-		Value:     ")",
-		Position:  2,
-		Line:      0,
+		TokenType:   lexer.RPAREN,
+		Value:       ")",
+		Position:    2,
+		Line:        0,
+		EndPosition: 3,
+		EndLine:     0,
 	},
 	Args: []Expr{},
 }
@@ -281,7 +315,7 @@ func TestFunctionCallExpr_StartPos(t *testing.T) {
 }
 
 func TestFunctionCallExpr_EndPos(t *testing.T) {
-	if fCall.EndPos() != 2 {
+	if fCall.EndPos() != 3 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -310,29 +344,35 @@ func TestFunctionCallExpr_exprNode(t *testing.T) {
 
 var iAccess = IndexableAccessExpr{
 	VariableToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "a",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.TEXT,
+		Value:       "a",
+		Position:    0,
+		Line:        0,
+		EndPosition: 1,
+		EndLine:     0,
 	},
 	VariableName: "a",
 	Indexes: []Expr{
 		Literal{
 			Token: lexer.Token{
-				TokenType: lexer.TEXT,
-				Value:     "0",
-				Position:  1,
-				Line:      0,
+				TokenType:   lexer.TEXT,
+				Value:       "0",
+				Position:    1,
+				Line:        0,
+				EndPosition: 2,
+				EndLine:     0,
 			},
 			Type:  "INT",
 			Value: "0",
 		},
 	},
 	LastBracket: lexer.Token{
-		TokenType: lexer.RBRACKET,
-		Value:     "]",
-		Position:  2,
-		Line:      0,
+		TokenType:   lexer.RBRACKET,
+		Value:       "]",
+		Position:    2,
+		Line:        0,
+		EndPosition: 3,
+		EndLine:     0,
 	},
 }
 
@@ -343,7 +383,7 @@ func TestIndexableAccessExpr_StartPos(t *testing.T) {
 }
 
 func TestIndexableAccessExpr_EndPos(t *testing.T) {
-	if iAccess.EndPos() != 2 {
+	if iAccess.EndPos() != 3 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -370,12 +410,68 @@ func TestIndexableAccessExpr_exprNode(t *testing.T) {
 	iAccess.exprNode()
 }
 
+var iString = InterpolatedStringExpr{
+	LeftQuote: lexer.Token{
+		TokenType:   lexer.DQUOTE,
+		Value:       "\"",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
+	},
+	Parts: []Expr{},
+	RightQuote: lexer.Token{
+		TokenType:   lexer.DQUOTE,
+		Value:       "\"",
+		Position:    6,
+		Line:        1,
+		EndPosition: 7,
+		EndLine:     1,
+	},
+}
+
+func TestInterpolatedStringExpr_StartPos(t *testing.T) {
+	if iString.StartPos() != 1 {
+		t.Error("StartPos failed to return the correct value")
+	}
+}
+
+func TestInterpolatedStringExpr_EndPos(t *testing.T) {
+	if iString.EndPos() != 7 {
+		t.Error("EndPos failed to return the correct value")
+	}
+}
+
+func TestInterpolatedStringExpr_StartLine(t *testing.T) {
+	if iString.StartLine() != 1 {
+		t.Error("StartLine failed to return the correct value")
+	}
+}
+
+func TestInterpolatedStringExpr_EndLine(t *testing.T) {
+	if iString.EndLine() != 1 {
+		t.Error("EndLine failed to return the correct value")
+	}
+}
+
+func TestInterpolatedStringExpr_precedence(t *testing.T) {
+	if iString.precedence() != HighestPrecedence {
+		t.Error("precedence failed to return the correct value")
+	}
+}
+
+func TestInterpolatedStringExpr_exprNode(t *testing.T) {
+	iString.exprNode()
+}
+
 var l = Literal{
 	Token: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "a",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.TEXT,
+		Value:       "a",
+		Position:    0,
+		Line:        0,
+		EndPosition: 1,
+		EndLine:     0,
 	},
 	Type:  "VAR",
 	Value: "a",
@@ -388,7 +484,7 @@ func TestLiteral_StartPos(t *testing.T) {
 }
 
 func TestLiteral_EndPos(t *testing.T) {
-	if l.EndPos() != 0 {
+	if l.EndPos() != 1 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -417,18 +513,22 @@ func TestLiteral_exprNode(t *testing.T) {
 
 var mLiteral = MapLiteral{
 	LBRACE: lexer.Token{
-		TokenType: lexer.LBRACE,
-		Value:     "{",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.LBRACE,
+		Value:       "{",
+		Position:    0,
+		Line:        0,
+		EndPosition: 1,
+		EndLine:     0,
 	},
 	Keys: []Expr{
 		Literal{
 			Token: lexer.Token{
-				TokenType: lexer.TEXT,
-				Value:     "a",
-				Position:  1,
-				Line:      0,
+				TokenType:   lexer.TEXT,
+				Value:       "a",
+				Position:    1,
+				Line:        0,
+				EndPosition: 2,
+				EndLine:     0,
 			},
 			Type:  "VAR",
 			Value: "a",
@@ -437,20 +537,24 @@ var mLiteral = MapLiteral{
 	Values: []Expr{
 		Literal{
 			Token: lexer.Token{
-				TokenType: lexer.TEXT,
-				Value:     "b",
-				Position:  3,
-				Line:      0,
+				TokenType:   lexer.TEXT,
+				Value:       "b",
+				Position:    3,
+				Line:        0,
+				EndPosition: 4,
+				EndLine:     0,
 			},
 			Type:  "VAR",
 			Value: "b",
 		},
 	},
 	RBRACE: lexer.Token{
-		TokenType: lexer.RBRACE,
-		Value:     "}",
-		Position:  4,
-		Line:      0,
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    4,
+		Line:        0,
+		EndPosition: 5,
+		EndLine:     0,
 	},
 }
 
@@ -461,7 +565,7 @@ func TestMapLiteral_StartPos(t *testing.T) {
 }
 
 func TestMapLiteral_EndPos(t *testing.T) {
-	if mLiteral.EndPos() != 4 {
+	if mLiteral.EndPos() != 5 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -490,26 +594,32 @@ func TestMapLiteral_exprNode(t *testing.T) {
 
 var pExpr = ParenExpr{
 	Lparen: lexer.Token{
-		TokenType: lexer.LPAREN,
-		Value:     "(",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.LPAREN,
+		Value:       "(",
+		Position:    0,
+		Line:        0,
+		EndPosition: 1,
+		EndLine:     0,
 	},
 	Expression: Literal{
 		Token: lexer.Token{
-			TokenType: lexer.TEXT,
-			Value:     "a",
-			Position:  1,
-			Line:      0,
+			TokenType:   lexer.TEXT,
+			Value:       "a",
+			Position:    1,
+			Line:        0,
+			EndPosition: 2,
+			EndLine:     0,
 		},
 		Type:  "VAR",
 		Value: "a",
 	},
 	Rparen: lexer.Token{
-		TokenType: lexer.RPAREN,
-		Value:     ")",
-		Position:  2,
-		Line:      0,
+		TokenType:   lexer.RPAREN,
+		Value:       ")",
+		Position:    2,
+		Line:        0,
+		EndPosition: 3,
+		EndLine:     0,
 	},
 }
 
@@ -520,7 +630,7 @@ func TestParenExpr_StartPos(t *testing.T) {
 }
 
 func TestParenExpr_EndPos(t *testing.T) {
-	if pExpr.EndPos() != 2 {
+	if pExpr.EndPos() != 3 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -549,27 +659,33 @@ func TestParenExpr_exprNode(t *testing.T) {
 
 var sExpr = SelectorExpr{
 	Field: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "a",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.TEXT,
+		Value:       "a",
+		Position:    0,
+		Line:        0,
+		EndPosition: 1,
+		EndLine:     0,
 	},
 	Expr: Literal{
 		Token: lexer.Token{
-			TokenType: lexer.TEXT,
-			Value:     "b",
-			Position:  1,
-			Line:      0,
+			TokenType:   lexer.TEXT,
+			Value:       "b",
+			Position:    1,
+			Line:        0,
+			EndPosition: 2,
+			EndLine:     0,
 		},
 		Type:  "VAR",
 		Value: "b",
 	},
 	Sel: Literal{
 		Token: lexer.Token{
-			TokenType: lexer.TEXT,
-			Value:     "c",
-			Position:  2,
-			Line:      0,
+			TokenType:   lexer.TEXT,
+			Value:       "c",
+			Position:    2,
+			Line:        0,
+			EndPosition: 3,
+			EndLine:     0,
 		},
 		Type:  "VAR",
 		Value: "c",
@@ -577,13 +693,16 @@ var sExpr = SelectorExpr{
 }
 
 func TestSelectorExpr_StartPos(t *testing.T) {
-	if sExpr.StartPos() != 0 {
+	if sExpr.StartPos() != 1 {
 		t.Error("StartPos failed to return the correct value")
 	}
+	if (SelectorExpr{Field: sExpr.Field}).StartPos() != 0 {
+		t.Error("StartPos failed to return the correct value without an expression")
+	}
 }
 
 func TestSelectorExpr_EndPos(t *testing.T) {
-	if sExpr.EndPos() != 2 {
+	if sExpr.EndPos() != 3 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -612,23 +731,29 @@ func TestSelectorExpr_exprNode(t *testing.T) {
 
 var sInstance = StructInstantiationExpr{
 	StructNameToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "a",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.TEXT,
+		Value:       "a",
+		Position:    0,
+		Line:        0,
+		EndPosition: 1,
+		EndLine:     0,
 	},
 	Name: "a",
 	LeftBrace: lexer.Token{
-		TokenType: lexer.LBRACE,
-		Value:     "{",
-		Position:  1,
-		Line:      0,
+		TokenType:   lexer.LBRACE,
+		Value:       "{",
+		Position:    1,
+		Line:        0,
+		EndPosition: 2,
+		EndLine:     0,
 	},
 	RightBrace: lexer.Token{
-		TokenType: lexer.RBRACE,
-		Value:     "}",
-		Position:  2,
-		Line:      0,
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    2,
+		Line:        0,
+		EndPosition: 3,
+		EndLine:     0,
 	},
 	Args: []Expr{},
 }
@@ -640,7 +765,7 @@ func TestStructInstantiationExpr_StartPos(t *testing.T) {
 }
 
 func TestStructInstantiationExpr_EndPos(t *testing.T) {
-	if sInstance.EndPos() != 2 {
+	if sInstance.EndPos() != 3 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -669,17 +794,21 @@ func TestStructInstantiationExpr_exprNode(t *testing.T) {
 
 var uExpr = UnaryExpr{
 	Operator: lexer.Token{
-		TokenType: lexer.SUB,
-		Value:     "-",
-		Position:  0,
-		Line:      0,
+		TokenType:   lexer.SUB,
+		Value:       "-",
+		Position:    0,
+		Line:        0,
+		EndPosition: 1,
+		EndLine:     0,
 	},
 	RightExpr: Literal{
 		Token: lexer.Token{
-			TokenType: lexer.TEXT,
-			Value:     "a",
-			Position:  1,
-			Line:      0,
+			TokenType:   lexer.TEXT,
+			Value:       "a",
+			Position:    1,
+			Line:        0,
+			EndPosition: 2,
+			EndLine:     0,
 		},
 		Type:  "VAR",
 		Value: "a",
@@ -693,7 +822,7 @@ func TestUnaryExpr_StartPos(t *testing.T) {
 }
 
 func TestUnaryExpr_EndPos(t *testing.T) {
-	if uExpr.EndPos() != 1 {
+	if uExpr.EndPos() != 2 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -0,0 +1,305 @@
+package parser
+
+import "github.com/Eclalang/Ecla/lexer"
+
+// Unresolved is the slot of the variables that must be looked up by their name.
+const Unresolved = -1
+
+// Binding is where a variable is found during the execution:
+// in the slot Slot of the scope Depth scopes above the scope in which it is used.
+type Binding struct {
+	Depth int
+	Slot  int
+}
+
+// NewBinding returns a binding looking the variable up by its name, until the file is resolved.
+func NewBinding() *Binding {
+	return &Binding{Slot: Unresolved}
+}
+
+// IsResolved returns true if the variable is found by its slot instead of its name.
+func (b *Binding) IsResolved() bool {
+	return b != nil && b.Slot != Unresolved
+}
+
+// resolverScope mirrors a scope created by the interpreter.
+type resolverScope struct {
+	// names maps the variables declared in the scope to their slot,
+	// the functions and the imports are set by name and are mapped to Unresolved
+	names map[string]int
+	slots int
+	// function is true for the scope of a function, or for the main scope
+	function bool
+}
+
+// resolver binds the variables to the slot of their declaration.
+type resolver struct {
+	file   *File
+	scopes []*resolverScope
+}
+
+// Resolve binds every variable used in the file to the slot of its declaration.
+// The variables declared outside of the function using them are found in the scopes the function captured.
+// The variables of the main scope are still looked up by name, the next files executed in the same Env can use them.
+// The names selected from without being declared in the scopes using them are added to the dependencies of the file.
+func (f *File) Resolve() {
+	if f.ParseTree == nil {
+		return
+	}
+	r := &resolver{file: f}
+	r.push(true)
+	// the functions can use the variables of the main scope declared after them
+	for _, node := range f.ParseTree.Operations {
+		if decl, ok := node.(VariableDecl); ok {
+			r.declareByName(decl.Name)
+		}
+	}
+	r.body(f.ParseTree.Operations)
+}
+
+func (r *resolver) push(function bool) {
+	r.scopes = append(r.scopes, &resolverScope{names: make(map[string]int), function: function})
+}
+
+func (r *resolver) pop() {
+	r.scopes = r.scopes[:len(r.scopes)-1]
+}
+
+// declare gives the next slot of the current scope to the variable.
+// A variable declared twice in a scope keeps its slot, the interpreter raises the error of the second declaration.
+func (r *resolver) declare(name string) int {
+	scope := r.scopes[len(r.scopes)-1]
+	if slot, ok := scope.names[name]; ok && slot != Unresolved {
+		return slot
+	}
+	slot := scope.slots
+	scope.slots++
+	scope.names[name] = slot
+	return slot
+}
+
+// declareByName declares a variable the interpreter only sets by name.
+func (r *resolver) declareByName(name string) {
+	r.scopes[len(r.scopes)-1].names[name] = Unresolved
+}
+
+// lookup returns where the variable is found from the current scope.
+func (r *resolver) lookup(name string) Binding {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		scope := r.scopes[i]
+		if slot, ok := scope.names[name]; ok {
+			if slot == Unresolved {
+				break
+			}
+			return Binding{Depth: len(r.scopes) - 1 - i, Slot: slot}
+		}
+	}
+	return Binding{Slot: Unresolved}
+}
+
+// declared returns true if the variable is declared in the current scope or in one of the scopes enclosing it.
+func (r *resolver) declared(name string) bool {
+	for _, scope := range r.scopes {
+		if _, ok := scope.names[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resolver) body(body []Node) {
+	for _, node := range body {
+		r.node(node)
+	}
+}
+
+// block resolves a body executed in its own scope.
+func (r *resolver) block(body []Node) {
+	r.push(false)
+	r.body(body)
+	r.pop()
+}
+
+// bind sets the binding of a declaration to the slot the variable is given in the current scope.
+func (r *resolver) bind(binding *Binding, name string) {
+	slot := r.declare(name)
+	if binding != nil {
+		*binding = Binding{Slot: slot}
+	}
+}
+
+// use sets the binding of a variable to where it is found from the current scope.
+func (r *resolver) use(binding *Binding, name string) {
+	if binding != nil {
+		*binding = r.lookup(name)
+	}
+}
+
+// function resolves the body of a function, executed in a scope holding its parameters and its receiver.
+func (r *resolver) function(prototype FunctionPrototype, receiver *FunctionParams, body []Node) {
+	r.push(true)
+	for _, param := range prototype.Parameters {
+		r.declare(param.Name)
+	}
+	if receiver != nil {
+		r.declare(receiver.Name)
+	}
+	r.body(body)
+	r.pop()
+}
+
+func (r *resolver) nodes(nodes []Expr) {
+	for _, node := range nodes {
+		r.node(node)
+	}
+}
+
+// node resolves the variables used and declared by the node.
+func (r *resolver) node(node Node) {
+	switch node.(type) {
+	case Literal:
+		tree := node.(Literal)
+		if tree.Type == "VAR" {
+			r.use(tree.Binding, tree.Value)
+		}
+	case BinaryExpr:
+		r.node(node.(BinaryExpr).LeftExpr)
+		r.node(node.(BinaryExpr).RightExpr)
+	case UnaryExpr:
+		r.node(node.(UnaryExpr).RightExpr)
+	case ParenExpr:
+		r.node(node.(ParenExpr).Expression)
+	case ArrayLiteral:
+		r.nodes(node.(ArrayLiteral).Values)
+	case InterpolatedStringExpr:
+		r.nodes(node.(InterpolatedStringExpr).Parts)
+	case MapLiteral:
+		r.nodes(node.(MapLiteral).Keys)
+		r.nodes(node.(MapLiteral).Values)
+	case FunctionCallExpr:
+		tree := node.(FunctionCallExpr)
+		r.nodes(tree.Args)
+		r.use(tree.Binding, tree.Name)
+	case IndexableAccessExpr:
+		tree := node.(IndexableAccessExpr)
+		r.nodes(tree.Indexes)
+		r.use(tree.Binding, tree.VariableName)
+	case SelectorExpr:
+		tree := node.(SelectorExpr)
+		// a selector on a name which is not a variable selects from an import
+		if base, ok := tree.Expr.(Literal); ok && base.Type == "VAR" && !r.declared(base.Value) {
+			r.file.AddDependency(base.Value)
+		}
+		r.node(tree.Expr)
+		r.selector(tree.Sel)
+	case StructInstantiationExpr:
+		r.nodes(node.(StructInstantiationExpr).Args)
+	case AnonymousFunctionExpr:
+		tree := node.(AnonymousFunctionExpr)
+		r.function(tree.Prototype, nil, tree.Body)
+	case AnonymousFunctionCallExpr:
+		tree := node.(AnonymousFunctionCallExpr)
+		r.node(tree.AnonymousFunction)
+		r.nodes(tree.Args)
+	case VariableDecl:
+		tree := node.(VariableDecl)
+		r.node(tree.Value)
+		if len(r.scopes) == 1 {
+			// the variables of the main scope are kept by name from a file to the next one executed in it
+			r.declareByName(tree.Name)
+			break
+		}
+		r.bind(tree.Binding, tree.Name)
+	case VariableAssignStmt:
+		tree := node.(VariableAssignStmt)
+		r.nodes(tree.Values)
+		r.nodes(tree.Names)
+	case FunctionDecl:
+		tree := node.(FunctionDecl)
+		if tree.Receiver == nil {
+			r.declareByName(tree.Name)
+		}
+		r.function(tree.Prototype, tree.Receiver, tree.Body)
+	case StructDecl:
+		for _, method := range node.(StructDecl).Methods {
+			r.function(method.Prototype, method.Receiver, method.Body)
+		}
+	case ImportStmt:
+		r.declareByName(GetPackageNameByPath(node.(ImportStmt).ModulePath))
+	case ReturnStmt:
+		r.nodes(node.(ReturnStmt).ReturnValues)
+	case ThrowStmt:
+		r.node(node.(ThrowStmt).Value)
+	case IfStmt:
+		r.ifStmt(node.(IfStmt))
+	case WhileStmt:
+		tree := node.(WhileStmt)
+		r.push(false)
+		r.node(tree.Cond)
+		r.block(tree.Body)
+		r.pop()
+	case ForStmt:
+		r.forStmt(node.(ForStmt))
+	case BlockScopeStmt:
+		r.block(node.(BlockScopeStmt).Body)
+	case TryStmt:
+		tree := node.(TryStmt)
+		r.block(tree.Body)
+		if tree.CatchStmt != nil {
+			r.push(false)
+			if tree.CatchStmt.ErrorName != "" {
+				r.bind(tree.CatchStmt.ErrorBinding, tree.CatchStmt.ErrorName)
+			}
+			r.body(tree.CatchStmt.Body)
+			r.pop()
+		}
+		if tree.FinallyStmt != nil {
+			r.block(tree.FinallyStmt.Body)
+		}
+	}
+}
+
+// selector resolves the selected part of a selector, its names are fields or methods and not variables.
+func (r *resolver) selector(node Expr) {
+	switch node.(type) {
+	case FunctionCallExpr:
+		r.nodes(node.(FunctionCallExpr).Args)
+	case IndexableAccessExpr:
+		r.nodes(node.(IndexableAccessExpr).Indexes)
+	case SelectorExpr:
+		r.selector(node.(SelectorExpr).Expr)
+		r.selector(node.(SelectorExpr).Sel)
+	}
+}
+
+func (r *resolver) ifStmt(tree IfStmt) {
+	r.node(tree.Cond)
+	r.block(tree.Body)
+	if tree.ElseStmt != nil {
+		if tree.ElseStmt.IfStmt != nil {
+			r.ifStmt(*tree.ElseStmt.IfStmt)
+		} else {
+			r.block(tree.ElseStmt.Body)
+		}
+	}
+}
+
+// forStmt resolves a for loop, its declarations are in the scope of the loop and its body in a scope of its own.
+func (r *resolver) forStmt(tree ForStmt) {
+	r.push(false)
+	if tree.RangeToken != (lexer.Token{}) {
+		r.node(tree.RangeExpr)
+		r.bind(tree.KeyBinding, tree.KeyToken.Value)
+		r.bind(tree.ValueBinding, tree.ValueToken.Value)
+	} else {
+		if tree.InitDecl != nil {
+			r.node(tree.InitDecl)
+		}
+		r.node(tree.CondExpr)
+	}
+	r.block(tree.Body)
+	if tree.PostAssignStmt != nil {
+		r.node(tree.PostAssignStmt)
+	}
+	r.pop()
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/Eclalang/Ecla/errorHandler"
+	"github.com/Eclalang/Ecla/lexer"
+)
+
+func TestFile_Resolve(t *testing.T) {
+	par := Parser{Tokens: lexer.Lexer(`var a int = 1;
+var b int = a;
+function f(x : int) (int) {
+	var y int = x + b;
+	for (k, v range [1, 2]) {
+		var z int = y + k;
+	}
+	var s string = "y is ${y}";
+	return y;
+}
+`), ErrorHandler: errorHandler.NewHandler()}
+	file := par.Parse()
+	ops := file.ParseTree.Operations
+
+	// the variables of the main scope are looked up by name
+	if b := ops[0].(VariableDecl).Binding; b.IsResolved() {
+		t.Errorf("a is bound to %v instead of being set by name", *b)
+	}
+	if b := ops[1].(VariableDecl).Value.(Literal).Binding; b.IsResolved() {
+		t.Errorf("a is resolved to %v instead of being looked up by name", *b)
+	}
+
+	// the parameters are the first slots of the function, the variables declared outside of it are looked up by name
+	fn := ops[2].(FunctionDecl)
+	decl := fn.Body[0].(VariableDecl)
+	if *decl.Binding != (Binding{Slot: 1}) {
+		t.Errorf("y is bound to %v instead of the slot 1", *decl.Binding)
+	}
+	sum := decl.Value.(BinaryExpr)
+	if b := sum.LeftExpr.(Literal).Binding; *b != (Binding{Depth: 0, Slot: 0}) {
+		t.Errorf("x is resolved to %v instead of the slot 0", *b)
+	}
+	if b := sum.RightExpr.(Literal).Binding; b.IsResolved() {
+		t.Errorf("b is resolved to %v instead of being looked up by name", *b)
+	}
+
+	// the key and the value are declared in the scope of the loop, the body has a scope of its own
+	loop := fn.Body[1].(ForStmt)
+	if *loop.KeyBinding != (Binding{Slot: 0}) || *loop.ValueBinding != (Binding{Slot: 1}) {
+		t.Errorf("k and v are bound to %v and %v instead of the slots 0 and 1", *loop.KeyBinding, *loop.ValueBinding)
+	}
+	decl = loop.Body[0].(VariableDecl)
+	if *decl.Binding != (Binding{Slot: 0}) {
+		t.Errorf("z is bound to %v instead of the slot 0", *decl.Binding)
+	}
+	sum = decl.Value.(BinaryExpr)
+	if b := sum.LeftExpr.(Literal).Binding; *b != (Binding{Depth: 2, Slot: 1}) {
+		t.Errorf("y is resolved to %v instead of the slot 1 two scopes above", *b)
+	}
+	if b := sum.RightExpr.(Literal).Binding; *b != (Binding{Depth: 1, Slot: 0}) {
+		t.Errorf("k is resolved to %v instead of the slot 0 one scope above", *b)
+	}
+
+	// the variables interpolated in a string are resolved like the other expressions
+	interpolated := fn.Body[2].(VariableDecl).Value.(InterpolatedStringExpr)
+	if b := interpolated.Parts[1].(Literal).Binding; *b != (Binding{Depth: 0, Slot: 1}) {
+		t.Errorf("y is resolved to %v instead of the slot 1", *b)
+	}
+}
+
+func TestFile_ResolveClosure(t *testing.T) {
+	par := Parser{Tokens: lexer.Lexer(`function f(x : int) {
+	var l []int = [x];
+	try {
+		var g function() (int) = function() (int) {
+			return l[0] + x;
+		};
+	} catch (e) {
+		var n int = len(e);
+	}
+	var l int = 0;
+}
+`), ErrorHandler: errorHandler.NewHandler()}
+	file := par.Parse()
+	fn := file.ParseTree.Operations[0].(FunctionDecl)
+
+	// the variables captured by a function are found from its scope through the scopes enclosing it
+	try := fn.Body[1].(TryStmt)
+	anonymous := try.Body[0].(VariableDecl).Value.(AnonymousFunctionExpr)
+	sum := anonymous.Body[0].(ReturnStmt).ReturnValues[0].(BinaryExpr)
+	if b := sum.LeftExpr.(IndexableAccessExpr).Binding; *b != (Binding{Depth: 2, Slot: 1}) {
+		t.Errorf("l is resolved to %v instead of the slot 1 two scopes above", *b)
+	}
+	if b := sum.RightExpr.(Literal).Binding; *b != (Binding{Depth: 2, Slot: 0}) {
+		t.Errorf("x is resolved to %v instead of the slot 0 two scopes above", *b)
+	}
+
+	// the error caught is the first slot of the scope of the catch
+	if b := try.CatchStmt.ErrorBinding; *b != (Binding{Slot: 0}) {
+		t.Errorf("e is bound to %v instead of the slot 0", *b)
+	}
+	if b := try.CatchStmt.Body[0].(VariableDecl).Value.(FunctionCallExpr).Args[0].(Literal).Binding; *b != (Binding{Slot: 0}) {
+		t.Errorf("e is resolved to %v instead of the slot 0", *b)
+	}
+
+	// a variable declared twice in a scope keeps its slot
+	if b := fn.Body[2].(VariableDecl).Binding; *b != (Binding{Slot: 1}) {
+		t.Errorf("l is bound to %v instead of the slot 1", *b)
+	}
+}
@@ -13,7 +13,7 @@ func (b BlockScopeStmt) StartPos() int {
 }
 
 func (b BlockScopeStmt) EndPos() int {
-	return b.RightBrace.Position
+	return b.RightBrace.EndPosition
 }
 
 func (b BlockScopeStmt) StartLine() int {
@@ -21,11 +21,139 @@ func (b BlockScopeStmt) StartLine() int {
 }
 
 func (b BlockScopeStmt) EndLine() int {
-	return b.RightBrace.Line
+	return b.RightBrace.EndLine
+}
+
+func (b BlockScopeStmt) StartOffset() int {
+	return b.LeftBrace.Offset
+}
+
+func (b BlockScopeStmt) EndOffset() int {
+	return b.RightBrace.EndOffset
 }
 
 func (b BlockScopeStmt) stmtNode() {}
 
+type BreakStmt struct {
+	BreakToken lexer.Token
+	LabelToken lexer.Token
+	Label      string
+}
+
+func (b BreakStmt) StartPos() int {
+	return b.BreakToken.Position
+}
+
+func (b BreakStmt) EndPos() int {
+	if b.Label != "" {
+		return b.LabelToken.EndPosition
+	}
+	return b.BreakToken.EndPosition
+}
+
+func (b BreakStmt) StartLine() int {
+	return b.BreakToken.Line
+}
+
+func (b BreakStmt) EndLine() int {
+	if b.Label != "" {
+		return b.LabelToken.EndLine
+	}
+	return b.BreakToken.EndLine
+}
+
+func (b BreakStmt) StartOffset() int {
+	return b.BreakToken.Offset
+}
+
+func (b BreakStmt) EndOffset() int {
+	if b.Label != "" {
+		return b.LabelToken.EndOffset
+	}
+	return b.BreakToken.EndOffset
+}
+
+func (b BreakStmt) stmtNode() {}
+
+type CatchStmt struct {
+	CatchToken lexer.Token
+	LeftParen  lexer.Token
+	RightParen lexer.Token
+	ErrorName  string
+	LeftBrace  lexer.Token
+	RightBrace lexer.Token
+	Body       []Node
+	// ErrorBinding is the slot of the error in the scope of the catch, it is set by Resolve
+	ErrorBinding *Binding
+}
+
+func (c CatchStmt) StartPos() int {
+	return c.CatchToken.Position
+}
+
+func (c CatchStmt) EndPos() int {
+	return c.RightBrace.EndPosition
+}
+
+func (c CatchStmt) StartLine() int {
+	return c.CatchToken.Line
+}
+
+func (c CatchStmt) EndLine() int {
+	return c.RightBrace.EndLine
+}
+
+func (c CatchStmt) StartOffset() int {
+	return c.CatchToken.Offset
+}
+
+func (c CatchStmt) EndOffset() int {
+	return c.RightBrace.EndOffset
+}
+
+func (c CatchStmt) stmtNode() {}
+
+type ContinueStmt struct {
+	ContinueToken lexer.Token
+	LabelToken    lexer.Token
+	Label         string
+}
+
+func (c ContinueStmt) StartPos() int {
+	return c.ContinueToken.Position
+}
+
+func (c ContinueStmt) EndPos() int {
+	if c.Label != "" {
+		return c.LabelToken.EndPosition
+	}
+	return c.ContinueToken.EndPosition
+}
+
+func (c ContinueStmt) StartLine() int {
+	return c.ContinueToken.Line
+}
+
+func (c ContinueStmt) EndLine() int {
+	if c.Label != "" {
+		return c.LabelToken.EndLine
+	}
+	return c.ContinueToken.EndLine
+}
+
+func (c ContinueStmt) StartOffset() int {
+	return c.ContinueToken.Offset
+}
+
+func (c ContinueStmt) EndOffset() int {
+	if c.Label != "" {
+		return c.LabelToken.EndOffset
+	}
+	return c.ContinueToken.EndOffset
+}
+
+func (c ContinueStmt) stmtNode() {}
+
 type ElseStmt struct {
 	ElseToken  lexer.Token
 	LeftBrace  lexer.Token
@@ -39,7 +167,10 @@ func (e ElseStmt) StartPos() int {
 }
 
 func (e ElseStmt) EndPos() int {
-	return e.RightBrace.Position
+	if e.IfStmt != nil {
+		return e.IfStmt.EndPos()
+	}
+	return e.RightBrace.EndPosition
 }
 
 func (e ElseStmt) StartLine() int {
@@ -47,12 +178,60 @@ func (e ElseStmt) StartLine() int {
 }
 
 func (e ElseStmt) EndLine() int {
-	return e.RightBrace.Line
+	if e.IfStmt != nil {
+		return e.IfStmt.EndLine()
+	}
+	return e.RightBrace.EndLine
+}
+
+func (e ElseStmt) StartOffset() int {
+	return e.ElseToken.Offset
+}
+
+func (e ElseStmt) EndOffset() int {
+	if e.IfStmt != nil {
+		return e.IfStmt.EndOffset()
+	}
+	return e.RightBrace.EndOffset
 }
 
 func (e ElseStmt) stmtNode() {}
 
+type FinallyStmt struct {
+	FinallyToken lexer.Token
+	LeftBrace    lexer.Token
+	RightBrace   lexer.Token
+	Body         []Node
+}
+
+func (f FinallyStmt) StartPos() int {
+	return f.FinallyToken.Position
+}
+
+func (f FinallyStmt) EndPos() int {
+	return f.RightBrace.EndPosition
+}
+
+func (f FinallyStmt) StartLine() int {
+	return f.FinallyToken.Line
+}
+
+func (f FinallyStmt) EndLine() int {
+	return f.RightBrace.EndLine
+}
+
+func (f FinallyStmt) StartOffset() int {
+	return f.FinallyToken.Offset
+}
+
+func (f FinallyStmt) EndOffset() int {
+	return f.RightBrace.EndOffset
+}
+
+func (f FinallyStmt) stmtNode() {}
+
 type ForStmt struct {
+	Label                string
 	ForToken             lexer.Token
 	LeftParen            lexer.Token
 	RightParen           lexer.Token
@@ -65,6 +244,8 @@ type ForStmt struct {
 	LeftBrace            lexer.Token
 	RightBrace           lexer.Token
 	Body                 []Node
+	// KeyBinding and ValueBinding are the slots of the key and of the value in the scope of the loop, they are set by Resolve
+	KeyBinding, ValueBinding *Binding
 }
 
 func (f ForStmt) StartPos() int {
@@ -72,7 +253,7 @@ func (f ForStmt) StartPos() int {
 }
 
 func (f ForStmt) EndPos() int {
-	return f.RightBrace.Position
+	return f.RightBrace.EndPosition
 }
 
 func (f ForStmt) StartLine() int {
@@ -80,7 +261,15 @@ func (f ForStmt) StartLine() int {
 }
 
 func (f ForStmt) EndLine() int {
-	return f.RightBrace.Line
+	return f.RightBrace.EndLine
+}
+
+func (f ForStmt) StartOffset() int {
+	return f.ForToken.Offset
+}
+
+func (f ForStmt) EndOffset() int {
+	return f.RightBrace.EndOffset
 }
 
 func (f ForStmt) stmtNode() {}
@@ -101,7 +290,10 @@ func (i IfStmt) StartPos() int {
 }
 
 func (i IfStmt) EndPos() int {
-	return i.RightBrace.Position
+	if i.ElseStmt != nil {
+		return i.ElseStmt.EndPos()
+	}
+	return i.RightBrace.EndPosition
 }
 
 func (i IfStmt) StartLine() int {
@@ -109,7 +301,21 @@ func (i IfStmt) StartLine() int {
 }
 
 func (i IfStmt) EndLine() int {
-	return i.RightBrace.Line
+	if i.ElseStmt != nil {
+		return i.ElseStmt.EndLine()
+	}
+	return i.RightBrace.EndLine
+}
+
+func (i IfStmt) StartOffset() int {
+	return i.IfToken.Offset
+}
+
+func (i IfStmt) EndOffset() int {
+	if i.ElseStmt != nil {
+		return i.ElseStmt.EndOffset()
+	}
+	return i.RightBrace.EndOffset
 }
 
 func (i IfStmt) stmtNode() {}
@@ -117,6 +323,8 @@ func (i IfStmt) stmtNode() {}
 type ImportStmt struct {
 	ImportToken lexer.Token
 	ModulePath  string
+	// RightQuote is the double quote closing the path of the module
+	RightQuote lexer.Token
 }
 
 func (i ImportStmt) StartPos() int {
@@ -124,7 +332,7 @@ func (i ImportStmt) StartPos() int {
 }
 
 func (i ImportStmt) EndPos() int {
-	return i.ImportToken.Position + len(i.ModulePath)
+	return i.RightQuote.EndPosition
 }
 
 func (i ImportStmt) StartLine() int {
@@ -132,7 +340,15 @@ func (i ImportStmt) StartLine() int {
 }
 
 func (i ImportStmt) EndLine() int {
-	return i.ImportToken.Line
+	return i.RightQuote.EndLine
+}
+
+func (i ImportStmt) StartOffset() int {
+	return i.ImportToken.Offset
+}
+
+func (i ImportStmt) EndOffset() int {
+	return i.RightQuote.EndOffset
 }
 
 func (i ImportStmt) stmtNode() {}
@@ -146,7 +362,7 @@ func (m MurlocStmt) StartPos() int {
 }
 
 func (m MurlocStmt) EndPos() int {
-	return m.MurlocToken.Position + len(lexer.MURLOC)
+	return m.MurlocToken.EndPosition
 }
 
 func (m MurlocStmt) StartLine() int {
@@ -154,7 +370,15 @@ func (m MurlocStmt) StartLine() int {
 }
 
 func (m MurlocStmt) EndLine() int {
-	return m.MurlocToken.Line
+	return m.MurlocToken.EndLine
+}
+
+func (m MurlocStmt) StartOffset() int {
+	return m.MurlocToken.Offset
+}
+
+func (m MurlocStmt) EndOffset() int {
+	return m.MurlocToken.EndOffset
 }
 
 func (m MurlocStmt) stmtNode() {}
@@ -172,7 +396,7 @@ func (r ReturnStmt) EndPos() int {
 	if len(r.ReturnValues) > 0 {
 		return r.ReturnValues[len(r.ReturnValues)-1].EndPos()
 	}
-	return r.ReturnToken.Position
+	return r.ReturnToken.EndPosition
 }
 
 func (r ReturnStmt) StartLine() int {
@@ -183,16 +407,113 @@ func (r ReturnStmt) EndLine() int {
 	if len(r.ReturnValues) > 0 {
 		return r.ReturnValues[len(r.ReturnValues)-1].EndLine()
 	}
-	return r.ReturnToken.Line
+	return r.ReturnToken.EndLine
+}
+
+func (r ReturnStmt) StartOffset() int {
+	return r.ReturnToken.Offset
+}
+
+func (r ReturnStmt) EndOffset() int {
+	if len(r.ReturnValues) > 0 {
+		return r.ReturnValues[len(r.ReturnValues)-1].EndOffset()
+	}
+	return r.ReturnToken.EndOffset
 }
 
 func (r ReturnStmt) stmtNode() {}
 
+type ThrowStmt struct {
+	ThrowToken lexer.Token
+	Value      Expr
+}
+
+func (t ThrowStmt) StartPos() int {
+	return t.ThrowToken.Position
+}
+
+func (t ThrowStmt) EndPos() int {
+	return t.Value.EndPos()
+}
+
+func (t ThrowStmt) StartLine() int {
+	return t.ThrowToken.Line
+}
+
+func (t ThrowStmt) EndLine() int {
+	return t.Value.EndLine()
+}
+
+func (t ThrowStmt) StartOffset() int {
+	return t.ThrowToken.Offset
+}
+
+func (t ThrowStmt) EndOffset() int {
+	return t.Value.EndOffset()
+}
+
+func (t ThrowStmt) stmtNode() {}
+
+type TryStmt struct {
+	TryToken    lexer.Token
+	LeftBrace   lexer.Token
+	RightBrace  lexer.Token
+	Body        []Node
+	CatchStmt   *CatchStmt
+	FinallyStmt *FinallyStmt
+}
+
+func (t TryStmt) StartPos() int {
+	return t.TryToken.Position
+}
+
+func (t TryStmt) EndPos() int {
+	if t.FinallyStmt != nil {
+		return t.FinallyStmt.EndPos()
+	}
+	if t.CatchStmt != nil {
+		return t.CatchStmt.EndPos()
+	}
+	return t.RightBrace.EndPosition
+}
+
+func (t TryStmt) StartLine() int {
+	return t.TryToken.Line
+}
+
+func (t TryStmt) EndLine() int {
+	if t.FinallyStmt != nil {
+		return t.FinallyStmt.EndLine()
+	}
+	if t.CatchStmt != nil {
+		return t.CatchStmt.EndLine()
+	}
+	return t.RightBrace.EndLine
+}
+
+func (t TryStmt) StartOffset() int {
+	return t.TryToken.Offset
+}
+
+func (t TryStmt) EndOffset() int {
+	if t.FinallyStmt != nil {
+		return t.FinallyStmt.EndOffset()
+	}
+	if t.CatchStmt != nil {
+		return t.CatchStmt.EndOffset()
+	}
+	return t.RightBrace.EndOffset
+}
+
+func (t TryStmt) stmtNode() {}
+
 type VariableAssignStmt struct {
-	VarToken lexer.Token
-	Names    []Expr
-	Operator string
-	Values   []Expr
+	VarToken      lexer.Token
+	Names         []Expr
+	Operator      string
+	OperatorToken lexer.Token
+	// Values holds a nil value when the operator has no right hand side, like ++
+	Values []Expr
 }
 
 func (v VariableAssignStmt) StartPos() int {
@@ -200,6 +521,9 @@ func (v VariableAssignStmt) StartPos() int {
 }
 
 func (v VariableAssignStmt) EndPos() int {
+	if len(v.Values) == 0 || v.Values[len(v.Values)-1] == nil {
+		return v.OperatorToken.EndPosition
+	}
 	return v.Values[len(v.Values)-1].EndPos()
 }
 
@@ -208,12 +532,27 @@ func (v VariableAssignStmt) StartLine() int {
 }
 
 func (v VariableAssignStmt) EndLine() int {
+	if len(v.Values) == 0 || v.Values[len(v.Values)-1] == nil {
+		return v.OperatorToken.EndLine
+	}
 	return v.Values[len(v.Values)-1].EndLine()
 }
 
+func (v VariableAssignStmt) StartOffset() int {
+	return v.VarToken.Offset
+}
+
+func (v VariableAssignStmt) EndOffset() int {
+	if len(v.Values) == 0 || v.Values[len(v.Values)-1] == nil {
+		return v.OperatorToken.EndOffset
+	}
+	return v.Values[len(v.Values)-1].EndOffset()
+}
+
 func (v VariableAssignStmt) stmtNode() {}
 
 type WhileStmt struct {
+	Label      string
 	WhileToken lexer.Token
 	LeftParen  lexer.Token
 	RightParen lexer.Token
@@ -228,7 +567,7 @@ func (w WhileStmt) StartPos() int {
 }
 
 func (w WhileStmt) EndPos() int {
-	return w.RightBrace.Position
+	return w.RightBrace.EndPosition
 }
 
 func (w WhileStmt) StartLine() int {
@@ -236,7 +575,15 @@ func (w WhileStmt) StartLine() int {
 }
 
 func (w WhileStmt) EndLine() int {
-	return w.RightBrace.Line
+	return w.RightBrace.EndLine
+}
+
+func (w WhileStmt) StartOffset() int {
+	return w.WhileToken.Offset
+}
+
+func (w WhileStmt) EndOffset() int {
+	return w.RightBrace.EndOffset
 }
 
 func (w WhileStmt) stmtNode() {}
@@ -8,16 +8,20 @@ import (
 
 var bStmt = BlockScopeStmt{
 	LeftBrace: lexer.Token{
-		TokenType: lexer.LBRACE,
-		Value:     "{",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.LBRACE,
+		Value:       "{",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	RightBrace: lexer.Token{
-		TokenType: lexer.RBRACE,
-		Value:     "}",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	Body: []Node{
 		aCall,
@@ -32,7 +36,7 @@ func TestBlockScopeStmt_StartPos(t *testing.T) {
 }
 
 func TestBlockScopeStmt_EndPos(t *testing.T) {
-	if bStmt.EndPos() != 1 {
+	if bStmt.EndPos() != 2 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -53,24 +57,157 @@ func TestBlockScopeStmt_stmtNode(t *testing.T) {
 	bStmt.stmtNode()
 }
 
+var brStmt = BreakStmt{
+	BreakToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "break",
+		Position:    1,
+		Line:        1,
+		EndPosition: 6,
+		EndLine:     1,
+	},
+}
+
+var brLabelStmt = BreakStmt{
+	BreakToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "break",
+		Position:    1,
+		Line:        1,
+		EndPosition: 6,
+		EndLine:     1,
+	},
+	LabelToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "outer",
+		Position:    7,
+		Line:        2,
+		EndPosition: 12,
+		EndLine:     2,
+	},
+	Label: "outer",
+}
+
+func TestBreakStmt_StartPos(t *testing.T) {
+	if brStmt.StartPos() != 1 {
+		t.Error("StartPos failed to return the correct value")
+	}
+}
+
+func TestBreakStmt_EndPos(t *testing.T) {
+	if brStmt.EndPos() != 6 {
+		t.Error("EndPos failed to return the correct value")
+	}
+	if brLabelStmt.EndPos() != 12 {
+		t.Error("EndPos failed to return the correct value with a label")
+	}
+}
+
+func TestBreakStmt_StartLine(t *testing.T) {
+	if brStmt.StartLine() != 1 {
+		t.Error("StartLine failed to return the correct value")
+	}
+}
+
+func TestBreakStmt_EndLine(t *testing.T) {
+	if brStmt.EndLine() != 1 {
+		t.Error("EndLine failed to return the correct value")
+	}
+	if brLabelStmt.EndLine() != 2 {
+		t.Error("EndLine failed to return the correct value with a label")
+	}
+}
+
+func TestBreakStmt_stmtNode(t *testing.T) {
+	brStmt.stmtNode()
+}
+
+var cStmt = ContinueStmt{
+	ContinueToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "continue",
+		Position:    1,
+		Line:        1,
+		EndPosition: 9,
+		EndLine:     1,
+	},
+}
+
+var cLabelStmt = ContinueStmt{
+	ContinueToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "continue",
+		Position:    1,
+		Line:        1,
+		EndPosition: 9,
+		EndLine:     1,
+	},
+	LabelToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "outer",
+		Position:    10,
+		Line:        1,
+		EndPosition: 15,
+		EndLine:     1,
+	},
+	Label: "outer",
+}
+
+func TestContinueStmt_StartPos(t *testing.T) {
+	if cStmt.StartPos() != 1 {
+		t.Error("StartPos failed to return the correct value")
+	}
+}
+
+func TestContinueStmt_EndPos(t *testing.T) {
+	if cStmt.EndPos() != 9 {
+		t.Error("EndPos failed to return the correct value")
+	}
+	if cLabelStmt.EndPos() != 15 {
+		t.Error("EndPos failed to return the correct value with a label")
+	}
+}
+
+func TestContinueStmt_StartLine(t *testing.T) {
+	if cStmt.StartLine() != 1 {
+		t.Error("StartLine failed to return the correct value")
+	}
+}
+
+func TestContinueStmt_EndLine(t *testing.T) {
+	if cStmt.EndLine() != 1 || cLabelStmt.EndLine() != 1 {
+		t.Error("EndLine failed to return the correct value")
+	}
+}
+
+func TestContinueStmt_stmtNode(t *testing.T) {
+	cStmt.stmtNode()
+}
+
 var eStmt = ElseStmt{
 	ElseToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "else",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "else",
+		Position:    1,
+		Line:        1,
+		EndPosition: 5,
+		EndLine:     1,
 	},
 	LeftBrace: lexer.Token{
-		TokenType: lexer.LBRACE,
-		Value:     "{",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.LBRACE,
+		Value:       "{",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	RightBrace: lexer.Token{
-		TokenType: lexer.RBRACE,
-		Value:     "}",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	Body: []Node{
 		aCall,
@@ -86,7 +223,7 @@ func TestElseStmt_StartPos(t *testing.T) {
 }
 
 func TestElseStmt_EndPos(t *testing.T) {
-	if eStmt.EndPos() != 1 {
+	if eStmt.EndPos() != 2 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -109,56 +246,72 @@ func TestElseStmt_stmtNode(t *testing.T) {
 
 var fStmt = ForStmt{
 	ForToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "for",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "for",
+		Position:    1,
+		Line:        1,
+		EndPosition: 4,
+		EndLine:     1,
 	},
 	LeftParen: lexer.Token{
-		TokenType: lexer.LPAREN,
-		Value:     "(",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.LPAREN,
+		Value:       "(",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	RightParen: lexer.Token{
-		TokenType: lexer.RPAREN,
-		Value:     ")",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.RPAREN,
+		Value:       ")",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	InitDecl:       nil,
 	CondExpr:       nil,
 	PostAssignStmt: nil,
 	KeyToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "key",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "key",
+		Position:    1,
+		Line:        1,
+		EndPosition: 4,
+		EndLine:     1,
 	},
 	ValueToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "value",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "value",
+		Position:    1,
+		Line:        1,
+		EndPosition: 6,
+		EndLine:     1,
 	},
 	RangeToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "range",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "range",
+		Position:    1,
+		Line:        1,
+		EndPosition: 6,
+		EndLine:     1,
 	},
 	RangeExpr: nil,
 	LeftBrace: lexer.Token{
-		TokenType: lexer.LBRACE,
-		Value:     "{",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.LBRACE,
+		Value:       "{",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	RightBrace: lexer.Token{
-		TokenType: lexer.RBRACE,
-		Value:     "}",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	Body: []Node{
 		aCall,
@@ -173,7 +326,7 @@ func TestForStmt_StartPos(t *testing.T) {
 }
 
 func TestForStmt_EndPos(t *testing.T) {
-	if fStmt.EndPos() != 1 {
+	if fStmt.EndPos() != 2 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -196,35 +349,45 @@ func TestForStmt_stmtNode(t *testing.T) {
 
 var iStmt = IfStmt{
 	IfToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "if",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "if",
+		Position:    1,
+		Line:        1,
+		EndPosition: 3,
+		EndLine:     1,
 	},
 	LeftParen: lexer.Token{
-		TokenType: lexer.LPAREN,
-		Value:     "(",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.LPAREN,
+		Value:       "(",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	RightParen: lexer.Token{
-		TokenType: lexer.RPAREN,
-		Value:     ")",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.RPAREN,
+		Value:       ")",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	Cond: nil,
 	LeftBrace: lexer.Token{
-		TokenType: lexer.LBRACE,
-		Value:     "{",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.LBRACE,
+		Value:       "{",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	RightBrace: lexer.Token{
-		TokenType: lexer.RBRACE,
-		Value:     "}",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	Body: []Node{
 		aCall,
@@ -240,7 +403,7 @@ func TestIfStmt_StartPos(t *testing.T) {
 }
 
 func TestIfStmt_EndPos(t *testing.T) {
-	if iStmt.EndPos() != 1 {
+	if iStmt.EndPos() != 2 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -263,12 +426,22 @@ func TestIfStmt_stmtNode(t *testing.T) {
 
 var impStmt = ImportStmt{
 	ImportToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "import",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "import",
+		Position:    1,
+		Line:        1,
+		EndPosition: 7,
+		EndLine:     1,
 	},
 	ModulePath: "console",
+	RightQuote: lexer.Token{
+		TokenType:   lexer.DQUOTE,
+		Value:       "\"",
+		Position:    16,
+		Line:        1,
+		EndPosition: 17,
+		EndLine:     1,
+	},
 }
 
 func TestImportStmt_StartPos(t *testing.T) {
@@ -278,7 +451,7 @@ func TestImportStmt_StartPos(t *testing.T) {
 }
 
 func TestImportStmt_EndPos(t *testing.T) {
-	if impStmt.EndPos() != 1+len(impStmt.ModulePath) {
+	if impStmt.EndPos() != 17 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -301,10 +474,12 @@ func TestImportStmt_stmtNode(t *testing.T) {
 
 var mStmt = MurlocStmt{
 	MurlocToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "murloc",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "murloc",
+		Position:    1,
+		Line:        1,
+		EndPosition: 7,
+		EndLine:     1,
 	},
 }
 
@@ -338,10 +513,12 @@ func TestMurlocStmt_stmtNode(t *testing.T) {
 
 var rStmt = ReturnStmt{
 	ReturnToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "return",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "return",
+		Position:    1,
+		Line:        1,
+		EndPosition: 7,
+		EndLine:     1,
 	},
 	ReturnValues: []Expr{
 		aCall,
@@ -361,7 +538,7 @@ func TestReturnStmt_EndPos(t *testing.T) {
 	}
 	back := rStmt.ReturnValues
 	rStmt.ReturnValues = nil
-	if rStmt.EndPos() != 1 {
+	if rStmt.EndPos() != 1+len("return") {
 		t.Error("EndPos failed to return the correct value")
 	}
 	rStmt.ReturnValues = back
@@ -393,10 +570,12 @@ func TestReturnStmt_stmtNode(t *testing.T) {
 
 var vAsStmt = VariableAssignStmt{
 	VarToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "var",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "var",
+		Position:    1,
+		Line:        1,
+		EndPosition: 4,
+		EndLine:     1,
 	},
 	Names: []Expr{
 		aCall,
@@ -439,35 +618,45 @@ func TestVariableAssignStmt_stmtNode(t *testing.T) {
 
 var wStmt = WhileStmt{
 	WhileToken: lexer.Token{
-		TokenType: lexer.TEXT,
-		Value:     "while",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.TEXT,
+		Value:       "while",
+		Position:    1,
+		Line:        1,
+		EndPosition: 6,
+		EndLine:     1,
 	},
 	LeftParen: lexer.Token{
-		TokenType: lexer.LPAREN,
-		Value:     "(",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.LPAREN,
+		Value:       "(",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	RightParen: lexer.Token{
-		TokenType: lexer.RPAREN,
-		Value:     ")",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.RPAREN,
+		Value:       ")",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	Cond: nil,
 	LeftBrace: lexer.Token{
-		TokenType: lexer.LBRACE,
-		Value:     "{",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.LBRACE,
+		Value:       "{",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	RightBrace: lexer.Token{
-		TokenType: lexer.RBRACE,
-		Value:     "}",
-		Position:  1,
-		Line:      1,
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    1,
+		Line:        1,
+		EndPosition: 2,
+		EndLine:     1,
 	},
 	Body: []Node{
 		aCall,
@@ -482,7 +671,7 @@ func TestWhileStmt_StartPos(t *testing.T) {
 }
 
 func TestWhileStmt_EndPos(t *testing.T) {
-	if wStmt.EndPos() != 1 {
+	if wStmt.EndPos() != 2 {
 		t.Error("EndPos failed to return the correct value")
 	}
 }
@@ -502,3 +691,221 @@ func TestWhileStmt_EndLine(t *testing.T) {
 func TestWhileStmt_stmtNode(t *testing.T) {
 	wStmt.stmtNode()
 }
+
+var catchStmt = CatchStmt{
+	CatchToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "catch",
+		Position:    1,
+		Line:        2,
+		EndPosition: 6,
+		EndLine:     2,
+	},
+	ErrorName: "e",
+	RightBrace: lexer.Token{
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    3,
+		Line:        4,
+		EndPosition: 4,
+		EndLine:     4,
+	},
+}
+
+var finallyStmt = FinallyStmt{
+	FinallyToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "finally",
+		Position:    1,
+		Line:        5,
+		EndPosition: 8,
+		EndLine:     5,
+	},
+	RightBrace: lexer.Token{
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    2,
+		Line:        6,
+		EndPosition: 3,
+		EndLine:     6,
+	},
+}
+
+var tryStmt = TryStmt{
+	TryToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "try",
+		Position:    1,
+		Line:        1,
+		EndPosition: 4,
+		EndLine:     1,
+	},
+	RightBrace: lexer.Token{
+		TokenType:   lexer.RBRACE,
+		Value:       "}",
+		Position:    5,
+		Line:        1,
+		EndPosition: 6,
+		EndLine:     1,
+	},
+}
+
+var tryCatchStmt = TryStmt{
+	TryToken:   tryStmt.TryToken,
+	RightBrace: tryStmt.RightBrace,
+	CatchStmt:  &catchStmt,
+}
+
+var tryCatchFinallyStmt = TryStmt{
+	TryToken:    tryStmt.TryToken,
+	RightBrace:  tryStmt.RightBrace,
+	CatchStmt:   &catchStmt,
+	FinallyStmt: &finallyStmt,
+}
+
+func TestCatchStmt_StartPos(t *testing.T) {
+	if catchStmt.StartPos() != 1 {
+		t.Error("StartPos failed to return the correct value")
+	}
+}
+
+func TestCatchStmt_EndPos(t *testing.T) {
+	if catchStmt.EndPos() != 4 {
+		t.Error("EndPos failed to return the correct value")
+	}
+}
+
+func TestCatchStmt_StartLine(t *testing.T) {
+	if catchStmt.StartLine() != 2 {
+		t.Error("StartLine failed to return the correct value")
+	}
+}
+
+func TestCatchStmt_EndLine(t *testing.T) {
+	if catchStmt.EndLine() != 4 {
+		t.Error("EndLine failed to return the correct value")
+	}
+}
+
+func TestCatchStmt_stmtNode(t *testing.T) {
+	catchStmt.stmtNode()
+}
+
+func TestFinallyStmt_StartPos(t *testing.T) {
+	if finallyStmt.StartPos() != 1 {
+		t.Error("StartPos failed to return the correct value")
+	}
+}
+
+func TestFinallyStmt_EndPos(t *testing.T) {
+	if finallyStmt.EndPos() != 3 {
+		t.Error("EndPos failed to return the correct value")
+	}
+}
+
+func TestFinallyStmt_StartLine(t *testing.T) {
+	if finallyStmt.StartLine() != 5 {
+		t.Error("StartLine failed to return the correct value")
+	}
+}
+
+func TestFinallyStmt_EndLine(t *testing.T) {
+	if finallyStmt.EndLine() != 6 {
+		t.Error("EndLine failed to return the correct value")
+	}
+}
+
+func TestFinallyStmt_stmtNode(t *testing.T) {
+	finallyStmt.stmtNode()
+}
+
+func TestTryStmt_StartPos(t *testing.T) {
+	if tryStmt.StartPos() != 1 {
+		t.Error("StartPos failed to return the correct value")
+	}
+}
+
+func TestTryStmt_EndPos(t *testing.T) {
+	if tryStmt.EndPos() != 6 {
+		t.Error("EndPos failed to return the correct value")
+	}
+	if tryCatchStmt.EndPos() != 4 {
+		t.Error("EndPos failed to return the correct value with a catch clause")
+	}
+	if tryCatchFinallyStmt.EndPos() != 3 {
+		t.Error("EndPos failed to return the correct value with a finally clause")
+	}
+}
+
+func TestTryStmt_StartLine(t *testing.T) {
+	if tryStmt.StartLine() != 1 {
+		t.Error("StartLine failed to return the correct value")
+	}
+}
+
+func TestTryStmt_EndLine(t *testing.T) {
+	if tryStmt.EndLine() != 1 {
+		t.Error("EndLine failed to return the correct value")
+	}
+	if tryCatchStmt.EndLine() != 4 {
+		t.Error("EndLine failed to return the correct value with a catch clause")
+	}
+	if tryCatchFinallyStmt.EndLine() != 6 {
+		t.Error("EndLine failed to return the correct value with a finally clause")
+	}
+}
+
+func TestTryStmt_stmtNode(t *testing.T) {
+	tryStmt.stmtNode()
+}
+
+var throwStmt = ThrowStmt{
+	ThrowToken: lexer.Token{
+		TokenType:   lexer.TEXT,
+		Value:       "throw",
+		Position:    1,
+		Line:        1,
+		EndPosition: 6,
+		EndLine:     1,
+	},
+	Value: Literal{
+		Token: lexer.Token{
+			TokenType:   lexer.DQUOTE,
+			Value:       "error",
+			Position:    7,
+			Line:        2,
+			EndPosition: 12,
+			EndLine:     2,
+		},
+		Type:  lexer.STRING,
+		Value: "error",
+	},
+}
+
+func TestThrowStmt_StartPos(t *testing.T) {
+	if throwStmt.StartPos() != 1 {
+		t.Error("StartPos failed to return the correct value")
+	}
+}
+
+func TestThrowStmt_EndPos(t *testing.T) {
+	if throwStmt.EndPos() != throwStmt.Value.EndPos() {
+		t.Error("EndPos failed to return the correct value")
+	}
+}
+
+func TestThrowStmt_StartLine(t *testing.T) {
+	if throwStmt.StartLine() != 1 {
+		t.Error("StartLine failed to return the correct value")
+	}
+}
+
+func TestThrowStmt_EndLine(t *testing.T) {
+	if throwStmt.EndLine() != 2 {
+		t.Error("EndLine failed to return the correct value")
+	}
+}
+
+func TestThrowStmt_stmtNode(t *testing.T) {
+	throwStmt.stmtNode()
+}
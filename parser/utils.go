@@ -22,3 +22,36 @@ type StructField struct {
 	Name string
 	Type string
 }
+
+// span is the code between two positions, an error raised on it is reported from its start to its end.
+type span struct {
+	startLine, startCol, endLine, endCol int
+}
+
+// tokenSpan returns the span of the token.
+func tokenSpan(token lexer.Token) span {
+	return span{startLine: token.Line, startCol: token.Position, endLine: token.EndLine, endCol: token.EndPosition}
+}
+
+func (s span) StartLine() int {
+	return s.startLine
+}
+
+func (s span) StartPos() int {
+	return s.startCol
+}
+
+func (s span) EndLine() int {
+	return s.endLine
+}
+
+func (s span) EndPos() int {
+	return s.endCol
+}
+
+// quotedToken returns the token of the value of a string or of a char spanning its quotes, from left to right.
+func quotedToken(value, left, right lexer.Token) lexer.Token {
+	value.Position, value.Line, value.Offset = left.Position, left.Line, left.Offset
+	value.EndPosition, value.EndLine, value.EndOffset = right.EndPosition, right.EndLine, right.EndOffset
+	return value
+}